@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// adaptiveStableChecks is how many consecutive passing checks at the current interval
+// are needed before an adaptive monitor's interval is lengthened
+const adaptiveStableChecks = 10
+
+// validateAdaptiveBounds checks a monitor's adaptive interval bounds (0 means use the default)
+func validateAdaptiveBounds(minInterval, maxInterval int) error {
+	for _, bound := range []int{minInterval, maxInterval} {
+		if bound != 0 && (bound < MinCheckInterval || bound > MaxCheckInterval) {
+			return fmt.Errorf("adaptive interval bounds must be between %d and %d seconds", MinCheckInterval, MaxCheckInterval)
+		}
+	}
+	if minInterval > 0 && maxInterval > 0 && minInterval > maxInterval {
+		return fmt.Errorf("minAdaptiveInterval must not exceed maxAdaptiveInterval")
+	}
+	return nil
+}
+
+// scheduledInterval returns the interval the scheduler should use for the monitor
+func (m *Monitor) scheduledInterval() int {
+	if m.AdaptiveInterval && m.EffectiveInterval > 0 {
+		return m.EffectiveInterval
+	}
+	if m.CheckInterval <= 0 {
+		return DefaultCheckInterval
+	}
+	return m.CheckInterval
+}
+
+// adaptiveBounds returns the monitor's adaptive interval range
+// Defaults to a quarter and four times the configured base interval
+func (m *Monitor) adaptiveBounds() (int, int) {
+	base := m.CheckInterval
+	if base <= 0 {
+		base = DefaultCheckInterval
+	}
+
+	minInterval := m.MinAdaptiveInterval
+	if minInterval <= 0 {
+		minInterval = max(base/4, MinCheckInterval)
+	}
+	maxInterval := m.MaxAdaptiveInterval
+	if maxInterval <= 0 {
+		maxInterval = min(base*4, MaxCheckInterval)
+	}
+	if maxInterval < minInterval {
+		maxInterval = minInterval
+	}
+	return minInterval, maxInterval
+}
+
+// nextAdaptiveInterval decides the monitor's next interval after a check
+// A failure drops straight to the minimum; a long run of passing checks doubles it up to the maximum
+func nextAdaptiveInterval(monitor *Monitor, status string, now time.Time) int {
+	minInterval, maxInterval := monitor.adaptiveBounds()
+	current := monitor.scheduledInterval()
+
+	if status != "up" {
+		return minInterval
+	}
+	if current < minInterval {
+		return minInterval
+	}
+	if current >= maxInterval {
+		return maxInterval
+	}
+
+	since := monitor.CreatedAt
+	if monitor.EffectiveIntervalSince != nil {
+		since = *monitor.EffectiveIntervalSince
+	}
+	if now.Sub(since) < time.Duration(adaptiveStableChecks*current)*time.Second {
+		return current
+	}
+
+	var checks, failures int64
+	db.Model(&CheckHistory{}).Where("monitor_id = ? AND created_at >= ?", monitor.ID, since).Count(&checks)
+	db.Model(&CheckHistory{}).Where("monitor_id = ? AND created_at >= ? AND status <> ?", monitor.ID, since, "up").Count(&failures)
+	if checks < adaptiveStableChecks || failures > 0 {
+		return current
+	}
+
+	return min(current*2, maxInterval)
+}
+
+// updateAdaptiveInterval applies the next adaptive interval and reschedules the monitor when it changes
+func updateAdaptiveInterval(monitor *Monitor, status string) {
+	if !monitor.AdaptiveInterval {
+		return
+	}
+
+	now := time.Now()
+	current := monitor.scheduledInterval()
+	next := nextAdaptiveInterval(monitor, status, now)
+	if next == current {
+		return
+	}
+
+	if err := db.Model(monitor).Updates(map[string]interface{}{
+		"effective_interval":       next,
+		"effective_interval_since": now,
+	}).Error; err != nil {
+		log.Error().Err(err).Uint("monitor_id", monitor.ID).Msg("[Adaptive] Failed to save adaptive interval")
+		return
+	}
+	monitor.EffectiveInterval = next
+	monitor.EffectiveIntervalSince = &now
+
+	log.Info().Uint("monitor_id", monitor.ID).Int("old_interval", current).Int("new_interval", next).
+		Str("status", status).Msg("[Adaptive] Adjusted check interval")
+
+	if err := monitorScheduler.addMonitorJob(monitor); err != nil {
+		log.Error().Err(err).Uint("monitor_id", monitor.ID).Msg("[Adaptive] Failed to reschedule monitor")
+	}
+}
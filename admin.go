@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// requireAPIKey checks the request's API key against API_KEY and writes an error response if it doesn't match
+// The key may be sent as "Authorization: Bearer <key>" or "X-API-Key: <key>"
+// Admin endpoints are disabled entirely while API_KEY is unset
+func requireAPIKey(w http.ResponseWriter, r *http.Request) bool {
+	apiKey := os.Getenv("API_KEY")
+	if apiKey == "" {
+		log.Warn().Str("path", r.URL.Path).Msg("[API] ERROR Admin endpoint requested but API_KEY is not configured")
+		http.Error(w, "Admin endpoints are disabled (API_KEY not configured)", http.StatusForbidden)
+		return false
+	}
+
+	if !hasAPIKey(r, apiKey) {
+		log.Warn().Str("path", r.URL.Path).Str("client_ip", clientIP(r)).Msg("[API] ERROR Invalid or missing API key")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+// hasAPIKey reports whether the request carries apiKey, compared in constant time
+func hasAPIKey(r *http.Request, apiKey string) bool {
+	provided := r.Header.Get("X-API-Key")
+	if provided == "" {
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			provided = strings.TrimSpace(token)
+		}
+	}
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(apiKey)) == 1
+}
+
+// requireAPIKeyForWrites wraps a handler so that, while API_KEY is set, every /api request that can
+// change state needs the key. GET, HEAD and CORS preflights (which can't carry the key) stay public,
+// as do the SSE stream and static files; without API_KEY requests pass through unchanged
+// Push heartbeats (/api/heartbeat/<token>) are authenticated by their monitor's token instead
+func requireAPIKeyForWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey := os.Getenv("API_KEY")
+		if apiKey == "" || !strings.HasPrefix(r.URL.Path, "/api/") || strings.HasPrefix(r.URL.Path, "/api/heartbeat/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !hasAPIKey(r, apiKey) {
+			log.Warn().Str("method", r.Method).Str("path", r.URL.Path).Str("client_ip", clientIP(r)).Msg("[API] ERROR Invalid or missing API key")
+			w.Header().Set("WWW-Authenticate", `Bearer realm="nanostatus"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// apiAdminConfigRaw handles GET requests for the raw monitors.yaml the server syncs from
+func apiAdminConfigRaw(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !requireAPIKey(w, r) {
+		return
+	}
+
+	configPath := monitorsConfigPath(getDBPath())
+	response := RawConfigResponse{Path: configPath}
+
+	info, err := os.Stat(configPath)
+	if err == nil {
+		content, readErr := os.ReadFile(configPath)
+		if readErr != nil {
+			log.Error().Err(readErr).Str("config_path", configPath).Msg("[API] ERROR GET /api/admin/config/raw: Failed to read config")
+			http.Error(w, "Failed to read config file", http.StatusInternalServerError)
+			return
+		}
+		response.Exists = true
+		response.LastModified = info.ModTime().UTC().Format(time.RFC3339)
+		response.Size = info.Size()
+		response.Content = string(content)
+	} else if !os.IsNotExist(err) {
+		log.Error().Err(err).Str("config_path", configPath).Msg("[API] ERROR GET /api/admin/config/raw: Failed to stat config")
+		http.Error(w, "Failed to read config file", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info().Str("config_path", configPath).Bool("exists", response.Exists).Msg("[API] GET /api/admin/config/raw")
+	if err := encodeJSONWithCompression(w, r, response); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding raw config")
+	}
+}
+
+// apiAdminLogs streams the server's own log lines (zerolog JSON) over SSE
+// New subscribers first receive the buffered recent lines
+func apiAdminLogs(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("client_ip", clientIP(r)).Msg("[API] Request")
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !requireAPIKey(w, r) {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		log.Error().Msg("[API] ERROR GET /api/admin/logs: ResponseWriter does not support flushing")
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	backlog, lines, ok := logStream.subscribe()
+	if !ok {
+		log.Warn().Msg("[API] ERROR GET /api/admin/logs: Too many log subscribers")
+		http.Error(w, "Too many log stream clients", http.StatusServiceUnavailable)
+		return
+	}
+	defer logStream.unsubscribe(lines)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	// Log lines end with a newline; SSE data fields must not contain one
+	for _, line := range backlog {
+		fmt.Fprintf(w, "data: %s\n\n", bytes.TrimRight(line, "\n"))
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+	startTime := time.Now()
+
+	// Nothing is logged per line here, otherwise each sent line would produce another
+	for {
+		select {
+		case line := <-lines:
+			fmt.Fprintf(w, "data: %s\n\n", bytes.TrimRight(line, "\n"))
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprintf(w, ": keepalive\n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			log.Info().Dur("duration", time.Since(startTime)).Msg("[API] Admin log stream closed")
+			return
+		}
+	}
+}
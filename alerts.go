@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Alert kinds
+const (
+	AlertDown      = "down"
+	AlertRecovered = "recovered"
+)
+
+// AlertManager turns monitor status transitions into alerts
+// Monitors with EscalateAfter only alert once they have stayed down for that long;
+// a recovery before then cancels the pending escalation
+type AlertManager struct {
+	pending   map[uint]*time.Timer // Pending down escalations by monitor ID
+	alerted   map[uint]bool        // Monitors whose down alert has fired (so recovery is alerted too)
+	downSince map[uint]time.Time   // When each down monitor went down, for the recovery alert
+	mu        sync.Mutex
+}
+
+var alertManager = &AlertManager{
+	pending:   make(map[uint]*time.Timer),
+	alerted:   make(map[uint]bool),
+	downSince: make(map[uint]time.Time),
+}
+
+// monitorDown handles a monitor transitioning to down
+func (a *AlertManager) monitorDown(monitor *Monitor) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, exists := a.pending[monitor.ID]; exists || a.alerted[monitor.ID] {
+		return
+	}
+	a.downSince[monitor.ID] = time.Now()
+
+	if monitor.EscalateAfter <= 0 {
+		a.alerted[monitor.ID] = true
+		go sendAlert(AlertDown, *monitor)
+		return
+	}
+
+	monitorID := monitor.ID
+	delay := time.Duration(monitor.EscalateAfter) * time.Second
+	a.pending[monitorID] = time.AfterFunc(delay, func() {
+		a.escalate(monitorID)
+	})
+	log.Info().Uint("monitor_id", monitorID).Dur("escalate_after", delay).Msg("[Alert] Monitor down - escalation pending")
+}
+
+// escalate fires a pending down alert if the monitor is still down
+func (a *AlertManager) escalate(monitorID uint) {
+	a.mu.Lock()
+	if _, exists := a.pending[monitorID]; !exists {
+		a.mu.Unlock()
+		return // Cancelled by a recovery
+	}
+	delete(a.pending, monitorID)
+	a.mu.Unlock()
+
+	var monitor Monitor
+	if err := db.First(&monitor, monitorID).Error; err != nil {
+		log.Debug().Uint("monitor_id", monitorID).Msg("[Alert] Monitor removed before escalation")
+		return
+	}
+	if monitor.Paused || monitor.Status != "down" {
+		log.Debug().Uint("monitor_id", monitorID).Str("status", monitor.Status).Msg("[Alert] Monitor no longer down, skipping escalation")
+		return
+	}
+
+	a.mu.Lock()
+	a.alerted[monitorID] = true
+	a.mu.Unlock()
+	sendAlert(AlertDown, monitor)
+}
+
+// monitorRecovered handles a monitor transitioning out of down
+func (a *AlertManager) monitorRecovered(monitor *Monitor) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	downSince, wasDown := a.downSince[monitor.ID]
+	delete(a.downSince, monitor.ID)
+
+	if timer, exists := a.pending[monitor.ID]; exists {
+		timer.Stop()
+		delete(a.pending, monitor.ID)
+		log.Info().Uint("monitor_id", monitor.ID).Msg("[Alert] Monitor recovered before escalation - alert cancelled")
+		return
+	}
+
+	if a.alerted[monitor.ID] {
+		delete(a.alerted, monitor.ID)
+		now := time.Now()
+		alert := newMonitorAlert(AlertRecovered, *monitor, now)
+		if wasDown {
+			alert.DownSeconds = int64(now.Sub(downSince).Seconds())
+			alert.Message += " after " + formatDowntime(alert.DownSeconds)
+		}
+		go dispatchAlert(alert, *monitor)
+	}
+}
+
+// forget drops the alert state of a monitor that was deleted or paused, cancelling a pending escalation
+// A paused monitor that is resumed while down alerts again, as if it had just gone down
+func (a *AlertManager) forget(monitorID uint) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if timer, exists := a.pending[monitorID]; exists {
+		timer.Stop()
+		delete(a.pending, monitorID)
+	}
+	delete(a.alerted, monitorID)
+	delete(a.downSince, monitorID)
+}
+
+// newMonitorAlert builds the alert sent for a monitor event
+func newMonitorAlert(kind string, monitor Monitor, at time.Time) MonitorAlert {
+	return MonitorAlert{
+		MonitorID: monitor.ID,
+		Name:      monitor.Name,
+		URL:       monitor.URL,
+		Kind:      kind,
+		Status:    monitor.Status,
+		Message:   alertMessage(kind, monitor),
+		Time:      at.UTC().Format(time.RFC3339),
+	}
+}
+
+// alertMessage renders the human-readable text of an alert
+func alertMessage(kind string, monitor Monitor) string {
+	switch kind {
+	case AlertDown:
+		message := fmt.Sprintf("%s is down (%s)", monitor.Name, monitor.URL)
+		if monitor.LastError != "" {
+			message += ": " + monitor.LastError
+		}
+		return message
+	case AlertRecovered:
+		if monitor.Status == StatusDegraded {
+			return fmt.Sprintf("%s has recovered but is degraded (%s)", monitor.Name, monitor.URL)
+		}
+		return fmt.Sprintf("%s has recovered (%s)", monitor.Name, monitor.URL)
+	case AlertRetired:
+		return fmt.Sprintf("%s was retired automatically (%s)", monitor.Name, monitor.URL)
+	case AlertCertificateChanged:
+		return fmt.Sprintf("%s presented a different TLS certificate (%s): SHA-256 %s, previously %s", monitor.Name, monitor.URL, monitor.CertFingerprint, monitor.PreviousCertFingerprint)
+	case AlertChanged:
+		return fmt.Sprintf("%s changed unexpectedly (%s): ETag %q, Last-Modified %q", monitor.Name, monitor.URL, monitor.LastETag, monitor.LastModified)
+	}
+	return fmt.Sprintf("%s: %s (%s)", monitor.Name, kind, monitor.URL)
+}
+
+// sendAlert dispatches an alert for a monitor
+func sendAlert(kind string, monitor Monitor) {
+	dispatchAlert(newMonitorAlert(kind, monitor, time.Now()), monitor)
+}
+
+// dispatchAlert delivers an alert to SSE clients and every configured notifier, unless notifications are silenced
+func dispatchAlert(alert MonitorAlert, monitor Monitor) {
+	if silenced, until := notificationSilence.active(time.Now()); silenced {
+		log.Info().Uint("monitor_id", monitor.ID).Str("kind", alert.Kind).Time("silenced_until", until).Msg("[Alert] Notifications silenced - alert suppressed")
+		return
+	}
+	if until, active := maintenanceUntil(monitor.ID, time.Now()); active {
+		log.Info().Uint("monitor_id", monitor.ID).Str("kind", alert.Kind).Time("maintenance_until", until).Msg("[Alert] Monitor in maintenance - alert suppressed")
+		return
+	}
+
+	log.Warn().Uint("monitor_id", monitor.ID).Str("name", monitor.Name).Str("kind", alert.Kind).Msg("[Alert] Monitor alert")
+	broadcastUpdate("monitor_alert", alert)
+	if len(notifiers) > 0 {
+		go notifyAll(alert, monitor)
+	}
+}
+
+// previewAlert renders the alert a hypothetical event would send, and whether it would be delayed or
+// suppressed, without sending anything
+func previewAlert(kind string, monitor Monitor) NotificationPreview {
+	now := time.Now()
+	switch kind {
+	case AlertDown:
+		monitor.Status = "down"
+	case AlertRecovered:
+		monitor.Status = "up"
+		monitor.LastError = ""
+	case AlertRetired:
+		monitor.Paused = true
+	case AlertChanged:
+		monitor.ValidatorChangedAt = &now
+	case AlertCertificateChanged:
+		monitor.PreviousCertFingerprint = monitor.CertFingerprint
+		monitor.CertFingerprintChangedAt = &now
+	}
+
+	preview := NotificationPreview{
+		Event:    kind,
+		Alert:    newMonitorAlert(kind, monitor, now),
+		Channels: notificationChannels(),
+	}
+	if kind == AlertDown {
+		preview.EscalateAfter = monitor.EscalateAfter
+	}
+	if silenced, until := notificationSilence.active(now); silenced {
+		formatted := until.UTC().Format(time.RFC3339)
+		preview.Suppressed = true
+		preview.SuppressedUntil = &formatted
+	} else if until, active := maintenanceUntil(monitor.ID, now); active {
+		formatted := until.UTC().Format(time.RFC3339)
+		preview.Suppressed = true
+		preview.SuppressedUntil = &formatted
+	}
+	return preview
+}
+
+// apiNotificationPreview handles GET requests rendering the alert a monitor event would send, without sending it
+func apiNotificationPreview(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	event := r.URL.Query().Get("event")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", id).Str("event", event).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id == "" {
+		log.Warn().Msg("[API] ERROR GET /api/monitor/notification-preview: Missing monitor ID")
+		http.Error(w, "Monitor ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if event == "" {
+		event = AlertDown
+	}
+	switch event {
+	case AlertDown, AlertRecovered, AlertRetired, AlertChanged, AlertCertificateChanged:
+	default:
+		log.Warn().Str("event", event).Msg("[API] ERROR GET /api/monitor/notification-preview: Invalid event")
+		http.Error(w, "event must be down, recovered, retired, changed, or certificate_changed", http.StatusBadRequest)
+		return
+	}
+
+	var monitor Monitor
+	if err := db.First(&monitor, id).Error; err != nil {
+		log.Warn().Str("id", id).Err(err).Msg("[API] ERROR GET /api/monitor/notification-preview: Monitor not found")
+		http.Error(w, "Monitor not found", http.StatusNotFound)
+		return
+	}
+
+	preview := previewAlert(event, monitor)
+	log.Info().Str("id", id).Str("event", event).Bool("suppressed", preview.Suppressed).Msg("[API] GET /api/monitor/notification-preview")
+	if err := encodeJSONWithCompression(w, r, preview); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding notification preview")
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAlertManagerForget(t *testing.T) {
+	a := &AlertManager{
+		pending:   make(map[uint]*time.Timer),
+		alerted:   make(map[uint]bool),
+		downSince: make(map[uint]time.Time),
+	}
+
+	// One monitor with an escalation pending, one whose down alert already fired
+	a.monitorDown(&Monitor{ID: 1, EscalateAfter: 3600})
+	a.alerted[2] = true
+	a.downSince[2] = time.Now()
+	if _, pending := a.pending[1]; !pending {
+		t.Fatal("no escalation pending for monitor 1")
+	}
+
+	for _, id := range []uint{1, 2} {
+		a.forget(id)
+		if _, pending := a.pending[id]; pending || a.alerted[id] {
+			t.Errorf("monitor %d: alert state kept after forget", id)
+		}
+		if _, down := a.downSince[id]; down {
+			t.Errorf("monitor %d: downSince kept after forget", id)
+		}
+	}
+
+	// Going down again after being forgotten starts a fresh escalation
+	a.monitorDown(&Monitor{ID: 1, EscalateAfter: 3600})
+	if _, pending := a.pending[1]; !pending {
+		t.Error("no escalation pending after the monitor went down again")
+	}
+	a.forget(1)
+}
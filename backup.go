@@ -0,0 +1,437 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Full backups are a single JSON document:
+//
+//	{"format": "nanostatus-backup", "version": 1, "exportedAt": "...",
+//	 "monitors": [...], "settings": [...], "versionChanges": [...], "validatorChanges": [...],
+//	 "statsSnapshots": [...], "buckets": [...], "history": [...]}
+//
+// Sections are written and read one row at a time, so large histories never sit in memory.
+// Rows other than monitors use their storage field names (e.g. "MonitorID").
+const (
+	BackupFormat        = "nanostatus-backup"
+	BackupFormatVersion = 1
+	backupBatchSize     = 500
+)
+
+// Import modes
+const (
+	ImportModeMerge   = "merge"   // Add monitors not present yet (by name and URL) along with their history
+	ImportModeReplace = "replace" // Delete everything, then restore the backup as-is
+)
+
+// ImportResponse reports what a full import restored
+type ImportResponse struct {
+	Mode     string         `json:"mode"`
+	Version  int            `json:"version"`
+	Imported map[string]int `json:"imported"` // Rows restored per section
+	Skipped  map[string]int `json:"skipped"`  // Rows left out per section (merge mode, or history of unknown monitors)
+}
+
+// writeBackupSection streams one table as a JSON array member of the backup document
+func writeBackupSection[T any](out *bufio.Writer, name string, order string) (int, error) {
+	fmt.Fprintf(out, ",\n%q: [", name)
+	count := 0
+	var batch []T
+	result := db.Order(order).FindInBatches(&batch, backupBatchSize, func(tx *gorm.DB, _ int) error {
+		for i := range batch {
+			data, err := json.Marshal(batch[i])
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				out.WriteByte(',')
+			}
+			out.WriteString("\n")
+			out.Write(data)
+			count++
+		}
+		return nil
+	})
+	if result.Error != nil {
+		return count, result.Error
+	}
+	out.WriteString("]")
+	return count, nil
+}
+
+// writeBackup streams the full database state as a backup document
+func writeBackup(w io.Writer) (map[string]int, error) {
+	out := bufio.NewWriterSize(w, 64*1024)
+	fmt.Fprintf(out, "{%q: %q, %q: %d, %q: %q", "format", BackupFormat, "version", BackupFormatVersion,
+		"exportedAt", time.Now().UTC().Format(time.RFC3339))
+
+	counts := make(map[string]int)
+	sections := []struct {
+		name  string
+		write func() (int, error)
+	}{
+		{"monitors", func() (int, error) { return writeBackupSection[Monitor](out, "monitors", "id") }},
+		{"preCheckSecrets", func() (int, error) { return writeBackupSection[PreCheckSecrets](out, "preCheckSecrets", "id") }},
+		{"settings", func() (int, error) { return writeBackupSection[Setting](out, "settings", "key") }},
+		{"versionChanges", func() (int, error) { return writeBackupSection[VersionChange](out, "versionChanges", "id") }},
+		{"validatorChanges", func() (int, error) { return writeBackupSection[ValidatorChange](out, "validatorChanges", "id") }},
+		{"statsSnapshots", func() (int, error) { return writeBackupSection[StatsSnapshot](out, "statsSnapshots", "id") }},
+		{"buckets", func() (int, error) { return writeBackupSection[CheckHistoryBucket](out, "buckets", "id") }},
+		{"history", func() (int, error) { return writeBackupSection[CheckHistory](out, "history", "id") }},
+	}
+	for _, section := range sections {
+		count, err := section.write()
+		counts[section.name] = count
+		if err != nil {
+			return counts, fmt.Errorf("failed to export %s: %w", section.name, err)
+		}
+	}
+
+	out.WriteString("\n}\n")
+	return counts, out.Flush()
+}
+
+// backupImporter restores a backup document inside one transaction
+type backupImporter struct {
+	tx       *gorm.DB
+	mode     string
+	version  int
+	monitors map[uint]uint // Backup monitor ID -> local ID (0 when the monitor was skipped)
+	response ImportResponse
+}
+
+// importSection decodes a JSON array one row at a time, handing each row to add
+func importSection[T any](decoder *json.Decoder, add func(*T) (bool, error)) (int, int, error) {
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return 0, 0, fmt.Errorf("expected an array")
+	}
+	imported, skipped := 0, 0
+	for decoder.More() {
+		var row T
+		if err := decoder.Decode(&row); err != nil {
+			return imported, skipped, err
+		}
+		added, err := add(&row)
+		if err != nil {
+			return imported, skipped, err
+		}
+		if added {
+			imported++
+		} else {
+			skipped++
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return imported, skipped, err
+	}
+	return imported, skipped, nil
+}
+
+// batchInserter buffers rows and inserts them in batches
+type batchInserter[T any] struct {
+	tx   *gorm.DB
+	rows []T
+}
+
+func (b *batchInserter[T]) add(row T) error {
+	b.rows = append(b.rows, row)
+	if len(b.rows) >= backupBatchSize {
+		return b.flush()
+	}
+	return nil
+}
+
+func (b *batchInserter[T]) flush() error {
+	if len(b.rows) == 0 {
+		return nil
+	}
+	err := b.tx.CreateInBatches(b.rows, backupBatchSize).Error
+	b.rows = b.rows[:0]
+	return err
+}
+
+// localMonitorID maps a backup monitor ID to the local one, reporting whether its rows should be imported
+func (imp *backupImporter) localMonitorID(backupID uint) (uint, bool) {
+	localID, exists := imp.monitors[backupID]
+	return localID, exists && localID != 0
+}
+
+// importMonitor restores one monitor; in merge mode monitors already present (by name and URL) are kept as they are
+func (imp *backupImporter) importMonitor(monitor *Monitor) (bool, error) {
+	backupID := monitor.ID
+	if imp.mode == ImportModeMerge {
+		var existing Monitor
+		if err := imp.tx.Where("name = ? AND url = ?", monitor.Name, monitor.URL).Limit(1).Find(&existing).Error; err != nil {
+			return false, err
+		}
+		if existing.ID != 0 {
+			imp.monitors[backupID] = 0
+			return false, nil
+		}
+		monitor.ID = 0
+	}
+	if err := imp.tx.Create(monitor).Error; err != nil {
+		return false, fmt.Errorf("monitor %q: %w", monitor.Name, err)
+	}
+	imp.monitors[backupID] = monitor.ID
+	return true, nil
+}
+
+// importSetting restores a setting, overwriting one with the same key
+func (imp *backupImporter) importSetting(setting *Setting) (bool, error) {
+	return true, imp.tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(setting).Error
+}
+
+// run reads the backup document and restores each section
+func (imp *backupImporter) run(body io.Reader) error {
+	decoder := json.NewDecoder(bufio.NewReaderSize(body, 64*1024))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return fmt.Errorf("backup must be a JSON object")
+	}
+
+	format := ""
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+
+		switch key {
+		case "format":
+			if err := decoder.Decode(&format); err != nil || format != BackupFormat {
+				return fmt.Errorf("not a %s document", BackupFormat)
+			}
+			continue
+		case "version":
+			if err := decoder.Decode(&imp.version); err != nil {
+				return fmt.Errorf("invalid version")
+			}
+			if imp.version < 1 || imp.version > BackupFormatVersion {
+				return fmt.Errorf("unsupported backup version %d (this server reads up to %d)", imp.version, BackupFormatVersion)
+			}
+			continue
+		case "exportedAt":
+			var ignored string
+			decoder.Decode(&ignored)
+			continue
+		}
+
+		// Data sections may only follow the header, so the version is known before anything is written
+		if format == "" || imp.version == 0 {
+			return fmt.Errorf("format and version must come before the %q section", key)
+		}
+
+		var imported, skipped int
+		known := true
+		switch key {
+		case "monitors":
+			imported, skipped, err = importSection(decoder, imp.importMonitor)
+		case "preCheckSecrets":
+			// Monitor JSON leaves the pre-check credentials out, so they follow in their own section
+			imported, skipped, err = importSection(decoder, func(secrets *PreCheckSecrets) (bool, error) {
+				localID, ok := imp.localMonitorID(secrets.MonitorID)
+				if !ok || (secrets.PreCheckBody == "" && secrets.PreCheckInject == "") {
+					return false, nil
+				}
+				return true, imp.tx.Model(&Monitor{}).Where("id = ?", localID).
+					Updates(map[string]interface{}{"pre_check_body": secrets.PreCheckBody, "pre_check_inject": secrets.PreCheckInject}).Error
+			})
+		case "settings":
+			imported, skipped, err = importSection(decoder, imp.importSetting)
+		case "versionChanges":
+			inserter := &batchInserter[VersionChange]{tx: imp.tx}
+			imported, skipped, err = importSection(decoder, func(change *VersionChange) (bool, error) {
+				localID, ok := imp.localMonitorID(change.MonitorID)
+				if !ok {
+					return false, nil
+				}
+				change.ID, change.MonitorID = 0, localID
+				return true, inserter.add(*change)
+			})
+			if err == nil {
+				err = inserter.flush()
+			}
+		case "validatorChanges":
+			inserter := &batchInserter[ValidatorChange]{tx: imp.tx}
+			imported, skipped, err = importSection(decoder, func(change *ValidatorChange) (bool, error) {
+				localID, ok := imp.localMonitorID(change.MonitorID)
+				if !ok {
+					return false, nil
+				}
+				change.ID, change.MonitorID = 0, localID
+				return true, inserter.add(*change)
+			})
+			if err == nil {
+				err = inserter.flush()
+			}
+		case "statsSnapshots":
+			inserter := &batchInserter[StatsSnapshot]{tx: imp.tx}
+			imported, skipped, err = importSection(decoder, func(snapshot *StatsSnapshot) (bool, error) {
+				// Snapshots describe the whole instance, so merging them into another one would be misleading
+				if imp.mode == ImportModeMerge {
+					return false, nil
+				}
+				snapshot.ID = 0
+				return true, inserter.add(*snapshot)
+			})
+			if err == nil {
+				err = inserter.flush()
+			}
+		case "buckets":
+			inserter := &batchInserter[CheckHistoryBucket]{tx: imp.tx}
+			imported, skipped, err = importSection(decoder, func(bucket *CheckHistoryBucket) (bool, error) {
+				localID, ok := imp.localMonitorID(bucket.MonitorID)
+				if !ok {
+					return false, nil
+				}
+				bucket.ID, bucket.MonitorID = 0, localID
+				return true, inserter.add(*bucket)
+			})
+			if err == nil {
+				err = inserter.flush()
+			}
+		case "history":
+			inserter := &batchInserter[CheckHistory]{tx: imp.tx}
+			imported, skipped, err = importSection(decoder, func(check *CheckHistory) (bool, error) {
+				localID, ok := imp.localMonitorID(check.MonitorID)
+				if !ok {
+					return false, nil
+				}
+				check.ID, check.MonitorID = 0, localID
+				return true, inserter.add(*check)
+			})
+			if err == nil {
+				err = inserter.flush()
+			}
+		default:
+			// Sections added by newer minor revisions are skipped
+			known = false
+			var ignored json.RawMessage
+			err = decoder.Decode(&ignored)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", key, err)
+		}
+		if known {
+			imp.response.Imported[key] += imported
+			imp.response.Skipped[key] += skipped
+		}
+	}
+
+	if format == "" || imp.version == 0 {
+		return fmt.Errorf("backup has no format or version")
+	}
+	return nil
+}
+
+// clearDatabase deletes all rows restored by a full import
+func clearDatabase(tx *gorm.DB) error {
+	for _, table := range []string{"check_histories", "check_history_buckets", "version_changes", "validator_changes", "stats_snapshots", "settings", "monitors"} {
+		if err := tx.Exec("DELETE FROM " + table).Error; err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// apiAdminExportFull handles GET requests streaming a full backup of the database as JSON
+func apiAdminExportFull(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !requireAPIKey(w, r) {
+		return
+	}
+
+	filename := fmt.Sprintf("nanostatus-backup-%s.json", time.Now().UTC().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+
+	// Headers are already sent once streaming starts, so a failure can only cut the document short
+	counts, err := writeBackup(w)
+	if err != nil {
+		log.Error().Err(err).Msg("[API] ERROR GET /api/admin/export/full: Backup incomplete")
+		return
+	}
+
+	log.Info().Int("monitors", counts["monitors"]).Int("history", counts["history"]).Int("buckets", counts["buckets"]).
+		Msg("[API] GET /api/admin/export/full: Exported full backup")
+}
+
+// apiAdminImportFull handles POST requests restoring a full backup (?mode=merge or ?mode=replace)
+func apiAdminImportFull(w http.ResponseWriter, r *http.Request) {
+	mode := r.URL.Query().Get("mode")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("mode", mode).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodPost {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !requireAPIKey(w, r) {
+		return
+	}
+
+	if mode != ImportModeMerge && mode != ImportModeReplace {
+		log.Warn().Str("mode", mode).Msg("[API] ERROR POST /api/admin/import/full: Invalid mode")
+		http.Error(w, "mode must be merge or replace", http.StatusBadRequest)
+		return
+	}
+
+	importer := &backupImporter{
+		mode:     mode,
+		monitors: make(map[uint]uint),
+		response: ImportResponse{Mode: mode, Imported: make(map[string]int), Skipped: make(map[string]int)},
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		importer.tx = tx
+		if mode == ImportModeReplace {
+			if err := clearDatabase(tx); err != nil {
+				return err
+			}
+		}
+		return importer.run(r.Body)
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("mode", mode).Msg("[API] ERROR POST /api/admin/import/full: Import failed, nothing was changed")
+		http.Error(w, "Import failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	importer.response.Version = importer.version
+
+	// Pick up the restored monitors and settings
+	if mode == ImportModeReplace {
+		notificationSilence.mu.Lock()
+		notificationSilence.until = time.Time{}
+		notificationSilence.mu.Unlock()
+	}
+	loadNotificationSilence()
+	// Backups don't carry heartbeat tokens, so restored push monitors get new ones
+	ensureHeartbeatTokens()
+	go monitorScheduler.refreshScheduler()
+	broadcastStatsIfChanged()
+
+	log.Info().Str("mode", mode).Int("monitors", importer.response.Imported["monitors"]).
+		Int("history", importer.response.Imported["history"]).Msg("[API] POST /api/admin/import/full: Imported full backup")
+	if err := encodeJSONWithCompression(w, r, importer.response); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding import response")
+	}
+}
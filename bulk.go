@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// maxBulkCreateMonitors caps how many monitors a single bulk create request may contain
+const maxBulkCreateMonitors = 500
+
+// BulkCreateError reports why one monitor of a bulk create request was rejected
+type BulkCreateError struct {
+	Index int    `json:"index"` // Position in the request array
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// BulkCreateResponse reports the outcome of a bulk create request
+type BulkCreateResponse struct {
+	Created []Monitor         `json:"created"`
+	Failed  []BulkCreateError `json:"failed"`
+}
+
+// apiBulkCreateMonitors handles POST requests creating many monitors from a JSON array of create requests
+// It is best-effort: monitors that fail validation are reported and skipped, and the valid ones are inserted
+// in a single transaction (a database error rolls back the whole batch). The status is 201 when every
+// monitor was created and 207 when any was rejected
+func apiBulkCreateMonitors(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodPost {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Error().Err(err).Msg("[API] ERROR POST /api/monitors/bulk: Failed to read request body")
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+	var requests []CreateMonitorRequest
+	if err := json.Unmarshal(bodyBytes, &requests); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR POST /api/monitors/bulk: Invalid request body")
+		http.Error(w, "Request body must be a JSON array of monitors", http.StatusBadRequest)
+		return
+	}
+	if len(requests) == 0 || len(requests) > maxBulkCreateMonitors {
+		log.Warn().Int("monitors", len(requests)).Msg("[API] ERROR POST /api/monitors/bulk: Invalid number of monitors")
+		http.Error(w, fmt.Sprintf("between 1 and %d monitors can be created at once", maxBulkCreateMonitors), http.StatusBadRequest)
+		return
+	}
+
+	response := BulkCreateResponse{Created: []Monitor{}, Failed: []BulkCreateError{}}
+	monitors := make([]Monitor, 0, len(requests))
+	batchNames := make(map[string]bool, len(requests))
+	for i := range requests {
+		req := &requests[i]
+		if err := validateMonitorRequest(req); err != nil {
+			response.Failed = append(response.Failed, BulkCreateError{Index: i, Name: req.Name, Error: err.Error()})
+			continue
+		}
+		nameKey := strings.ToLower(req.Name)
+		if enforceUniqueNames && (batchNames[nameKey] || monitorNameTaken(req.Name, 0)) {
+			response.Failed = append(response.Failed, BulkCreateError{Index: i, Name: req.Name, Error: "A monitor with this name already exists"})
+			continue
+		}
+		batchNames[nameKey] = true
+
+		monitor := Monitor{
+			Status:        "unknown",
+			Uptime:        0,
+			ResponseTime:  0,
+			LastCheck:     "never",
+			CheckInterval: DefaultCheckInterval,
+		}
+		applyMonitorRequest(&monitor, req)
+		monitors = append(monitors, monitor)
+	}
+
+	if len(monitors) > 0 {
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for i := range monitors {
+				if err := tx.Create(&monitors[i]).Error; err != nil {
+					return fmt.Errorf("monitor %q: %w", monitors[i].Name, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("[API] ERROR POST /api/monitors/bulk: Failed to create monitors")
+			http.Error(w, "Failed to create monitors", http.StatusInternalServerError)
+			return
+		}
+		response.Created = monitors
+
+		// Start tickers for the new monitors once, then check each immediately
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			monitorScheduler.refreshScheduler()
+		}()
+		for i := range monitors {
+			go checkService(&monitors[i])
+			broadcastUpdate("monitor_added", monitors[i])
+		}
+		broadcastStatsIfChanged()
+	}
+
+	log.Info().Int("created", len(response.Created)).Int("failed", len(response.Failed)).
+		Msg("[API] POST /api/monitors/bulk: Created monitors")
+	status := http.StatusCreated
+	if len(response.Failed) > 0 {
+		status = http.StatusMultiStatus
+	}
+	if err := encodeJSONWithStatus(w, r, status, response); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding bulk create response")
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Certificate warnings flagged on HTTPS checks
+const (
+	CertWarningUntrusted        = "untrusted"
+	CertWarningHostnameMismatch = "hostname_mismatch"
+	CertWarningExpired          = "expired"
+	CertWarningExpiring         = "expiring"
+	CertWarningWeakKey          = "weak_key"
+	CertWarningWeakSignature    = "weak_signature"
+	CertWarningSelfSigned       = "self_signed"
+)
+
+// AlertCertificateChanged is sent when a monitor with WatchCertificate is presented a different leaf certificate
+const AlertCertificateChanged = "certificate_changed"
+
+// DefaultCertExpiryWarningDays is how close to expiry a certificate gets flagged as expiring
+const DefaultCertExpiryWarningDays = 14
+
+var certExpiryWarningDays = DefaultCertExpiryWarningDays
+
+// initCertificatePolicy configures certificate flagging from CERT_EXPIRY_WARNING_DAYS
+func initCertificatePolicy() {
+	certExpiryWarningDays = getEnvInt("CERT_EXPIRY_WARNING_DAYS", DefaultCertExpiryWarningDays)
+	if certExpiryWarningDays < 0 {
+		log.Warn().Int("days", certExpiryWarningDays).Msg("[Config] Invalid CERT_EXPIRY_WARNING_DAYS, using default")
+		certExpiryWarningDays = DefaultCertExpiryWarningDays
+	}
+}
+
+// GormDataType stores the certificate details as text
+func (*CertificateInfo) GormDataType() string {
+	return "text"
+}
+
+// Value implements driver.Valuer
+func (c *CertificateInfo) Value() (driver.Value, error) {
+	return marshalJSONColumn(c, c == nil)
+}
+
+// Scan implements sql.Scanner
+func (c *CertificateInfo) Scan(value interface{}) error {
+	*c = CertificateInfo{}
+	return unmarshalJSONColumn(value, c)
+}
+
+// certificateFromState describes the certificate of a completed HTTPS request
+func certificateFromState(state *tls.ConnectionState, host string) *CertificateInfo {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	return describeCertificate(state.PeerCertificates, host, nil)
+}
+
+// certificateFromError describes the certificate of a request that failed verification (nil for other errors)
+func certificateFromError(err error, host string) *CertificateInfo {
+	var verifyErr *tls.CertificateVerificationError
+	if !errors.As(err, &verifyErr) || len(verifyErr.UnverifiedCertificates) == 0 {
+		return nil
+	}
+	return describeCertificate(verifyErr.UnverifiedCertificates, host, verifyErr.Err)
+}
+
+// describeCertificate builds the details of a presented chain; trust and hostname are checked
+// separately so both problems are reported even though verification stops at the first
+func describeCertificate(chain []*x509.Certificate, host string, verifyErr error) *CertificateInfo {
+	leaf := chain[0]
+	now := time.Now()
+
+	info := &CertificateInfo{
+		Subject:            leaf.Subject.String(),
+		Issuer:             leaf.Issuer.String(),
+		SANs:               append([]string{}, leaf.DNSNames...),
+		SerialNumber:       leaf.SerialNumber.Text(16),
+		Fingerprint:        certificateFingerprint(leaf),
+		NotBefore:          leaf.NotBefore,
+		NotAfter:           leaf.NotAfter,
+		SignatureAlgorithm: leaf.SignatureAlgorithm.String(),
+		ChainLength:        len(chain),
+		HostnameMatch:      leaf.VerifyHostname(host) == nil,
+		CheckedAt:          now.UTC(),
+	}
+	for _, ip := range leaf.IPAddresses {
+		info.SANs = append(info.SANs, ip.String())
+	}
+	info.KeyAlgorithm, info.KeyBits = certificateKey(leaf)
+	if verifyErr != nil {
+		info.VerifyError = errorMessage(verifyErr)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := leaf.Verify(x509.VerifyOptions{Intermediates: intermediates, CurrentTime: now})
+	info.Trusted = err == nil
+
+	if !info.Trusted {
+		info.Warnings = append(info.Warnings, CertWarningUntrusted)
+	}
+	if !info.HostnameMatch {
+		info.Warnings = append(info.Warnings, CertWarningHostnameMismatch)
+	}
+	if now.After(leaf.NotAfter) {
+		info.Warnings = append(info.Warnings, CertWarningExpired)
+	} else if leaf.NotAfter.Sub(now) < time.Duration(certExpiryWarningDays)*24*time.Hour {
+		info.Warnings = append(info.Warnings, CertWarningExpiring)
+	}
+	if (info.KeyAlgorithm == "RSA" && info.KeyBits < 2048) || (info.KeyAlgorithm == "ECDSA" && info.KeyBits < 256) {
+		info.Warnings = append(info.Warnings, CertWarningWeakKey)
+	}
+	switch leaf.SignatureAlgorithm {
+	case x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+		info.Warnings = append(info.Warnings, CertWarningWeakSignature)
+	}
+	if leaf.Subject.String() == leaf.Issuer.String() && leaf.CheckSignatureFrom(leaf) == nil {
+		info.Warnings = append(info.Warnings, CertWarningSelfSigned)
+	}
+
+	return info
+}
+
+// certificateFingerprint returns the SHA-256 fingerprint of a certificate as colon-separated hex,
+// the format browsers and openssl x509 -fingerprint show
+func certificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	encoded := strings.ToUpper(hex.EncodeToString(sum[:]))
+	pairs := make([]string, 0, len(sum))
+	for i := 0; i < len(encoded); i += 2 {
+		pairs = append(pairs, encoded[i:i+2])
+	}
+	return strings.Join(pairs, ":")
+}
+
+// trackCertificateFingerprint stores the leaf fingerprint of monitors with WatchCertificate and alerts when
+// it changes, which is either a planned rotation or someone intercepting the connection
+// The first fingerprint seen is the baseline
+func trackCertificateFingerprint(monitor *Monitor, cert *CertificateInfo, updates map[string]interface{}, now time.Time) {
+	if !monitor.WatchCertificate || cert == nil || cert.Fingerprint == "" || cert.Fingerprint == monitor.CertFingerprint {
+		return
+	}
+
+	updates["cert_fingerprint"] = cert.Fingerprint
+	if monitor.CertFingerprint == "" {
+		return
+	}
+
+	log.Warn().Uint("monitor_id", monitor.ID).Str("name", monitor.Name).
+		Str("old_fingerprint", monitor.CertFingerprint).Str("new_fingerprint", cert.Fingerprint).
+		Msg("[Check] TLS certificate changed")
+
+	updates["previous_cert_fingerprint"] = monitor.CertFingerprint
+	updates["cert_fingerprint_changed_at"] = now
+
+	alerted := *monitor
+	alerted.PreviousCertFingerprint = monitor.CertFingerprint
+	alerted.CertFingerprint = cert.Fingerprint
+	alerted.CertFingerprintChangedAt = &now
+	alerted.Certificate = cert
+	go sendAlert(AlertCertificateChanged, alerted)
+}
+
+// certificateKey returns the algorithm and size of a certificate's public key
+func certificateKey(cert *x509.Certificate) (string, int) {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return "RSA", key.N.BitLen()
+	case *ecdsa.PublicKey:
+		return "ECDSA", key.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return "Ed25519", 256
+	}
+	return cert.PublicKeyAlgorithm.String(), 0
+}
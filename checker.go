@@ -1,11 +1,18 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +24,98 @@ import (
 // Shared HTTP client with connection pooling for health checks
 var httpClient *http.Client
 
+// noRedirectClient shares httpClient's transport but returns 3xx responses as-is
+var noRedirectClient *http.Client
+
+// baseTransport is the shared transport, also cloned for monitors with a SourceIP
+var baseTransport *http.Transport
+
+// Redirect policies control how 3xx responses are classified
+const (
+	RedirectPolicyFollow   = "follow"   // Follow redirects and judge the final response (default)
+	RedirectPolicyRedirect = "redirect" // Report 3xx as a distinct "redirect" status
+	RedirectPolicyDown     = "down"     // Treat 3xx as down
+)
+
+// validateTimeoutSeconds checks a monitor's check timeout (0 or less uses DefaultTimeoutSeconds)
+func validateTimeoutSeconds(timeout int) error {
+	if timeout > MaxTimeoutSeconds {
+		return fmt.Errorf("timeoutSeconds must be at most %d", MaxTimeoutSeconds)
+	}
+	return nil
+}
+
+// validateCheckInterval checks a monitor's check interval (0 uses DefaultCheckInterval)
+func validateCheckInterval(interval int) error {
+	if interval != 0 && (interval < MinCheckInterval || interval > MaxCheckInterval) {
+		return fmt.Errorf("checkInterval must be between %d and %d seconds", MinCheckInterval, MaxCheckInterval)
+	}
+	return nil
+}
+
+// checkTimeout returns how long a single check of the monitor may take, from connecting to reading the body
+func (m *Monitor) checkTimeout() time.Duration {
+	if m.TimeoutSeconds <= 0 {
+		return DefaultTimeoutSeconds * time.Second
+	}
+	return time.Duration(m.TimeoutSeconds) * time.Second
+}
+
+// DefaultMaxRedirects is how many redirects are followed when a monitor sets no MaxRedirects (as net/http)
+const DefaultMaxRedirects = 10
+
+// maxMaxRedirects caps a monitor's MaxRedirects
+const maxMaxRedirects = 50
+
+// errTooManyRedirects is returned by a client's CheckRedirect once a monitor's redirect limit is exceeded
+var errTooManyRedirects = errors.New("too many redirects")
+
+// validateMaxRedirects checks a monitor's redirect limit (0 uses DefaultMaxRedirects)
+func validateMaxRedirects(maxRedirects int) error {
+	if maxRedirects < 0 || maxRedirects > maxMaxRedirects {
+		return fmt.Errorf("maxRedirects must be between 0 and %d", maxMaxRedirects)
+	}
+	return nil
+}
+
+// redirectLimit returns a CheckRedirect func that stops after maxRedirects redirects
+func redirectLimit(maxRedirects int) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > maxRedirects {
+			return fmt.Errorf("%w (more than %d)", errTooManyRedirects, maxRedirects)
+		}
+		return nil
+	}
+}
+
+// followsRedirects reports whether a monitor's checks follow redirects (FollowRedirects defaults to true)
+func (m *Monitor) followsRedirects() bool {
+	return m.FollowRedirects == nil || *m.FollowRedirects
+}
+
+// validateFollowRedirects checks that turning off redirect following doesn't contradict other redirect settings
+func validateFollowRedirects(followRedirects *bool, policy string, maxRedirects int) error {
+	if followRedirects == nil || *followRedirects {
+		return nil
+	}
+	if policy == RedirectPolicyFollow {
+		return fmt.Errorf("followRedirects false contradicts redirectPolicy follow")
+	}
+	if maxRedirects > 0 {
+		return fmt.Errorf("maxRedirects requires followRedirects")
+	}
+	return nil
+}
+
+// isValidRedirectPolicy reports whether policy is empty or a known redirect policy
+func isValidRedirectPolicy(policy string) bool {
+	switch policy {
+	case "", RedirectPolicyFollow, RedirectPolicyRedirect, RedirectPolicyDown:
+		return true
+	}
+	return false
+}
+
 // MonitorScheduler manages monitor jobs using gocron
 type MonitorScheduler struct {
 	scheduler gocron.Scheduler
@@ -38,18 +137,51 @@ func init() {
 			KeepAlive: 30 * time.Second,
 		}).DialContext,
 		TLSHandshakeTimeout:   5 * time.Second,
-		ResponseHeaderTimeout: 10 * time.Second,
 		ExpectContinueTimeout: 1 * time.Second,
 	}
 
+	baseTransport = transport
+
 	// Create shared HTTP client
+	// There is no client-wide timeout: each request carries a context deadline from the monitor's TimeoutSeconds
 	httpClient = &http.Client{
-		Timeout:   10 * time.Second,
+		Transport:     transport,
+		CheckRedirect: redirectLimit(DefaultMaxRedirects),
+	}
+
+	noRedirectClient = &http.Client{
 		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+}
+
+// initMonitorScheduler creates and starts the gocron scheduler for monitor checks
+// SCHEDULER_MAX_CONCURRENT_JOBS bounds how many scheduled checks may run at once (0 = unlimited);
+// SCHEDULER_LIMIT_MODE chooses whether runs over the limit are skipped (reschedule) or queued (wait)
+func initMonitorScheduler() {
+	var options []gocron.SchedulerOption
+
+	maxJobs := getEnvInt("SCHEDULER_MAX_CONCURRENT_JOBS", 0)
+	if maxJobs > 0 {
+		var mode gocron.LimitMode = gocron.LimitModeReschedule
+		modeName := strings.ToLower(os.Getenv("SCHEDULER_LIMIT_MODE"))
+		switch modeName {
+		case "", "reschedule":
+			modeName = "reschedule"
+		case "wait":
+			mode = gocron.LimitModeWait
+		default:
+			log.Warn().Str("mode", modeName).Msg("[Scheduler] Unknown SCHEDULER_LIMIT_MODE, expected reschedule or wait - using reschedule")
+			modeName = "reschedule"
+		}
+		options = append(options, gocron.WithLimitConcurrentJobs(uint(maxJobs), mode))
+		log.Info().Int("max_concurrent_jobs", maxJobs).Str("mode", modeName).Msg("[Scheduler] Limiting concurrent scheduled checks")
 	}
 
-	// Initialize scheduler
-	sched, err := gocron.NewScheduler()
+	sched, err := gocron.NewScheduler(options...)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to create scheduler")
 	}
@@ -65,13 +197,455 @@ func init() {
 	log.Info().Msg("[Scheduler] Started gocron scheduler")
 }
 
+// validateCacheAgeRange checks that a monitor's Age assertion bounds are sensible
+func validateCacheAgeRange(minAge, maxAge int) error {
+	if minAge < 0 || maxAge < 0 {
+		return fmt.Errorf("cache age bounds must not be negative")
+	}
+	if maxAge > 0 && minAge > maxAge {
+		return fmt.Errorf("minCacheAge must not exceed maxCacheAge")
+	}
+	return nil
+}
+
+// readCacheHeaders extracts the Age and Date headers used for cache validation
+func readCacheHeaders(header http.Header) (*int, *time.Time) {
+	var age *int
+	if value := strings.TrimSpace(header.Get("Age")); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed >= 0 {
+			age = &parsed
+		}
+	}
+
+	var date *time.Time
+	if value := header.Get("Date"); value != "" {
+		if parsed, err := http.ParseTime(value); err == nil {
+			date = &parsed
+		}
+	}
+
+	return age, date
+}
+
+// cacheAgeInRange reports whether the Age header satisfies a monitor's bounds
+// A missing Age header only passes when no minimum is required
+func cacheAgeInRange(age *int, minAge, maxAge int) bool {
+	if age == nil {
+		return minAge <= 0
+	}
+	if minAge > 0 && *age < minAge {
+		return false
+	}
+	if maxAge > 0 && *age > maxAge {
+		return false
+	}
+	return true
+}
+
+// Response time modes choose which measurement drives a monitor's responseTime
+const (
+	ResponseTimeModeTTFB  = "ttfb"  // Time to first byte - when the response headers arrived (default)
+	ResponseTimeModeTotal = "total" // Time until the response body was fully read
+)
+
+// maxDrainBodyBytes caps how much of a response body is read to measure the total time
+const maxDrainBodyBytes = 4 << 20
+
+// isValidResponseTimeMode reports whether mode is empty or a known response time mode
+func isValidResponseTimeMode(mode string) bool {
+	return mode == "" || mode == ResponseTimeModeTTFB || mode == ResponseTimeModeTotal
+}
+
+// endpointCheck holds the outcome of checking a single URL
+type endpointCheck struct {
+	EndpointResult
+	RedirectLocation string
+	CacheAge         *int
+	ServerDate       *time.Time
+	Header           http.Header // Response headers (nil if the request failed)
+	SchemaError      string      // JSON Schema violation of the response body (primary URL only)
+	StatusCode       int         // HTTP status code of the response (0 if the request failed)
+	ContentEncoding  string      // Compression of the response body on the wire (empty if uncompressed)
+	Protocol         string      // Negotiated HTTP version, e.g. HTTP/1.1 or HTTP/2.0 (empty if the request failed)
+	TTFB             int         // Milliseconds until the first response byte
+	TotalTime        int         // Milliseconds until the response body was fully read
+	Certificate      *CertificateInfo // Leaf certificate details of HTTPS checks (primary URL only)
+	Label            string      // Value extracted by ExtractLabel (primary URL only)
+	ErrorCategory    string      // Category of the request error (timeout, dns, connection_reset, ...)
+	Error            string      // Request error message, prefixed with its category
+}
+
+// maxEndpoints caps how many additional URLs a single monitor may check
+const maxEndpoints = 10
+
+// validateEndpoints checks a monitor's additional endpoint URLs
+func validateEndpoints(endpoints []string) error {
+	if len(endpoints) > maxEndpoints {
+		return fmt.Errorf("at most %d additional endpoints are allowed", maxEndpoints)
+	}
+	for _, endpoint := range endpoints {
+		if strings.TrimSpace(endpoint) == "" {
+			return fmt.Errorf("endpoints must not be empty")
+		}
+		if err := validateURLScheme(endpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// urlScheme returns the lowercased scheme of a monitor URL, or "" for bare hosts (which default to https)
+func urlScheme(rawURL string) string {
+	scheme, _, found := strings.Cut(strings.TrimSpace(rawURL), "://")
+	if !found {
+		return ""
+	}
+	return strings.ToLower(scheme)
+}
+
+// validateURLScheme rejects URLs whose scheme the checker doesn't understand, e.g. ftp:// or a typo like htps://
+func validateURLScheme(rawURL string) error {
+	scheme := urlScheme(rawURL)
+	if scheme != "" && !slices.Contains(AllowedSchemes, scheme) {
+		return fmt.Errorf("unsupported scheme: %s (supported: %s)", scheme, strings.Join(AllowedSchemes, ", "))
+	}
+	return nil
+}
+
+// worseStatus returns the more severe of two check statuses (down > redirect > degraded > up)
+func worseStatus(a, b string) string {
+	severity := func(status string) int {
+		switch status {
+		case "up":
+			return 0
+		case StatusDegraded:
+			return 1
+		case "redirect":
+			return 2
+		default:
+			return 3
+		}
+	}
+	if severity(b) > severity(a) {
+		return b
+	}
+	return a
+}
+
+// checkEndpoints checks the monitor's primary URL followed by its additional endpoints
+// The primary result is always first; endpoints run concurrently when ParallelEndpoints is set
+func checkEndpoints(monitor *Monitor) []endpointCheck {
+	urls := append([]string{monitor.URL}, monitor.Endpoints...)
+	results := make([]endpointCheck, len(urls))
+
+	// Run the pre-check step first - without its token the real check can't pass
+	inject, err := preCheckHeader(monitor)
+	if err != nil {
+		log.Debug().Err(err).Uint("monitor_id", monitor.ID).Msg("[Check] Pre-check step failed")
+		for i, endpointURL := range urls {
+			results[i] = endpointCheck{EndpointResult: EndpointResult{URL: endpointURL, Status: "down"}}
+			results[i].ErrorCategory = ErrorCategoryPreCheck
+			results[i].Error = ErrorCategoryPreCheck + ": " + err.Error()
+		}
+		return results
+	}
+
+	if monitor.ParallelEndpoints && len(urls) > 1 {
+		var wg sync.WaitGroup
+		for i, endpointURL := range urls {
+			wg.Add(1)
+			go func(i int, endpointURL string) {
+				defer wg.Done()
+				results[i] = checkEndpointWithRetries(monitor, endpointURL, inject)
+			}(i, endpointURL)
+		}
+		wg.Wait()
+		return results
+	}
+
+	for i, endpointURL := range urls {
+		results[i] = checkEndpointWithRetries(monitor, endpointURL, inject)
+	}
+	return results
+}
+
+// checkEndpoint performs a single HTTP check of rawURL using the monitor's settings
+// inject holds extra request headers, such as the token from the monitor's pre-check step
+func checkEndpoint(monitor *Monitor, rawURL string, inject http.Header) endpointCheck {
+	if strings.HasPrefix(rawURL, "udp://") {
+		return checkUDP(monitor, rawURL)
+	}
+	if strings.HasPrefix(rawURL, "dns://") {
+		return checkDNS(monitor, rawURL)
+	}
+	if isWebSocketURL(rawURL) {
+		return checkWebSocket(monitor, rawURL, inject)
+	}
+
+	result := endpointCheck{EndpointResult: EndpointResult{URL: rawURL}}
+	start := time.Now()
+
+	// Monitors stored before schemes were validated would otherwise fail forever without an explanation
+	if err := validateURLScheme(rawURL); err != nil {
+		result.Status = "down"
+		result.ErrorCategory = ErrorCategoryConfig
+		result.Error = ErrorCategoryConfig + ": unsupported scheme: " + urlScheme(rawURL)
+		return result
+	}
+
+	// Parse URL and handle different protocols
+	serviceURL := rawURL
+	if !strings.HasPrefix(serviceURL, "http://") && !strings.HasPrefix(serviceURL, "https://") {
+		if strings.HasPrefix(serviceURL, "ping://") {
+			// For ping, we'll just mark as up for now (would need ping library for real ping)
+			result.Status = "up"
+			result.ResponseTime = 10
+		} else {
+			// Default to https
+			serviceURL = "https://" + serviceURL
+		}
+	}
+
+	// Validate URL
+	parsedURL, err := url.Parse(serviceURL)
+	if err != nil || parsedURL.Host == "" {
+		result.Status = "down"
+		result.ResponseTime = 0
+		result.ErrorCategory = ErrorCategoryConfig
+		result.Error = ErrorCategoryConfig + ": invalid URL"
+		return result
+	}
+
+	// POST and PUT checks send the request body, rendered fresh so nonces and timestamps never repeat
+	method := monitor.checkMethod()
+	var body io.Reader
+	if monitor.RequestBody != "" && (method == http.MethodPost || method == http.MethodPut) {
+		rendered, err := renderRequestBody(monitor)
+		if err != nil {
+			result.Status = "down"
+			result.ErrorCategory = ErrorCategoryOther
+			result.Error = ErrorCategoryOther + ": failed to render request body: " + err.Error()
+			return result
+		}
+		body = strings.NewReader(rendered)
+	}
+
+	// Light checks ask with HEAD first and only download the page when the server rejects HEAD
+	light := monitor.LightCheck && method == http.MethodGet
+	if light {
+		method = http.MethodHead
+	}
+
+	// Make HTTP request; the deadline also covers reading the body below
+	ctx, cancel := context.WithTimeout(context.Background(), monitor.checkTimeout())
+	defer cancel()
+	var firstByte time.Duration
+	newRequest := func(method string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, serviceURL, body)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "NanoStatus/1.0")
+		if body != nil {
+			req.Header.Set("Content-Type", monitor.requestContentType())
+		}
+		req.Header.Set("Cache-Control", "no-cache, no-store, must-revalidate")
+		req.Header.Set("Pragma", "no-cache")
+		req.Header.Set("Expires", "0")
+		for name, values := range inject {
+			req.Header[name] = values
+		}
+
+		// Record when the first response byte arrives (the last response's, when redirects are followed)
+		return req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+			GotFirstResponseByte: func() { firstByte = time.Since(start) },
+		})), nil
+	}
+	req, err := newRequest(method)
+	if err != nil {
+		// The request can't even be built (e.g. a malformed URL), so retrying won't help
+		result.Status = "down"
+		result.ResponseTime = 0
+		result.ErrorCategory = ErrorCategoryConfig
+		result.Error = ErrorCategoryConfig + ": invalid request: " + errorMessage(err)
+		log.Debug().Err(err).Uint("monitor_id", monitor.ID).Str("url", rawURL).Msg("[Check] Failed to build request")
+		return result
+	}
+
+	// Only stop at redirects when the monitor wants 3xx judged on its own: as a distinct status by its redirect
+	// policy, or like any other response (where 3xx is up) when it doesn't follow redirects
+	strictRedirects := monitor.RedirectPolicy == RedirectPolicyRedirect || monitor.RedirectPolicy == RedirectPolicyDown
+	client := clientForMonitor(monitor, strictRedirects || !monitor.followsRedirects())
+	resp, err := client.Do(req)
+
+	// Servers that don't allow HEAD get the GET a regular check would have sent; the response time is the GET's
+	if err == nil && light && headRejected(resp.StatusCode) {
+		resp.Body.Close()
+		log.Debug().Uint("monitor_id", monitor.ID).Str("url", rawURL).Int("status_code", resp.StatusCode).
+			Msg("[Check] HEAD rejected, falling back to GET")
+		if req, err = newRequest(http.MethodGet); err == nil {
+			start, firstByte = time.Now(), 0
+			resp, err = client.Do(req)
+		}
+	}
+
+	if err != nil {
+		result.Status = "down"
+		result.ResponseTime = 0
+		result.setRequestError(err)
+		log.Debug().Err(err).Uint("monitor_id", monitor.ID).Str("url", rawURL).Str("category", result.ErrorCategory).Msg("[Check] Request failed")
+		if rawURL == monitor.URL {
+			result.Certificate = certificateFromError(err, parsedURL.Hostname())
+		}
+		return result
+	}
+
+	var schemaErr, keywordErr error
+	result.StatusCode = resp.StatusCode
+	result.ContentEncoding = responseContentEncoding(resp)
+	result.Protocol = resp.Proto
+	if (monitor.JSONSchema != "" || monitor.ExpectKeyword != "" || monitor.labelFromBody()) && rawURL == monitor.URL {
+		// The assertions and label extraction read the same capped, decoded copy of the body
+		data, err := readDecodedResponseBody(resp, monitor.bodyReadLimit())
+		if monitor.JSONSchema != "" {
+			if err != nil {
+				schemaErr = err
+			} else {
+				schemaErr = validateResponseSchema(monitor.JSONSchema, data)
+			}
+		}
+		if monitor.ExpectKeyword != "" {
+			if err != nil {
+				keywordErr = err
+			} else {
+				keywordErr = checkKeyword(monitor, data)
+			}
+		}
+		if monitor.labelFromBody() && err == nil {
+			result.Label = extractLabel(monitor, resp.Header, data)
+		}
+	} else if monitor.ExtractLabel != "" && rawURL == monitor.URL {
+		result.Label = extractLabel(monitor, resp.Header, nil)
+	}
+	// Read the rest of the body so the total time covers the full transfer (and the connection can be reused)
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBodyBytes))
+	resp.Body.Close()
+	result.TotalTime = int(time.Since(start).Milliseconds())
+	result.TTFB = int(firstByte.Milliseconds())
+	if firstByte == 0 {
+		result.TTFB = result.TotalTime
+	}
+	result.ResponseTime = result.TTFB
+	if monitor.ResponseTimeMode == ResponseTimeModeTotal {
+		result.ResponseTime = result.TotalTime
+	}
+	result.Header = resp.Header
+	if rawURL == monitor.URL {
+		result.Certificate = certificateFromState(resp.TLS, resp.Request.URL.Hostname())
+	}
+
+	// A rejected token is refetched on the next check rather than reused until it expires
+	if inject != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		preCheckCache.invalidate(monitor.ID)
+	}
+	result.CacheAge, result.ServerDate = readCacheHeaders(resp.Header)
+	if outcome, ok := statusOutcome(monitor.StatusOutcomes, resp.StatusCode); ok {
+		result.Status = outcome
+		log.Debug().Uint("monitor_id", monitor.ID).Str("url", rawURL).Int("status_code", resp.StatusCode).
+			Str("outcome", outcome).Msg("[Check] Status code classified by statusOutcomes")
+	} else if monitor.ExpectedStatusCodes != "" {
+		if statusCodeExpected(monitor.ExpectedStatusCodes, resp.StatusCode) {
+			result.Status = "up"
+		} else {
+			result.Status = "down"
+			result.ErrorCategory = ErrorCategoryStatusCode
+			result.Error = fmt.Sprintf("%s: unexpected status code %d (expected %s)", ErrorCategoryStatusCode, resp.StatusCode, monitor.ExpectedStatusCodes)
+			log.Debug().Uint("monitor_id", monitor.ID).Str("url", rawURL).Int("status_code", resp.StatusCode).
+				Str("expected", monitor.ExpectedStatusCodes).Msg("[Check] Unexpected status code")
+		}
+	} else if strictRedirects && resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		result.RedirectLocation = resp.Header.Get("Location")
+		if monitor.RedirectPolicy == RedirectPolicyDown {
+			result.Status = "down"
+			result.ErrorCategory = ErrorCategoryStatusCode
+			result.Error = fmt.Sprintf("%s: HTTP %d redirect to %s", ErrorCategoryStatusCode, resp.StatusCode, result.RedirectLocation)
+		} else {
+			result.Status = "redirect"
+		}
+		log.Debug().Uint("monitor_id", monitor.ID).Str("url", rawURL).Int("status_code", resp.StatusCode).
+			Str("location", result.RedirectLocation).Msg("[Check] Unexpected redirect")
+	} else if resp.StatusCode >= 200 && resp.StatusCode < 400 {
+		result.Status = "up"
+	} else {
+		result.Status = "down"
+		result.ErrorCategory = ErrorCategoryStatusCode
+		result.Error = fmt.Sprintf("%s: HTTP %d %s", ErrorCategoryStatusCode, resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	// Optionally assert the CDN cache is serving within the expected Age range
+	if result.Status == "up" && (monitor.MinCacheAge > 0 || monitor.MaxCacheAge > 0) &&
+		!cacheAgeInRange(result.CacheAge, monitor.MinCacheAge, monitor.MaxCacheAge) {
+		result.Status = "down"
+		log.Debug().Uint("monitor_id", monitor.ID).Interface("age", result.CacheAge).
+			Int("min_age", monitor.MinCacheAge).Int("max_age", monitor.MaxCacheAge).
+			Msg("[Check] Age header outside expected range")
+	}
+
+	if result.Status == "up" && schemaErr != nil {
+		result.Status = "down"
+		result.SchemaError = schemaErr.Error()
+		log.Debug().Uint("monitor_id", monitor.ID).Str("url", rawURL).Err(schemaErr).Msg("[Check] Response failed JSON schema validation")
+	}
+
+	if result.Status == "up" && keywordErr != nil {
+		result.Status = "down"
+		result.ErrorCategory = ErrorCategoryKeyword
+		result.Error = ErrorCategoryKeyword + ": " + keywordErr.Error()
+		log.Debug().Uint("monitor_id", monitor.ID).Str("url", rawURL).Err(keywordErr).Msg("[Check] Response failed keyword assertion")
+	}
+
+	return result
+}
+
+// inGracePeriod reports whether the monitor is still within its post-creation/warming grace period
+func inGracePeriod(monitor *Monitor, now time.Time) bool {
+	if monitor.GracePeriod <= 0 {
+		return false
+	}
+	started := monitor.CreatedAt
+	if monitor.GraceStartedAt != nil {
+		started = *monitor.GraceStartedAt
+	}
+	return now.Before(started.Add(time.Duration(monitor.GracePeriod) * time.Second))
+}
+
+// displayedStatus returns the status shown for a check result: a failure within the grace period, or
+// before FailureThreshold failures in a row, shows as pending. A monitor that is already down stays down,
+// so restarting the grace period (warming) can't fake a recovery
+func displayedStatus(monitor *Monitor, status string, failures int, now time.Time) string {
+	if status != "down" || monitor.Status == "down" {
+		return status
+	}
+	if inGracePeriod(monitor, now) {
+		log.Debug().Uint("monitor_id", monitor.ID).Msg("[Check] Failure within grace period, reporting pending")
+		return "pending"
+	}
+	if failures < monitor.failureThreshold() {
+		log.Debug().Uint("monitor_id", monitor.ID).Int("failures", failures).Int("threshold", monitor.failureThreshold()).
+			Msg("[Check] Failure below threshold, reporting pending")
+		return "pending"
+	}
+	return status
+}
+
 // checkService performs a health check on a single monitor
 // monitorID can be a monitor ID (uint) or a monitor pointer
 func checkService(monitorIDOrPtr interface{}) {
 	var monitor Monitor
 	var monitorID uint
+	heartbeat := false
 	
-	// Handle both monitor ID and monitor pointer
+	// Handle both monitor ID and monitor pointer, or a push monitor's heartbeat
 	switch v := monitorIDOrPtr.(type) {
 	case uint:
 		monitorID = v
@@ -86,6 +660,13 @@ func checkService(monitorIDOrPtr interface{}) {
 			log.Error().Err(err).Uint("monitor_id", monitorID).Msg("Failed to load monitor for check")
 			return
 		}
+	case pushHeartbeat:
+		monitorID = v.monitorID
+		heartbeat = true
+		if err := db.First(&monitor, monitorID).Error; err != nil {
+			log.Error().Err(err).Uint("monitor_id", monitorID).Msg("Failed to load monitor for heartbeat")
+			return
+		}
 	default:
 		log.Error().Interface("type", v).Msg("checkService called with invalid type")
 		return
@@ -96,75 +677,120 @@ func checkService(monitorIDOrPtr interface{}) {
 		log.Debug().Uint("monitor_id", monitorID).Msg("[Check] Skipping check for paused monitor")
 		return
 	}
+
+	// Skip dependent checks while the monitor they depend on is down
+	if dependency, blocked := blockingDependency(&monitor); blocked {
+		skipCheck(&monitor, &dependency)
+		return
+	}
 	
-	log.Debug().Uint("monitor_id", monitorID).Str("url", monitor.URL).Int("interval", monitor.CheckInterval).Msg("[Check] Starting health check")
+	var results []endpointCheck
+	if monitor.isPush() {
+		// Push monitors are never polled: scheduled runs only record a missed heartbeat
+		result, due := checkPush(&monitor, heartbeat, time.Now())
+		if !due {
+			return
+		}
+		results = []endpointCheck{result}
+	} else {
+		// Wait for a check slot - monitors that aren't currently up are critical and bypass load deferral
+		release := checkLimiter.acquire(monitorID, monitor.Status != "up")
+		defer release()
 
-	start := time.Now()
-	var status string
-	var responseTime int
+		log.Debug().Uint("monitor_id", monitorID).Str("url", monitor.URL).Int("interval", monitor.CheckInterval).Msg("[Check] Starting health check")
 
-	// Parse URL and handle different protocols
-	serviceURL := monitor.URL
-	if !strings.HasPrefix(serviceURL, "http://") && !strings.HasPrefix(serviceURL, "https://") {
-		if strings.HasPrefix(serviceURL, "ping://") {
-			// For ping, we'll just mark as up for now (would need ping library for real ping)
-			status = "up"
-			responseTime = 10
-		} else {
-			// Default to https
-			serviceURL = "https://" + serviceURL
-		}
+		// Check the primary URL plus any additional endpoints - all must pass
+		results = checkEndpoints(&monitor)
 	}
+	primary := results[0]
+	status := primary.Status
+	responseTime := primary.ResponseTime
+	redirectLocation := primary.RedirectLocation
+	cacheAge, serverDate := primary.CacheAge, primary.ServerDate
+	header := primary.Header
+	errorCategory, lastError := primary.ErrorCategory, primary.Error
+	ttfb, totalTime := primary.TTFB, primary.TotalTime
+	version := readVersionHeader(&monitor, primary.Header)
 
-	// Validate URL
-	parsedURL, err := url.Parse(serviceURL)
-	if err != nil || parsedURL.Host == "" {
-		status = "down"
-		responseTime = 0
-	} else {
-		// Make HTTP request
-		req, err := http.NewRequest("GET", serviceURL, nil)
-		if err != nil {
-			status = "down"
-			responseTime = 0
-		} else {
-			req.Header.Set("User-Agent", "NanoStatus/1.0")
-			req.Header.Set("Cache-Control", "no-cache, no-store, must-revalidate")
-    		req.Header.Set("Pragma", "no-cache")
-    		req.Header.Set("Expires", "0")
-			//req.URL.RawQuery = fmt.Sprintf("_t=%d", time.Now().UnixNano())
-			resp, err := httpClient.Do(req)
-			elapsed := time.Since(start)
-			responseTime = int(elapsed.Milliseconds())
-
-			if err != nil {
-				status = "down"
-				responseTime = 0
-			} else {
-				resp.Body.Close()
-				if resp.StatusCode >= 200 && resp.StatusCode < 400 {
-					status = "up"
-				} else {
-					status = "down"
-				}
+	var endpointResults EndpointResultList
+	if len(results) > 1 {
+		endpointResults = make(EndpointResultList, 0, len(results))
+		for _, result := range results {
+			endpointResults = append(endpointResults, result.EndpointResult)
+			// Keep the headers of the first failing endpoint for debugging
+			if status == "up" && result.Status != "up" {
+				header = result.Header
+				errorCategory, lastError = result.ErrorCategory, result.Error
+			}
+			status = worseStatus(status, result.Status)
+			// Report the slowest endpoint as the monitor's response time
+			if result.ResponseTime > responseTime {
+				responseTime = result.ResponseTime
 			}
+			ttfb = max(ttfb, result.TTFB)
+			totalTime = max(totalTime, result.TotalTime)
 		}
 	}
 
+	// A passing check slower than the degraded threshold is recorded as degraded
+	if status == "up" && slowCheck(&monitor, responseTime) {
+		status = StatusDegraded
+		log.Debug().Uint("monitor_id", monitorID).Int("response_time_ms", responseTime).
+			Int("threshold_ms", monitor.DegradedThresholdMs).Msg("[Check] Response time above degraded threshold")
+	}
+
+	summaryCounters.record(status, responseTime)
+
+	// During the grace period, and until FailureThreshold failures in a row, failures are recorded in history
+	// but shown as pending
+	previousStatus := monitor.Status
+	failures := consecutiveFailures(&monitor, status)
+	displayStatus := displayedStatus(&monitor, status, failures, time.Now())
+
+	// Checks during a maintenance window are recorded but excluded from uptime, and alerts are suppressed
+	inMaintenance := isInMaintenance(monitor.ID, time.Now())
+
 	// Save check history to database (persists response time data)
 	checkHistory := CheckHistory{
 		MonitorID:    monitor.ID,
 		Status:       status,
 		ResponseTime: 0,
 		CreatedAt:    time.Now(),
+		CacheAge:     cacheAge,
+		ServerDate:   serverDate,
+		ErrorCategory: errorCategory,
+		TTFB:         ttfb,
+		TotalTime:    totalTime,
+		Label:        primary.Label,
+		InMaintenance: inMaintenance,
 	}
 
-	if status == "up" && responseTime > 0 {
+	if (status == "up" || status == StatusDegraded) && responseTime > 0 {
 		checkHistory.ResponseTime = responseTime
 	}
 
-	if err := db.Create(&checkHistory).Error; err != nil {
-		log.Error().Err(err).Uint("monitor_id", monitor.ID).Msg("Failed to save check history")
+	if monitor.StoreHeaders {
+		checkHistory.Headers = headerCapture.capture(header)
+	}
+
+	// Sampled monitors only store every Nth check and monitors without history none; the monitor's status below
+	// still updates every check
+	if checkSampler.keep(&monitor) {
+		if err := db.Create(&checkHistory).Error; err != nil {
+			log.Error().Err(err).Uint("monitor_id", monitor.ID).Msg("Failed to save check history")
+		}
+	}
+
+	// Flag sustained latency elevation even when every single check is under the threshold
+	var rollingAverage int
+	if monitor.LatencyWindow > 0 {
+		var samples int
+		rollingAverage, samples = rollingResponseTime(monitor.ID, monitor.LatencyWindow)
+		if status == "up" && displayStatus == "up" && latencyElevated(&monitor, rollingAverage, samples) {
+			displayStatus = StatusDegraded
+			log.Debug().Uint("monitor_id", monitorID).Int("rolling_avg_ms", rollingAverage).
+				Int("threshold_ms", monitor.LatencyThreshold).Msg("[Check] Rolling response time above threshold, reporting degraded")
+		}
 	}
 
 	// Update monitor with latest check
@@ -207,7 +833,10 @@ func checkService(monitorIDOrPtr interface{}) {
 		}
 		log.Debug().Uint("monitor_id", monitor.ID).Float64("uptime", monitor.Uptime).Msg("[Check] Using view for uptime")
 	} else {
-		// Fallback to direct query if view is unavailable
+		// Fallback to direct query if view is unavailable; no rows just means no checks in the last 24h
+		if viewErr != nil && !errors.Is(viewErr, sql.ErrNoRows) {
+			log.Debug().Err(viewErr).Uint("monitor_id", monitor.ID).Msg("[Check] monitor_stats_24h view failed, falling back to direct uptime query")
+		}
 		twentyFourHoursAgo := now.Add(-24 * time.Hour)
 		var result struct {
 			TotalCount int64
@@ -215,15 +844,15 @@ func checkService(monitorIDOrPtr interface{}) {
 		}
 		
 		uptimeErr := db.Model(&CheckHistory{}).
-			Select("COUNT(*) as total_count, SUM(CASE WHEN status = 'up' THEN 1 ELSE 0 END) as up_count").
-			Where("monitor_id = ? AND created_at > ?", monitor.ID, twentyFourHoursAgo).
+			Select("COUNT(*) as total_count, SUM(CASE WHEN " + uptimeStatusSQL("status") + " THEN 1 ELSE 0 END) as up_count").
+			Where("monitor_id = ? AND created_at > ? AND in_maintenance = ?", monitor.ID, twentyFourHoursAgo, false).
 			Scan(&result).Error
 		
 		if uptimeErr == nil && result.TotalCount > 0 {
 			monitor.Uptime = float64(result.UpCount) / float64(result.TotalCount) * 100
-		} else {
+		} else if !inMaintenance {
 			// If no checks in last 24h, use current status
-			if status == "up" {
+			if countsAsUp(status) {
 				monitor.Uptime = 100.0
 			} else {
 				monitor.Uptime = 0.0
@@ -233,13 +862,67 @@ func checkService(monitorIDOrPtr interface{}) {
 
 	// Update monitor - only update check-related fields, not CheckInterval
 	// This ensures we don't overwrite CheckInterval changes made via API
-	db.Model(&monitor).Updates(map[string]interface{}{
-		"status":        status,
+	updates := map[string]interface{}{
+		"status":        displayStatus,
 		"response_time": responseTime,
 		"last_check":    lastCheck,
 		"uptime":        monitor.Uptime,
+		"last_redirect": redirectLocation,
+		"last_cache_age": cacheAge,
+		"last_server_date": serverDate,
+		"endpoint_results": endpointResults,
+		"schema_error":  primary.SchemaError,
+		"last_error":    lastError,
+		"last_ttfb":     ttfb,
+		"last_total_time": totalTime,
+		"last_content_encoding": primary.ContentEncoding,
+		"last_protocol": primary.Protocol,
+		"rolling_response_time": rollingAverage,
+		"last_error_category": errorCategory,
+		"last_label":    primary.Label,
+		"consecutive_failures": failures,
 		"updated_at":    now,
-	})
+	}
+
+	// Keep the last seen certificate when a check fails before the TLS handshake
+	if primary.Certificate != nil {
+		updates["certificate"] = primary.Certificate
+	}
+	trackCertificateFingerprint(&monitor, primary.Certificate, updates, now)
+
+	// Track how long the target has looked permanently gone, for auto-retiring monitors
+	retire := retireReason(primary)
+	if retire != "" && monitor.RetireCandidateSince == nil {
+		updates["retire_candidate_since"] = now
+	} else if retire == "" && monitor.RetireCandidateSince != nil {
+		updates["retire_candidate_since"] = nil
+	}
+
+	// Track the reported version, recording an event whenever it changes
+	if version != "" && version != monitor.CurrentVersion {
+		if monitor.CurrentVersion != "" {
+			recordVersionChange(&monitor, monitor.CurrentVersion, version, now)
+		}
+		updates["current_version"] = version
+		updates["version_changed_at"] = now
+	}
+
+	// Track when the displayed status last changed, for "up for 14 days" streaks
+	if displayStatus != previousStatus {
+		updates["status_since"] = now
+	} else if monitor.StatusSince == nil {
+		// Monitors from before status tracking derive their streak from history once
+		since := now
+		if displayStatus == status {
+			since = statusSinceFromHistory(monitor.ID, status, now)
+		}
+		updates["status_since"] = since
+	}
+
+	// Watch the cache validators of static assets for unplanned deploys
+	trackValidatorChanges(&monitor, primary.Header, updates, now)
+
+	db.Model(&monitor).Updates(updates)
 	
 	// Reload monitor from database to get fresh data including CheckInterval for broadcast
 	if err := db.First(&monitor, monitorID).Error; err != nil {
@@ -247,8 +930,24 @@ func checkService(monitorIDOrPtr interface{}) {
 		return
 	}
 
-	// Broadcast monitor update via SSE
-	broadcastUpdate("monitor_update", monitor)
+	if retireMonitorIfDue(&monitor, retire, now) {
+		return
+	}
+
+	// Alert and track incidents on transitions into and out of down (failures within the grace period show as pending)
+	if displayStatus == "down" && previousStatus != "down" {
+		openIncident(monitor.ID, now)
+		alertManager.monitorDown(&monitor)
+	} else if displayStatus != "down" && previousStatus == "down" {
+		closeIncident(monitor.ID, now)
+		alertManager.monitorRecovered(&monitor)
+	}
+
+	// Lengthen or shorten the interval of adaptive monitors based on this result
+	updateAdaptiveInterval(&monitor, status)
+
+	// Broadcast monitor update via SSE (batched during the startup sweep and config syncs)
+	broadcastMonitorUpdate(monitor)
 	
 	// Schedule stats update (debounced to batch rapid updates)
 	broadcastStatsIfChanged()
@@ -259,6 +958,10 @@ func checkAllServices() {
 	var monitors []Monitor
 	db.Find(&monitors)
 
+	// Send the sweep's results to SSE clients in batches rather than one message per monitor
+	endBatch := monitorBatcher.begin()
+	defer endBatch()
+
 	for i := range monitors {
 		// Skip paused monitors
 		if monitors[i].Paused {
@@ -288,10 +991,7 @@ func (ms *MonitorScheduler) addMonitorJob(monitor *Monitor) error {
 		return nil
 	}
 	
-	interval := monitor.CheckInterval
-	if interval <= 0 {
-		interval = 60 // Default to 60 seconds
-	}
+	interval := loadBudget.apply(monitor.scheduledInterval())
 	
 	ms.mu.RLock()
 	currentInterval, hasJob := ms.intervals[monitor.ID]
@@ -335,6 +1035,8 @@ func (ms *MonitorScheduler) addMonitorJob(monitor *Monitor) error {
 		}),
 		gocron.WithName(fmt.Sprintf("monitor-%d", monitorID)),
 		gocron.WithStartAt(gocron.WithStartImmediately()),
+		// Don't queue up runs while a deferred check is still waiting for capacity
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
 	)
 	
 	if err != nil {
@@ -380,6 +1082,9 @@ func (ms *MonitorScheduler) refreshScheduler() {
 	}
 	
 	log.Debug().Int("monitor_count", len(monitors)).Msg("[Scheduler] Refreshing scheduler")
+
+	// Stretch intervals first if the monitors together would exceed the check rate budget
+	loadBudget.update(monitors)
 	
 	ms.mu.Lock()
 	activeIDs := make(map[uint]bool)
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateCheckInterval(t *testing.T) {
+	for _, interval := range []int{0, MinCheckInterval, 60, MaxCheckInterval} {
+		if err := validateCheckInterval(interval); err != nil {
+			t.Errorf("validateCheckInterval(%d) = %v, want nil", interval, err)
+		}
+	}
+	for _, interval := range []int{-1, 1, MinCheckInterval - 1, MaxCheckInterval + 1} {
+		if err := validateCheckInterval(interval); err == nil {
+			t.Errorf("validateCheckInterval(%d) accepted", interval)
+		}
+	}
+}
+
+func TestValidateMonitorRequestCheckInterval(t *testing.T) {
+	req := CreateMonitorRequest{Name: "api", URL: "https://example.com", CheckInterval: 1}
+	if err := validateMonitorRequest(&req); err == nil {
+		t.Error("checkInterval below the minimum accepted")
+	}
+	req.CheckInterval = MaxCheckInterval + 1
+	if err := validateMonitorRequest(&req); err == nil {
+		t.Error("checkInterval above the maximum accepted")
+	}
+}
+
+func TestMonitorsFromConfigsSkipsInvalidCheckInterval(t *testing.T) {
+	monitors, _ := monitorsFromConfigs([]MonitorConfig{
+		{Name: "too-fast", URL: "https://example.com", CheckInterval: 1},
+		{Name: "too-slow", URL: "https://example.com", CheckInterval: MaxCheckInterval + 1},
+		{Name: "default", URL: "https://example.com"},
+		{Name: "bounded", URL: "https://example.com", CheckInterval: 30},
+	}, ConfigSourceYAML)
+
+	intervals := make(map[string]int, len(monitors))
+	for _, monitor := range monitors {
+		intervals[monitor.Name] = monitor.CheckInterval
+	}
+	want := map[string]int{"default": DefaultCheckInterval, "bounded": 30}
+	if len(intervals) != len(want) || intervals["default"] != want["default"] || intervals["bounded"] != want["bounded"] {
+		t.Errorf("monitors = %v, want %v", intervals, want)
+	}
+}
+
+func TestUnsupportedSchemes(t *testing.T) {
+	for _, scheme := range []string{"ftp", "sftp", "file", "gopher", "ldap", "htps", "smtp"} {
+		rawURL := scheme + "://example.com/path"
+		if err := validateURLScheme(rawURL); err == nil || !strings.Contains(err.Error(), "unsupported scheme: "+scheme) {
+			t.Errorf("validateURLScheme(%q) = %v, want an unsupported scheme error", rawURL, err)
+		}
+
+		req := CreateMonitorRequest{Name: scheme, URL: rawURL}
+		if err := validateMonitorRequest(&req); err == nil {
+			t.Errorf("%s: monitor accepted", rawURL)
+		}
+
+		// Monitors stored before validation fail with a config error instead of being requested
+		result := checkEndpoint(&Monitor{URL: rawURL}, rawURL, nil)
+		if result.Status != "down" || result.ErrorCategory != ErrorCategoryConfig || result.Error != "config: unsupported scheme: "+scheme {
+			t.Errorf("%s: check = %s/%s %q, want down with a config error", rawURL, result.Status, result.ErrorCategory, result.Error)
+		}
+	}
+
+	for _, rawURL := range []string{"https://example.com", "HTTP://example.com", "example.com", "udp://example.com:53", "wss://example.com"} {
+		if err := validateURLScheme(rawURL); err != nil {
+			t.Errorf("validateURLScheme(%q) = %v, want nil", rawURL, err)
+		}
+	}
+}
+
+func TestFollowRedirects(t *testing.T) {
+	// The redirect itself is the healthy answer; its destination is broken
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/moved" {
+			http.Redirect(w, r, "/broken", http.StatusMovedPermanently)
+			return
+		}
+		http.Error(w, "broken", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	rawURL := server.URL + "/moved"
+
+	follow, noFollow := true, false
+	for _, tc := range []struct {
+		name       string
+		follow     *bool
+		status     string
+		statusCode int
+	}{
+		{"default", nil, "down", http.StatusInternalServerError},
+		{"followRedirects true", &follow, "down", http.StatusInternalServerError},
+		{"followRedirects false", &noFollow, "up", http.StatusMovedPermanently},
+	} {
+		result := checkEndpoint(&Monitor{URL: rawURL, FollowRedirects: tc.follow}, rawURL, nil)
+		if result.Status != tc.status || result.StatusCode != tc.statusCode {
+			t.Errorf("%s: check = %s (HTTP %d), want %s (HTTP %d)", tc.name, result.Status, result.StatusCode, tc.status, tc.statusCode)
+		}
+	}
+}
+
+func TestValidateFollowRedirects(t *testing.T) {
+	noFollow := false
+	if err := validateFollowRedirects(&noFollow, "", 0); err != nil {
+		t.Errorf("followRedirects false rejected: %v", err)
+	}
+	if err := validateFollowRedirects(&noFollow, RedirectPolicyFollow, 0); err == nil {
+		t.Error("followRedirects false accepted with redirectPolicy follow")
+	}
+	if err := validateFollowRedirects(&noFollow, "", 3); err == nil {
+		t.Error("followRedirects false accepted with maxRedirects")
+	}
+}
+
+func TestWarmingDownMonitorStaysDown(t *testing.T) {
+	now := time.Now()
+	warming := now.Add(-time.Minute)
+	monitor := &Monitor{Status: "down", GracePeriod: 600, GraceStartedAt: &warming, CreatedAt: now.Add(-24 * time.Hour)}
+
+	// Restarting the grace period of a down monitor must not report pending, which would close its incident
+	if got := displayedStatus(monitor, "down", 5, now); got != "down" {
+		t.Errorf("failure of a warming down monitor shows %q, want down", got)
+	}
+
+	monitor.Status = "up"
+	if got := displayedStatus(monitor, "down", 1, now); got != "pending" {
+		t.Errorf("failure of a warming up monitor shows %q, want pending", got)
+	}
+	if got := displayedStatus(monitor, "up", 0, now); got != "up" {
+		t.Errorf("success shows %q, want up", got)
+	}
+}
+
+func TestCheckTimeoutMarksSlowEndpointDown(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	monitor := &Monitor{URL: server.URL, TimeoutSeconds: 2}
+	start := time.Now()
+	result := checkEndpoint(monitor, server.URL, nil)
+	elapsed := time.Since(start)
+
+	if result.Status != "down" || result.ErrorCategory != ErrorCategoryTimeout {
+		t.Errorf("slow endpoint: status %q category %q, want down timeout", result.Status, result.ErrorCategory)
+	}
+	if elapsed < 2*time.Second || elapsed > 2500*time.Millisecond {
+		t.Errorf("check took %v, want about the 2s timeout", elapsed.Round(time.Millisecond))
+	}
+}
+
+func TestRequestBuildErrorIsReported(t *testing.T) {
+	// Stored before methods were validated: net/http refuses to build the request
+	monitor := &Monitor{URL: "https://example.com", Method: "GE T"}
+	result := checkEndpoint(monitor, monitor.URL, nil)
+	if result.Status != "down" || result.ErrorCategory != ErrorCategoryConfig || !strings.Contains(result.Error, "invalid method") {
+		t.Errorf("check = %s/%s %q, want down with a config error naming the method", result.Status, result.ErrorCategory, result.Error)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// Error categories for failed check requests
+const (
+	ErrorCategoryTimeout           = "timeout"
+	ErrorCategoryDNS               = "dns"
+	ErrorCategoryConnectionRefused = "connection_refused"
+	ErrorCategoryConnectionReset   = "connection_reset"
+	ErrorCategoryEOF               = "eof"
+	ErrorCategoryTLS               = "tls"
+	ErrorCategoryRedirects         = "too_many_redirects"
+	ErrorCategoryConfig            = "config" // The monitor's settings can't be checked, e.g. an unsupported URL scheme
+	ErrorCategoryOther             = "error"
+)
+
+// maxErrorMessageLength caps the error message stored on a monitor
+const maxErrorMessageLength = 200
+
+// classifyRequestError sorts a request error into a category so flaky upstreams can be diagnosed
+func classifyRequestError(err error) string {
+	if errors.Is(err, errTooManyRedirects) {
+		return ErrorCategoryRedirects
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return ErrorCategoryDNS
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrorCategoryTimeout
+	}
+
+	switch {
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE):
+		return ErrorCategoryConnectionReset
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ErrorCategoryConnectionRefused
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrorCategoryEOF
+	}
+
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	if errors.As(err, &certErr) || errors.As(err, &recordErr) || errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) || strings.Contains(err.Error(), "tls: ") {
+		return ErrorCategoryTLS
+	}
+
+	return ErrorCategoryOther
+}
+
+// errorMessage returns a concise message for a request error, without the method/URL prefix
+func errorMessage(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	message := err.Error()
+	if len(message) > maxErrorMessageLength {
+		// Drop a multi-byte character the cut went through rather than storing invalid UTF-8
+		message = strings.ToValidUTF8(message[:maxErrorMessageLength], "") + "..."
+	}
+	return message
+}
+
+// setRequestError records a classified request error on an endpoint result
+func (c *endpointCheck) setRequestError(err error) {
+	c.ErrorCategory = classifyRequestError(err)
+	c.Error = c.ErrorCategory + ": " + errorMessage(err)
+}
@@ -8,14 +8,59 @@ import (
 	"gorm.io/gorm"
 )
 
-// cleanOldCheckHistory removes check history records older than 1 year
-func cleanOldCheckHistory() {
-	oneYearAgo := time.Now().Add(-365 * 24 * time.Hour)
+// RetentionSettings control how long check history is kept, read from the environment at startup
+type RetentionSettings struct {
+	BucketAfterHours int // BUCKET_AFTER_HOURS: age at which raw checks are aggregated into hourly buckets
+	RawDays          int // RAW_RETENTION_DAYS: days raw checks are kept; after that only their bucket remains
+	HistoryDays      int // HISTORY_RETENTION_DAYS: days any check history, raw or bucketed, is kept
+}
+
+const (
+	maxRawRetentionDays     = 365
+	maxHistoryRetentionDays = 3650
+)
+
+// loadRetentionSettings reads the retention environment variables, keeping them in an order that never
+// loses data before its time: checks are bucketed before their raw rows expire, and history is kept at
+// least as long as raw checks. Out-of-range values are clamped with a warning
+func loadRetentionSettings() RetentionSettings {
+	settings := RetentionSettings{
+		BucketAfterHours: getEnvInt("BUCKET_AFTER_HOURS", 24),
+		RawDays:          getEnvInt("RAW_RETENTION_DAYS", 7),
+		HistoryDays:      getEnvInt("HISTORY_RETENTION_DAYS", 365),
+	}
+
+	if settings.RawDays < 1 || settings.RawDays > maxRawRetentionDays {
+		log.Warn().Int("days", settings.RawDays).Msg("[Cleanup] RAW_RETENTION_DAYS must be between 1 and 365, using 7")
+		settings.RawDays = 7
+	}
+	if settings.BucketAfterHours < 1 {
+		log.Warn().Int("hours", settings.BucketAfterHours).Msg("[Cleanup] BUCKET_AFTER_HOURS must be at least 1, using 24")
+		settings.BucketAfterHours = 24
+	}
+	// Raw checks are only deleted once bucketed, so bucketing after they expire would just keep them longer
+	if settings.BucketAfterHours > settings.RawDays*24 {
+		log.Warn().Int("hours", settings.BucketAfterHours).Int("raw_retention_days", settings.RawDays).
+			Msg("[Cleanup] BUCKET_AFTER_HOURS exceeds RAW_RETENTION_DAYS, bucketing when raw checks expire")
+		settings.BucketAfterHours = settings.RawDays * 24
+	}
+	if settings.HistoryDays < settings.RawDays || settings.HistoryDays > maxHistoryRetentionDays {
+		clamped := max(settings.RawDays, min(settings.HistoryDays, maxHistoryRetentionDays))
+		log.Warn().Int("days", settings.HistoryDays).Int("using", clamped).
+			Msg("[Cleanup] HISTORY_RETENTION_DAYS must be between RAW_RETENTION_DAYS and 3650")
+		settings.HistoryDays = clamped
+	}
+	return settings
+}
+
+// cleanOldCheckHistory removes raw checks and hourly buckets older than HISTORY_RETENTION_DAYS
+func cleanOldCheckHistory(retention RetentionSettings) {
+	cutoff := time.Now().AddDate(0, 0, -retention.HistoryDays)
 	
-	log.Info().Time("cutoff", oneYearAgo).Msg("[Cleanup] Starting cleanup of check history")
+	log.Info().Time("cutoff", cutoff).Msg("[Cleanup] Starting cleanup of check history")
 	
 	var deletedCount int64
-	result := db.Where("created_at < ?", oneYearAgo).Delete(&CheckHistory{})
+	result := db.Where("created_at < ?", cutoff).Delete(&CheckHistory{})
 	
 	if result.Error != nil {
 		log.Error().Err(result.Error).Msg("[Cleanup] Failed to clean old check history")
@@ -24,13 +69,93 @@ func cleanOldCheckHistory() {
 	
 	deletedCount = result.RowsAffected
 	log.Info().Int64("deleted", deletedCount).Msg("[Cleanup] Successfully deleted check history records")
+
+	result = db.Where("bucket_hour < ?", cutoff.Unix()).Delete(&CheckHistoryBucket{})
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("[Cleanup] Failed to clean old check history buckets")
+		return
+	}
+	log.Info().Int64("deleted", result.RowsAffected).Msg("[Cleanup] Deleted old check history buckets")
+}
+
+// cleanOldStatsSnapshots removes stats snapshots older than STATS_SNAPSHOT_RETENTION_DAYS (default 365)
+func cleanOldStatsSnapshots() {
+	retentionDays := getEnvInt("STATS_SNAPSHOT_RETENTION_DAYS", 365)
+	if retentionDays <= 0 {
+		return // Keep snapshots forever
+	}
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+
+	result := db.Where("created_at < ?", cutoff).Delete(&StatsSnapshot{})
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("[Cleanup] Failed to clean old stats snapshots")
+		return
+	}
+	log.Info().Int64("deleted", result.RowsAffected).Time("cutoff", cutoff).Msg("[Cleanup] Deleted old stats snapshots")
+}
+
+// bucketHourSQL is the bucket hour of a raw check as a unix timestamp
+// GORM stores datetime as text with format: "2026-01-12 05:29:47.500629789 +0000 UTC..."
+// Extract date and hour (first 13 chars: "2026-01-12 05"), then convert to unix timestamp
+// Use substr to get "YYYY-MM-DD HH" format, then use datetime() to parse and convert
+const bucketHourSQL = `CAST(unixepoch(substr(created_at, 1, 13) || ':00:00') AS INTEGER)`
+
+// bucketHourKey returns the bucket hour bucketHourSQL computes for a check stored at t
+// (the stored wall-clock hour, read as if it were UTC)
+func bucketHourKey(t time.Time) int64 {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, time.UTC).Unix()
+}
+
+// bucketAggregateSQL aggregates raw checks older than a cutoff into hourly buckets per monitor
+// It has no lower bound: raw checks only outlive the raw retention window when an earlier run failed to
+// bucket them, and those are exactly the ones that still need bucketing
+func bucketAggregateSQL() string {
+	return `
+		SELECT 
+			monitor_id,
+			` + bucketHourSQL + ` as bucket_hour,
+			COUNT(*) as total_checks,
+			SUM(CASE WHEN ` + uptimeStatusSQL("status") + ` THEN 1 ELSE 0 END) as up_checks,
+			AVG(CASE WHEN response_time > 0 THEN response_time ELSE NULL END) as avg_response_time,
+			MIN(CASE WHEN response_time > 0 THEN response_time ELSE NULL END) as min_response_time,
+			MAX(CASE WHEN response_time > 0 THEN response_time ELSE NULL END) as max_response_time
+		FROM check_histories
+		WHERE created_at < ?
+		GROUP BY monitor_id, bucket_hour
+		ORDER BY monitor_id, bucket_hour
+	`
+}
+
+// deleteBucketedCheckHistory deletes raw checks in whole hours before the given time, but only hours
+// that already have a bucket. Deleting whole hours keeps a later run from re-aggregating a partly deleted
+// hour and overwriting its complete bucket; requiring the bucket means a failed upsert never loses checks
+// The bucket hour is computed outside the subquery: inside it, created_at would be the bucket's column
+func deleteBucketedCheckHistory(before time.Time) (int64, error) {
+	var deleted int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+			DELETE FROM check_histories
+			WHERE created_at < ?
+				AND `+bucketHourSQL+` < ?
+				AND `+bucketHourSQL+` IN (
+					SELECT b.bucket_hour FROM check_history_buckets b
+					WHERE b.monitor_id = check_histories.monitor_id
+				)
+		`, before, bucketHourKey(before))
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
 }
 
-// bucketOldCheckHistory aggregates CheckHistory records older than 24 hours into hourly buckets
+// bucketOldCheckHistory aggregates CheckHistory records older than BUCKET_AFTER_HOURS into hourly buckets
+// and deletes raw records older than RAW_RETENTION_DAYS
 // Uses SQL aggregation for maximum efficiency instead of loading data into Go
-func bucketOldCheckHistory() {
-	cutoffTime := time.Now().Add(-24 * time.Hour)
-	sevenDaysAgo := time.Now().Add(-7 * 24 * time.Hour)
+// Safe to re-run after an interruption at any point: buckets are recomputed from the raw checks and
+// replaced (never added to), and raw checks are only deleted once their hour's bucket is stored
+func bucketOldCheckHistory(retention RetentionSettings) {
+	cutoffTime := time.Now().Add(-time.Duration(retention.BucketAfterHours) * time.Hour)
+	rawCutoff := time.Now().AddDate(0, 0, -retention.RawDays)
 	
 	log.Info().Time("cutoff", cutoffTime).Msg("[Bucketing] Starting check history bucketing")
 	
@@ -51,23 +176,7 @@ func bucketOldCheckHistory() {
 		MaxResponseTime int
 	}
 	
-	// GORM stores datetime as text with format: "2026-01-12 05:29:47.500629789 +0000 UTC..."
-	// Extract date and hour (first 13 chars: "2026-01-12 05"), then convert to unix timestamp
-	// Use substr to get "YYYY-MM-DD HH" format, then use datetime() to parse and convert
-	err := db.Raw(`
-		SELECT 
-			monitor_id,
-			CAST(unixepoch(substr(created_at, 1, 13) || ':00:00') AS INTEGER) as bucket_hour,
-			COUNT(*) as total_checks,
-			SUM(CASE WHEN status = 'up' THEN 1 ELSE 0 END) as up_checks,
-			AVG(CASE WHEN response_time > 0 THEN response_time ELSE NULL END) as avg_response_time,
-			MIN(CASE WHEN response_time > 0 THEN response_time ELSE NULL END) as min_response_time,
-			MAX(CASE WHEN response_time > 0 THEN response_time ELSE NULL END) as max_response_time
-		FROM check_histories
-		WHERE created_at < ? AND created_at >= ?
-		GROUP BY monitor_id, bucket_hour
-		ORDER BY monitor_id, bucket_hour
-	`, cutoffTime, sevenDaysAgo).Scan(&aggregatedBuckets).Error
+	err := db.Raw(bucketAggregateSQL(), cutoffTime).Scan(&aggregatedBuckets).Error
 	
 	if err != nil {
 		log.Error().Err(err).Msg("[Bucketing] Failed to aggregate checks into buckets")
@@ -77,11 +186,10 @@ func bucketOldCheckHistory() {
 	if len(aggregatedBuckets) == 0 {
 		log.Info().Msg("[Bucketing] No old checks to bucket")
 		// Still try to delete old raw records
-		result := db.Where("created_at < ?", sevenDaysAgo).Delete(&CheckHistory{})
-		if result.Error != nil {
-			log.Error().Err(result.Error).Msg("[Bucketing] Failed to delete old raw records")
+		if deleted, err := deleteBucketedCheckHistory(rawCutoff); err != nil {
+			log.Error().Err(err).Msg("[Bucketing] Failed to delete old raw records")
 		} else {
-			log.Debug().Int64("deleted", result.RowsAffected).Msg("[Bucketing] Deleted old raw records")
+			log.Debug().Int64("deleted", deleted).Msg("[Bucketing] Deleted old raw records")
 		}
 		return
 	}
@@ -151,13 +259,13 @@ func bucketOldCheckHistory() {
 		totalBucketed += len(batch)
 	}
 	
-	// Delete old raw records after bucketing (keep last 7 days raw for detailed charts)
-	result := db.Where("created_at < ?", sevenDaysAgo).Delete(&CheckHistory{})
-	
-	if result.Error != nil {
-		log.Error().Err(result.Error).Msg("[Bucketing] Failed to delete old raw records")
+	// Delete old raw records after bucketing (keep RAW_RETENTION_DAYS raw for detailed charts)
+	// Hours whose batch failed above keep their raw checks and are bucketed by the next run
+	deleted, err := deleteBucketedCheckHistory(rawCutoff)
+	if err != nil {
+		log.Error().Err(err).Msg("[Bucketing] Failed to delete old raw records")
 	} else {
-		log.Debug().Int64("deleted", result.RowsAffected).Msg("[Bucketing] Deleted old raw records")
+		log.Debug().Int64("deleted", deleted).Msg("[Bucketing] Deleted old raw records")
 	}
 	
 	log.Info().Int("buckets_created", totalBucketed).Msg("[Bucketing] Completed check history bucketing")
@@ -166,7 +274,8 @@ func bucketOldCheckHistory() {
 var cleanupScheduler gocron.Scheduler
 
 // startCleanupScheduler starts a background job that runs cleanup daily at midnight using gocron
-func startCleanupScheduler() {
+// retention is loaded by the caller before the scheduler goroutine starts
+func startCleanupScheduler(retention RetentionSettings) {
 	// Create a new scheduler for cleanup jobs
 	sched, err := gocron.NewScheduler()
 	if err != nil {
@@ -174,6 +283,9 @@ func startCleanupScheduler() {
 	}
 	
 	cleanupScheduler = sched
+
+	log.Info().Int("bucket_after_hours", retention.BucketAfterHours).Int("raw_retention_days", retention.RawDays).
+		Int("history_retention_days", retention.HistoryDays).Msg("[Cleanup] Check history retention")
 	
 	// Schedule cleanup job to run daily at midnight (00:00)
 	// Cron expression: "0 0 * * *" means: minute=0, hour=0, every day, every month, every weekday
@@ -181,8 +293,14 @@ func startCleanupScheduler() {
 		gocron.CronJob("0 0 * * *", false),
 		gocron.NewTask(func() {
 			log.Info().Msg("[Cleanup] Running scheduled cleanup and bucketing")
-			cleanOldCheckHistory()
-			bucketOldCheckHistory()
+			cleanOldCheckHistory(retention)
+			bucketOldCheckHistory(retention)
+			cleanOldStatsSnapshots()
+			if enabled, vacuum := scheduledDatabaseMaintenance(); enabled {
+				if _, err := optimizeDatabase(vacuum); err != nil {
+					log.Error().Err(err).Msg("[Cleanup] Scheduled database maintenance failed")
+				}
+			}
 		}),
 		gocron.WithName("daily-cleanup"),
 	)
@@ -190,6 +308,36 @@ func startCleanupScheduler() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("[Cleanup] Failed to schedule cleanup job")
 	}
+
+	// Captured response headers have a much shorter retention, so expire them hourly
+	_, err = cleanupScheduler.NewJob(
+		gocron.DurationJob(time.Hour),
+		gocron.NewTask(expireCapturedHeaders),
+		gocron.WithName("header-expiry"),
+	)
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("[Cleanup] Failed to schedule header expiry job")
+	}
+
+	// Snapshot the overall stats so their trend can be charted (STATS_SNAPSHOT_INTERVAL minutes, default 60)
+	snapshotInterval := getEnvInt("STATS_SNAPSHOT_INTERVAL", 60)
+	if snapshotInterval <= 0 {
+		log.Warn().Int("interval", snapshotInterval).Msg("[Cleanup] Invalid STATS_SNAPSHOT_INTERVAL, using 60 minutes")
+		snapshotInterval = 60
+	}
+	_, err = cleanupScheduler.NewJob(
+		gocron.DurationJob(time.Duration(snapshotInterval)*time.Minute),
+		gocron.NewTask(recordStatsSnapshot),
+		gocron.WithName("stats-snapshot"),
+	)
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("[Cleanup] Failed to schedule stats snapshot job")
+	}
+
+	// Optional info-level heartbeat in the logs (SUMMARY_LOG_INTERVAL seconds)
+	scheduleCheckSummary(cleanupScheduler)
 	
 	// Start the scheduler
 	cleanupScheduler.Start()
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// seedHourlyChecks stores checks for a monitor in two whole hours, ten days ago: three in the
+// first hour (two up) and two in the second (both down)
+func seedHourlyChecks(t *testing.T, monitorID uint) (first, second time.Time) {
+	t.Helper()
+	first = time.Now().UTC().AddDate(0, 0, -10).Truncate(time.Hour)
+	second = first.Add(time.Hour)
+	history := []CheckHistory{
+		{MonitorID: monitorID, Status: "up", ResponseTime: 100, CreatedAt: first.Add(5 * time.Minute)},
+		{MonitorID: monitorID, Status: "up", ResponseTime: 300, CreatedAt: first.Add(25 * time.Minute)},
+		{MonitorID: monitorID, Status: "down", CreatedAt: first.Add(45 * time.Minute)},
+		{MonitorID: monitorID, Status: "down", CreatedAt: second.Add(10 * time.Minute)},
+		{MonitorID: monitorID, Status: "down", CreatedAt: second.Add(40 * time.Minute)},
+	}
+	if err := db.Create(&history).Error; err != nil {
+		t.Fatal(err)
+	}
+	return first, second
+}
+
+func TestDeleteBucketedCheckHistoryKeepsUnbucketedHours(t *testing.T) {
+	db := newTestDB(t)
+	first, second := seedHourlyChecks(t, 1)
+
+	// A run that stored the first hour's bucket and was killed before the second
+	if err := db.Create(&CheckHistoryBucket{MonitorID: 1, BucketHour: bucketHourKey(first), TotalChecks: 3, UpChecks: 2}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := deleteBucketedCheckHistory(time.Now().AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 3 {
+		t.Errorf("deleted %d raw checks, want the 3 of the bucketed hour", deleted)
+	}
+	var remaining int64
+	db.Model(&CheckHistory{}).Where("monitor_id = ? AND created_at >= ?", 1, second).Count(&remaining)
+	if remaining != 2 {
+		t.Errorf("%d raw checks of the unbucketed hour remain, want 2", remaining)
+	}
+}
+
+func TestBucketOldCheckHistoryResumesAfterInterruption(t *testing.T) {
+	db := newTestDB(t)
+	first, second := seedHourlyChecks(t, 1)
+	settings := RetentionSettings{BucketAfterHours: 24, RawDays: 7, HistoryDays: 365}
+
+	// A run killed mid-bucketing: the first hour's bucket is stale, the second's missing, and no raw
+	// checks deleted yet
+	if err := db.Create(&CheckHistoryBucket{MonitorID: 1, BucketHour: bucketHourKey(first), TotalChecks: 1, UpChecks: 1}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-running (twice, as if the next run was interrupted after deleting) must neither double-count nor lose checks
+	bucketOldCheckHistory(settings)
+	bucketOldCheckHistory(settings)
+
+	want := map[int64][2]int{bucketHourKey(first): {3, 2}, bucketHourKey(second): {2, 0}}
+	var buckets []CheckHistoryBucket
+	if err := db.Where("monitor_id = ?", 1).Find(&buckets).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != len(want) {
+		t.Fatalf("got %d buckets, want %d", len(buckets), len(want))
+	}
+	for _, bucket := range buckets {
+		if counts := want[bucket.BucketHour]; bucket.TotalChecks != counts[0] || bucket.UpChecks != counts[1] {
+			t.Errorf("bucket %d = %d checks (%d up), want %d (%d up)", bucket.BucketHour, bucket.TotalChecks, bucket.UpChecks, counts[0], counts[1])
+		}
+	}
+
+	var raw int64
+	db.Model(&CheckHistory{}).Count(&raw)
+	if raw != 0 {
+		t.Errorf("%d raw checks remain after bucketing, want 0", raw)
+	}
+}
+
+func TestLoadRetentionSettings(t *testing.T) {
+	for _, tc := range []struct {
+		name                         string
+		bucketAfter, raw, history    string
+		wantBucket, wantRaw, wantAll int
+	}{
+		{"defaults", "", "", "", 24, 7, 365},
+		{"small disk", "12", "3", "30", 12, 3, 30},
+		{"raw days out of range", "", "0", "", 24, 7, 365},
+		{"bucketing after raw expiry", "200", "2", "", 48, 2, 365},
+		{"history shorter than raw", "", "14", "7", 24, 14, 14},
+		{"history too long", "", "", "10000", 24, 7, maxHistoryRetentionDays},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("BUCKET_AFTER_HOURS", tc.bucketAfter)
+			t.Setenv("RAW_RETENTION_DAYS", tc.raw)
+			t.Setenv("HISTORY_RETENTION_DAYS", tc.history)
+			got := loadRetentionSettings()
+			want := RetentionSettings{BucketAfterHours: tc.wantBucket, RawDays: tc.wantRaw, HistoryDays: tc.wantAll}
+			if got != want {
+				t.Errorf("loadRetentionSettings() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -5,19 +5,86 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 	"gopkg.in/yaml.v3"
 )
 
-// MonitorConfig represents a monitor in the YAML configuration
+// Config sources that can manage monitors
+const (
+	ConfigSourceYAML   = "yaml"   // monitors.yaml next to the database
+	ConfigSourceRemote = "remote" // JSON list fetched from MONITORS_SOURCE_URL
+	ConfigSourceImport = "import" // YAML or JSON posted to /api/monitors/import
+)
+
+// MonitorConfig represents a monitor in the YAML configuration (or a remote JSON monitor source)
 type MonitorConfig struct {
-	Name         string `yaml:"name"`
-	URL          string `yaml:"url"`
-	Icon         string `yaml:"icon,omitempty"`
-	CheckInterval int   `yaml:"checkInterval,omitempty"`
-	IsThirdParty bool   `yaml:"isThirdParty,omitempty"`
-	Paused       bool   `yaml:"paused,omitempty"`
+	Name         string `yaml:"name" json:"name"`
+	URL          string `yaml:"url" json:"url"`
+	Icon         string `yaml:"icon,omitempty" json:"icon,omitempty"`
+	CheckInterval int   `yaml:"checkInterval,omitempty" json:"checkInterval,omitempty"`
+	TimeoutSeconds int  `yaml:"timeoutSeconds,omitempty" json:"timeoutSeconds,omitempty"`
+	IsThirdParty bool   `yaml:"isThirdParty,omitempty" json:"isThirdParty,omitempty"`
+	Paused       bool   `yaml:"paused,omitempty" json:"paused,omitempty"`
+	RedirectPolicy string `yaml:"redirectPolicy,omitempty" json:"redirectPolicy,omitempty"`
+	MaxRedirects int     `yaml:"maxRedirects,omitempty" json:"maxRedirects,omitempty"`
+	FollowRedirects *bool `yaml:"followRedirects,omitempty" json:"followRedirects,omitempty"`
+	StatusOutcomes string `yaml:"statusOutcomes,omitempty" json:"statusOutcomes,omitempty"`
+	ExpectedStatusCodes string `yaml:"expectedStatusCodes,omitempty" json:"expectedStatusCodes,omitempty"`
+	MinCacheAge  int    `yaml:"minCacheAge,omitempty" json:"minCacheAge,omitempty"`
+	MaxCacheAge  int    `yaml:"maxCacheAge,omitempty" json:"maxCacheAge,omitempty"`
+	Tags         []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Group        string `yaml:"group,omitempty" json:"group,omitempty"`
+	Endpoints    []string `yaml:"endpoints,omitempty" json:"endpoints,omitempty"`
+	ParallelEndpoints bool `yaml:"parallelEndpoints,omitempty" json:"parallelEndpoints,omitempty"`
+	GracePeriod  int    `yaml:"gracePeriod,omitempty" json:"gracePeriod,omitempty"`
+	EscalateAfter int   `yaml:"escalateAfter,omitempty" json:"escalateAfter,omitempty"`
+	FailureThreshold int `yaml:"failureThreshold,omitempty" json:"failureThreshold,omitempty"`
+	RetryCount   int    `yaml:"retryCount,omitempty" json:"retryCount,omitempty"`
+	RetryDelayMs int    `yaml:"retryDelayMs,omitempty" json:"retryDelayMs,omitempty"`
+	Type         string `yaml:"type,omitempty" json:"type,omitempty"`
+	HeartbeatGrace int  `yaml:"heartbeatGrace,omitempty" json:"heartbeatGrace,omitempty"`
+	StoreHeaders bool   `yaml:"storeHeaders,omitempty" json:"storeHeaders,omitempty"`
+	LightCheck   bool   `yaml:"lightCheck,omitempty" json:"lightCheck,omitempty"`
+	HTTP3        bool   `yaml:"http3,omitempty" json:"http3,omitempty"`
+	UDPProbe     string `yaml:"udpProbe,omitempty" json:"udpProbe,omitempty"`
+	UDPExpect    string `yaml:"udpExpect,omitempty" json:"udpExpect,omitempty"`
+	WebSocketPing bool  `yaml:"websocketPing,omitempty" json:"websocketPing,omitempty"`
+	AdaptiveInterval bool `yaml:"adaptiveInterval,omitempty" json:"adaptiveInterval,omitempty"`
+	MinAdaptiveInterval int `yaml:"minAdaptiveInterval,omitempty" json:"minAdaptiveInterval,omitempty"`
+	MaxAdaptiveInterval int `yaml:"maxAdaptiveInterval,omitempty" json:"maxAdaptiveInterval,omitempty"`
+	VersionHeader string `yaml:"versionHeader,omitempty" json:"versionHeader,omitempty"`
+	ExtractLabel string `yaml:"extractLabel,omitempty" json:"extractLabel,omitempty"`
+	WatchValidators bool `yaml:"watchValidators,omitempty" json:"watchValidators,omitempty"`
+	WatchCertificate bool `yaml:"watchCertificate,omitempty" json:"watchCertificate,omitempty"`
+	DependsOn    string `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty"`
+	Method       string `yaml:"method,omitempty" json:"method,omitempty"`
+	RequestBody  string `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
+	ContentType  string `yaml:"contentType,omitempty" json:"contentType,omitempty"`
+	ExpectKeyword string `yaml:"expectKeyword,omitempty" json:"expectKeyword,omitempty"`
+	ExpectKeywordAbsent bool `yaml:"expectKeywordAbsent,omitempty" json:"expectKeywordAbsent,omitempty"`
+	MaxBodyBytes int64  `yaml:"maxBodyBytes,omitempty" json:"maxBodyBytes,omitempty"`
+	JSONSchema   string `yaml:"jsonSchema,omitempty" json:"jsonSchema,omitempty"`
+	SLATarget    float64 `yaml:"slaTarget,omitempty" json:"slaTarget,omitempty"`
+	SourceIP     string `yaml:"sourceIp,omitempty" json:"sourceIp,omitempty"`
+	DNSServer    string `yaml:"dnsServer,omitempty" json:"dnsServer,omitempty"`
+	ExpectedIP   string `yaml:"expectedIp,omitempty" json:"expectedIp,omitempty"`
+	AutoRetireAfter int `yaml:"autoRetireAfter,omitempty" json:"autoRetireAfter,omitempty"`
+	AutoRetireAction string `yaml:"autoRetireAction,omitempty" json:"autoRetireAction,omitempty"`
+	ResponseTimeMode string `yaml:"responseTimeMode,omitempty" json:"responseTimeMode,omitempty"`
+	LatencyWindow int `yaml:"latencyWindow,omitempty" json:"latencyWindow,omitempty"`
+	SampleRate   int    `yaml:"sampleRate,omitempty" json:"sampleRate,omitempty"`
+	StoreHistory *bool  `yaml:"storeHistory,omitempty" json:"storeHistory,omitempty"`
+	LatencyThreshold int `yaml:"latencyThreshold,omitempty" json:"latencyThreshold,omitempty"`
+	DegradedThresholdMs int `yaml:"degradedThresholdMs,omitempty" json:"degradedThresholdMs,omitempty"`
+	PreCheckURL  string `yaml:"preCheckUrl,omitempty" json:"preCheckUrl,omitempty"`
+	PreCheckMethod string `yaml:"preCheckMethod,omitempty" json:"preCheckMethod,omitempty"`
+	PreCheckBody string `yaml:"preCheckBody,omitempty" json:"preCheckBody,omitempty"`
+	PreCheckExtract string `yaml:"preCheckExtract,omitempty" json:"preCheckExtract,omitempty"`
+	PreCheckInject string `yaml:"preCheckInject,omitempty" json:"preCheckInject,omitempty"`
+	PreCheckTTL  int    `yaml:"preCheckTtl,omitempty" json:"preCheckTtl,omitempty"`
 }
 
 // ConfigFile represents the root of the YAML configuration
@@ -48,20 +115,241 @@ func loadMonitorsFromYAML(configPath string) ([]Monitor, []string, error) {
 		return nil, nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
-	monitors := make([]Monitor, 0, len(config.Monitors))
-	hashes := make([]string, 0, len(config.Monitors))
-	
-		for _, cfg := range config.Monitors {
+	monitors, hashes := monitorsFromConfigs(config.Monitors, ConfigSourceYAML)
+
+	log.Info().Int("count", len(monitors)).Str("config_path", configPath).Msg("[Config] Loaded monitors")
+	return monitors, hashes, nil
+}
+
+// monitorsFromConfigs validates monitor configs and converts them to monitors tagged with their source
+// Invalid entries are skipped with a warning; returns monitors with their config hashes calculated
+func monitorsFromConfigs(configs []MonitorConfig, source string) ([]Monitor, []string) {
+	monitors := make([]Monitor, 0, len(configs))
+	hashes := make([]string, 0, len(configs))
+	seenNames := make(map[string]bool, len(configs))
+
+	for _, cfg := range configs {
 		// Validate required fields
-		if cfg.Name == "" || cfg.URL == "" {
+		// Push monitors aren't polled, so their URL is optional
+		if cfg.Name == "" || (cfg.URL == "" && cfg.Type != MonitorTypePush) {
 			log.Warn().Msg("[Config] Skipping monitor with missing name or URL")
 			continue
 		}
 
+		if err := validateMonitorType(cfg.Type); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid type")
+			continue
+		}
+
+		if err := validateHeartbeatGrace(cfg.HeartbeatGrace); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid heartbeat grace")
+			continue
+		}
+
+		if cfg.URL != "" {
+			if err := validateURLScheme(cfg.URL); err != nil {
+				log.Warn().Err(err).Str("name", cfg.Name).Str("url", cfg.URL).Msg("[Config] Skipping monitor with unsupported URL scheme")
+				continue
+			}
+		}
+
 		// Set default check interval
+		if err := validateCheckInterval(cfg.CheckInterval); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Int("check_interval", cfg.CheckInterval).Msg("[Config] Skipping monitor with invalid check interval")
+			continue
+		}
 		checkInterval := cfg.CheckInterval
-		if checkInterval <= 0 {
-			checkInterval = 60
+		if checkInterval == 0 {
+			checkInterval = DefaultCheckInterval
+		}
+
+		if err := validateTimeoutSeconds(cfg.TimeoutSeconds); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid timeout")
+			continue
+		}
+
+		if !isValidRedirectPolicy(cfg.RedirectPolicy) {
+			log.Warn().Str("name", cfg.Name).Str("redirect_policy", cfg.RedirectPolicy).Msg("[Config] Skipping monitor with invalid redirect policy")
+			continue
+		}
+
+		if err := validateMaxRedirects(cfg.MaxRedirects); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid redirect limit")
+			continue
+		}
+
+		if err := validateFollowRedirects(cfg.FollowRedirects, cfg.RedirectPolicy, cfg.MaxRedirects); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with conflicting redirect settings")
+			continue
+		}
+
+		if err := validateCacheAgeRange(cfg.MinCacheAge, cfg.MaxCacheAge); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid cache age range")
+			continue
+		}
+
+		if err := validateLatencyWindow(cfg.LatencyWindow, cfg.LatencyThreshold); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid latency window")
+			continue
+		}
+
+		if err := validateDegradedThreshold(cfg.DegradedThresholdMs); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid degraded threshold")
+			continue
+		}
+
+		if err := validateSampleRate(cfg.SampleRate); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid sample rate")
+			continue
+		}
+
+		if err := validateStatusOutcomes(cfg.StatusOutcomes); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid status outcomes")
+			continue
+		}
+
+		if err := validateExpectedStatusCodes(cfg.ExpectedStatusCodes); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid expected status codes")
+			continue
+		}
+
+		if err := validateExtractLabel(strings.TrimSpace(cfg.ExtractLabel)); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid label extraction")
+			continue
+		}
+
+		if err := validateTags(cfg.Tags); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid tags")
+			continue
+		}
+
+		if err := validateGroup(cfg.Group); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid group")
+			continue
+		}
+
+		if err := validateEndpoints(cfg.Endpoints); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid endpoints")
+			continue
+		}
+
+		if err := validateUDPProbe(cfg.UDPProbe, cfg.UDPExpect); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid UDP probe")
+			continue
+		}
+
+		if err := validateAdaptiveBounds(cfg.MinAdaptiveInterval, cfg.MaxAdaptiveInterval); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid adaptive interval bounds")
+			continue
+		}
+
+		if err := validateRequestBody(cfg.RequestBody); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid request body")
+			continue
+		}
+
+		if err := validateDependsOn(cfg.Name, cfg.DependsOn); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid dependency")
+			continue
+		}
+
+		if err := validateMethod(cfg.Method, cfg.RequestBody); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Str("method", cfg.Method).Msg("[Config] Skipping monitor with invalid method")
+			continue
+		}
+
+		if err := validateContentType(cfg.ContentType); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid content type")
+			continue
+		}
+
+		if err := validateExpectKeyword(cfg.ExpectKeyword, cfg.ExpectKeywordAbsent, cfg.Method); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid keyword assertion")
+			continue
+		}
+
+		if err := validateLightCheck(cfg.LightCheck, cfg.Method, cfg.RequestBody, cfg.ExpectKeyword, cfg.JSONSchema, cfg.ExtractLabel); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid light check")
+			continue
+		}
+
+		if err := validateHTTP3(cfg.HTTP3, cfg.Type, cfg.URL, cfg.Endpoints, cfg.PreCheckURL); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid http3 setting")
+			continue
+		}
+
+		if err := validateMaxBodyBytes(cfg.MaxBodyBytes); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid body read limit")
+			continue
+		}
+
+		if err := validateSLATarget(cfg.SLATarget); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid SLA target")
+			continue
+		}
+
+		if err := validateSourceIP(cfg.SourceIP); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid source IP")
+			continue
+		}
+
+		if err := validateDNSServer(strings.TrimSpace(cfg.DNSServer)); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Str("dns_server", cfg.DNSServer).Msg("[Config] Skipping monitor with invalid DNS server")
+			continue
+		}
+
+		if err := validateExpectedIP(strings.TrimSpace(cfg.ExpectedIP)); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid expected IP")
+			continue
+		}
+
+		if err := validateAutoRetire(cfg.AutoRetireAfter, cfg.AutoRetireAction); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid auto-retire settings")
+			continue
+		}
+
+		if !isValidResponseTimeMode(cfg.ResponseTimeMode) {
+			log.Warn().Str("name", cfg.Name).Str("response_time_mode", cfg.ResponseTimeMode).Msg("[Config] Skipping monitor with invalid response time mode")
+			continue
+		}
+
+		if err := validateJSONSchema(cfg.JSONSchema); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid JSON schema")
+			continue
+		}
+
+		if err := validatePreCheck(cfg.PreCheckURL, cfg.PreCheckMethod, cfg.PreCheckExtract, cfg.PreCheckInject, cfg.PreCheckTTL); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid pre-check step")
+			continue
+		}
+
+		if cfg.GracePeriod < 0 {
+			log.Warn().Str("name", cfg.Name).Int("grace_period", cfg.GracePeriod).Msg("[Config] Skipping monitor with negative grace period")
+			continue
+		}
+
+		if cfg.EscalateAfter < 0 {
+			log.Warn().Str("name", cfg.Name).Int("escalate_after", cfg.EscalateAfter).Msg("[Config] Skipping monitor with negative escalation delay")
+			continue
+		}
+
+		if err := validateFailureThreshold(cfg.FailureThreshold); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid failure threshold")
+			continue
+		}
+
+		if err := validateRetries(cfg.RetryCount, cfg.RetryDelayMs); err != nil {
+			log.Warn().Err(err).Str("name", cfg.Name).Msg("[Config] Skipping monitor with invalid retries")
+			continue
+		}
+
+		if enforceUniqueNames {
+			nameKey := strings.ToLower(cfg.Name)
+			if seenNames[nameKey] {
+				log.Warn().Str("name", cfg.Name).Msg("[Config] Skipping monitor with duplicate name")
+				continue
+			}
+			seenNames[nameKey] = true
 		}
 
 		// Calculate hash for this config
@@ -72,9 +360,68 @@ func loadMonitorsFromYAML(configPath string) ([]Monitor, []string, error) {
 			URL:          cfg.URL,
 			Icon:         cfg.Icon,
 			CheckInterval: checkInterval,
+			TimeoutSeconds: cfg.TimeoutSeconds,
 			IsThirdParty: cfg.IsThirdParty,
 			Paused:       cfg.Paused,
+			RedirectPolicy: cfg.RedirectPolicy,
+			MaxRedirects: cfg.MaxRedirects,
+			FollowRedirects: cfg.FollowRedirects,
+			StatusOutcomes: cfg.StatusOutcomes,
+			ExpectedStatusCodes: strings.TrimSpace(cfg.ExpectedStatusCodes),
+			MinCacheAge:  cfg.MinCacheAge,
+			MaxCacheAge:  cfg.MaxCacheAge,
+			Tags:         uniqueTags(cfg.Tags),
+			Group:        strings.TrimSpace(cfg.Group),
+			Endpoints:    cfg.Endpoints,
+			ParallelEndpoints: cfg.ParallelEndpoints,
+			GracePeriod:  cfg.GracePeriod,
+			EscalateAfter: cfg.EscalateAfter,
+			FailureThreshold: cfg.FailureThreshold,
+			RetryCount:   cfg.RetryCount,
+			RetryDelayMs: cfg.RetryDelayMs,
+			Type:         cfg.Type,
+			HeartbeatGrace: cfg.HeartbeatGrace,
+			StoreHeaders: cfg.StoreHeaders,
+			LightCheck:   cfg.LightCheck,
+			HTTP3:        cfg.HTTP3,
+			UDPProbe:     cfg.UDPProbe,
+			UDPExpect:    cfg.UDPExpect,
+			WebSocketPing: cfg.WebSocketPing,
+			AdaptiveInterval: cfg.AdaptiveInterval,
+			MinAdaptiveInterval: cfg.MinAdaptiveInterval,
+			MaxAdaptiveInterval: cfg.MaxAdaptiveInterval,
+			VersionHeader: strings.TrimSpace(cfg.VersionHeader),
+			ExtractLabel: strings.TrimSpace(cfg.ExtractLabel),
+			WatchValidators: cfg.WatchValidators,
+			WatchCertificate: cfg.WatchCertificate,
+			DependsOn:    strings.TrimSpace(cfg.DependsOn),
+			Method:       strings.ToUpper(strings.TrimSpace(cfg.Method)),
+			RequestBody:  cfg.RequestBody,
+			ContentType:  strings.TrimSpace(cfg.ContentType),
+			ExpectKeyword: cfg.ExpectKeyword,
+			ExpectKeywordAbsent: cfg.ExpectKeywordAbsent,
+			MaxBodyBytes: cfg.MaxBodyBytes,
+			JSONSchema:   strings.TrimSpace(cfg.JSONSchema),
+			SLATarget:    cfg.SLATarget,
+			SourceIP:     cfg.SourceIP,
+			DNSServer:    strings.TrimSpace(cfg.DNSServer),
+			ExpectedIP:   strings.TrimSpace(cfg.ExpectedIP),
+			AutoRetireAfter: cfg.AutoRetireAfter,
+			AutoRetireAction: cfg.AutoRetireAction,
+			ResponseTimeMode: cfg.ResponseTimeMode,
+			LatencyWindow: cfg.LatencyWindow,
+			LatencyThreshold: cfg.LatencyThreshold,
+			DegradedThresholdMs: cfg.DegradedThresholdMs,
+			SampleRate:   cfg.SampleRate,
+			StoreHistory: cfg.StoreHistory,
+			PreCheckURL:  cfg.PreCheckURL,
+			PreCheckMethod: strings.ToUpper(cfg.PreCheckMethod),
+			PreCheckBody: cfg.PreCheckBody,
+			PreCheckExtract: cfg.PreCheckExtract,
+			PreCheckInject: cfg.PreCheckInject,
+			PreCheckTTL:  cfg.PreCheckTTL,
 			ConfigHash:   configHash,
+			ConfigSource: source,
 			Status:       "unknown",
 			Uptime:       0,
 			ResponseTime: 0,
@@ -85,8 +432,7 @@ func loadMonitorsFromYAML(configPath string) ([]Monitor, []string, error) {
 		hashes = append(hashes, configHash)
 	}
 
-	log.Info().Int("count", len(monitors)).Str("config_path", configPath).Msg("[Config] Loaded monitors")
-	return monitors, hashes, nil
+	return monitors, hashes
 }
 
 // calculateConfigHash calculates a SHA256 hash of the monitor configuration
@@ -101,8 +447,186 @@ func calculateConfigHash(cfg MonitorConfig) string {
 		cfg.IsThirdParty,
 		cfg.Paused,
 	)
+
+	// Optional fields are only appended when set so existing hashes stay stable
+	if cfg.TimeoutSeconds > 0 {
+		configStr += fmt.Sprintf("|timeout=%d", cfg.TimeoutSeconds)
+	}
+	if cfg.RedirectPolicy != "" {
+		configStr += "|redirectPolicy=" + cfg.RedirectPolicy
+	}
+	if cfg.MaxRedirects > 0 {
+		configStr += fmt.Sprintf("|maxRedirects=%d", cfg.MaxRedirects)
+	}
+	if cfg.FollowRedirects != nil && !*cfg.FollowRedirects {
+		configStr += "|followRedirects=false"
+	}
+	if cfg.MinCacheAge != 0 || cfg.MaxCacheAge != 0 {
+		configStr += fmt.Sprintf("|cacheAge=%d-%d", cfg.MinCacheAge, cfg.MaxCacheAge)
+	}
+	if cfg.LatencyWindow > 0 {
+		configStr += fmt.Sprintf("|latencyWindow=%d|latencyThreshold=%d", cfg.LatencyWindow, cfg.LatencyThreshold)
+	}
+	if cfg.DegradedThresholdMs > 0 {
+		configStr += fmt.Sprintf("|degradedThresholdMs=%d", cfg.DegradedThresholdMs)
+	}
+	if cfg.SampleRate > 1 {
+		configStr += fmt.Sprintf("|sampleRate=%d", cfg.SampleRate)
+	}
+	if cfg.StoreHistory != nil && !*cfg.StoreHistory {
+		configStr += "|storeHistory=false"
+	}
+	if cfg.StatusOutcomes != "" {
+		configStr += "|statusOutcomes=" + cfg.StatusOutcomes
+	}
+	if cfg.ExpectedStatusCodes != "" {
+		configStr += "|expectedStatusCodes=" + strings.TrimSpace(cfg.ExpectedStatusCodes)
+	}
+	if len(cfg.Tags) > 0 {
+		configStr += "|tags=" + strings.Join(cfg.Tags, ",")
+	}
+	if cfg.Group != "" {
+		configStr += "|group=" + strings.TrimSpace(cfg.Group)
+	}
+	if len(cfg.Endpoints) > 0 {
+		configStr += fmt.Sprintf("|endpoints=%s|parallel=%v", strings.Join(cfg.Endpoints, ","), cfg.ParallelEndpoints)
+	}
+	if cfg.GracePeriod != 0 {
+		configStr += fmt.Sprintf("|gracePeriod=%d", cfg.GracePeriod)
+	}
+	if cfg.FailureThreshold > 1 {
+		configStr += fmt.Sprintf("|failureThreshold=%d", cfg.FailureThreshold)
+	}
+	if cfg.RetryCount > 0 {
+		configStr += fmt.Sprintf("|retryCount=%d|retryDelayMs=%d", cfg.RetryCount, cfg.RetryDelayMs)
+	}
+	if cfg.Type != "" {
+		configStr += fmt.Sprintf("|type=%s|heartbeatGrace=%d", cfg.Type, cfg.HeartbeatGrace)
+	}
+	if cfg.EscalateAfter != 0 {
+		configStr += fmt.Sprintf("|escalateAfter=%d", cfg.EscalateAfter)
+	}
+	if cfg.StoreHeaders {
+		configStr += "|storeHeaders=true"
+	}
+	if cfg.LightCheck {
+		configStr += "|lightCheck=true"
+	}
+	if cfg.HTTP3 {
+		configStr += "|http3=true"
+	}
+	if cfg.UDPProbe != "" || cfg.UDPExpect != "" {
+		configStr += fmt.Sprintf("|udpProbe=%s|udpExpect=%s", cfg.UDPProbe, cfg.UDPExpect)
+	}
+	if cfg.WebSocketPing {
+		configStr += "|websocketPing"
+	}
+	if cfg.AdaptiveInterval {
+		configStr += fmt.Sprintf("|adaptive=%d-%d", cfg.MinAdaptiveInterval, cfg.MaxAdaptiveInterval)
+	}
+	if cfg.VersionHeader != "" {
+		configStr += "|versionHeader=" + cfg.VersionHeader
+	}
+	if cfg.ExtractLabel != "" {
+		configStr += "|extractLabel=" + strings.TrimSpace(cfg.ExtractLabel)
+	}
+	if cfg.WatchValidators {
+		configStr += "|watchValidators"
+	}
+	if cfg.WatchCertificate {
+		configStr += "|watchCertificate"
+	}
+	if cfg.DependsOn != "" {
+		configStr += "|dependsOn=" + strings.TrimSpace(cfg.DependsOn)
+	}
+	if cfg.Method != "" {
+		configStr += "|method=" + strings.ToUpper(strings.TrimSpace(cfg.Method))
+	}
+	if cfg.RequestBody != "" {
+		configStr += "|requestBody=" + cfg.RequestBody
+	}
+	if cfg.ContentType != "" {
+		configStr += "|contentType=" + cfg.ContentType
+	}
+	if cfg.ExpectKeyword != "" {
+		configStr += "|expectKeyword=" + cfg.ExpectKeyword
+	}
+	if cfg.ExpectKeywordAbsent {
+		configStr += "|expectKeywordAbsent"
+	}
+	if cfg.MaxBodyBytes > 0 {
+		configStr += fmt.Sprintf("|maxBodyBytes=%d", cfg.MaxBodyBytes)
+	}
+	if cfg.JSONSchema != "" {
+		configStr += "|jsonSchema=" + cfg.JSONSchema
+	}
+	if cfg.SLATarget != 0 {
+		configStr += fmt.Sprintf("|slaTarget=%g", cfg.SLATarget)
+	}
+	if cfg.SourceIP != "" {
+		configStr += "|sourceIp=" + cfg.SourceIP
+	}
+	if cfg.DNSServer != "" {
+		configStr += "|dnsServer=" + strings.TrimSpace(cfg.DNSServer)
+	}
+	if cfg.ExpectedIP != "" {
+		configStr += "|expectedIp=" + strings.TrimSpace(cfg.ExpectedIP)
+	}
+	if cfg.AutoRetireAfter != 0 {
+		configStr += fmt.Sprintf("|autoRetire=%d|%s", cfg.AutoRetireAfter, cfg.AutoRetireAction)
+	}
+	if cfg.ResponseTimeMode != "" {
+		configStr += "|responseTimeMode=" + cfg.ResponseTimeMode
+	}
+	if cfg.PreCheckURL != "" {
+		configStr += fmt.Sprintf("|preCheck=%s|%s|%s|%s|%s|%d", cfg.PreCheckURL, cfg.PreCheckMethod,
+			cfg.PreCheckBody, cfg.PreCheckExtract, cfg.PreCheckInject, cfg.PreCheckTTL)
+	}
 	
 	hash := sha256.Sum256([]byte(configStr))
 	return hex.EncodeToString(hash[:])
 }
 
+
+
+// getEnvInt reads an integer environment variable, returning def when unset or invalid
+func getEnvInt(key string, def int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Warn().Str("key", key).Str("value", value).Int("default", def).Msg("[Config] Invalid integer in environment, using default")
+		return def
+	}
+	return parsed
+}
+
+// getEnvFloat reads a float environment variable, returning def when unset or invalid
+func getEnvFloat(key string, def float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Warn().Str("key", key).Str("value", value).Float64("default", def).Msg("[Config] Invalid number in environment, using default")
+		return def
+	}
+	return parsed
+}
+
+// getEnvBool reads a boolean environment variable, returning def when unset or invalid
+func getEnvBool(key string, def bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Warn().Str("key", key).Str("value", value).Bool("default", def).Msg("[Config] Invalid boolean in environment, using default")
+		return def
+	}
+	return parsed
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"database/sql"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/driver/sqlite"
@@ -11,15 +13,26 @@ import (
 	_ "modernc.org/sqlite"
 )
 
+// getDBPath returns the database path from DB_PATH, defaulting to ./nanostatus.db
+func getDBPath() string {
+	dbPath := os.Getenv("DB_PATH")
+	if dbPath == "" {
+		dbPath = "./nanostatus.db"
+	}
+	return dbPath
+}
+
+// monitorsConfigPath returns the monitors.yaml path, which lives next to the database
+func monitorsConfigPath(dbPath string) string {
+	return filepath.Join(filepath.Dir(dbPath), "monitors.yaml")
+}
+
 // initDB initializes the database connection and runs migrations
 func initDB() {
 	var err error
 	// Use pure Go SQLite driver (no CGO required)
 	// Database path can be set via DB_PATH env var, defaults to ./nanostatus.db
-	dbPath := os.Getenv("DB_PATH")
-	if dbPath == "" {
-		dbPath = "./nanostatus.db"
-	}
+	dbPath := getDBPath()
 	
 	// Ensure the directory exists (for Docker volumes)
 	if dir := filepath.Dir(dbPath); dir != "." && dir != "" {
@@ -54,11 +67,27 @@ func initDB() {
 		log.Fatal().Err(err).Msg("Failed to connect to database")
 	}
 
-	// Auto-migrate schemas
-	if err := db.AutoMigrate(&Monitor{}, &CheckHistory{}, &CheckHistoryBucket{}); err != nil {
+	// Auto-migrate schemas, then add the indexes and views GORM can't express
+	if err := migrateDB(db); err != nil {
 		log.Fatal().Err(err).Msg("Failed to migrate database")
 	}
 
+	log.Info().Str("path", dbPath).Msg("✅ Database initialized")
+
+	// Normalize corrupted stored values before anything schedules or aggregates them
+	repairMonitorData()
+	ensureHeartbeatTokens()
+
+	// Always sync YAML config on startup (creates if empty, updates if changed)
+	syncYAMLConfig(dbPath)
+}
+
+// migrateDB creates or updates the tables, the indexes GORM tags can't express, and the aggregation views
+func migrateDB(db *gorm.DB) error {
+	if err := db.AutoMigrate(&Monitor{}, &CheckHistory{}, &CheckHistoryBucket{}, &VersionChange{}, &ValidatorChange{}, &StatsSnapshot{}, &Setting{}, &MaintenanceWindow{}, &Incident{}); err != nil {
+		return err
+	}
+
 	// Create partial index for active monitors (SQLite doesn't support partial indexes in GORM tags)
 	if err := db.Exec(`
 		CREATE INDEX IF NOT EXISTS idx_monitors_active 
@@ -78,13 +107,9 @@ func initDB() {
 
 	// Create aggregation views
 	if err := createAggregationViews(db); err != nil {
-		log.Fatal().Err(err).Msg("Failed to create aggregation views")
+		return fmt.Errorf("failed to create aggregation views: %w", err)
 	}
-
-	log.Info().Str("path", dbPath).Msg("✅ Database initialized")
-
-	// Always sync YAML config on startup (creates if empty, updates if changed)
-	syncYAMLConfig(dbPath)
+	return nil
 }
 
 // syncYAMLConfig synchronizes monitors from YAML config with the database
@@ -92,8 +117,7 @@ func initDB() {
 // Compares hashes to detect changes and updates monitors accordingly
 func syncYAMLConfig(dbPath string) {
 	// Look for monitors.yaml in the same directory as the database
-	dbDir := filepath.Dir(dbPath)
-	configPath := filepath.Join(dbDir, "monitors.yaml")
+	configPath := monitorsConfigPath(dbPath)
 	
 	// Try to load from YAML config file
 	yamlMonitors, yamlHashes, err := loadMonitorsFromYAML(configPath)
@@ -101,20 +125,6 @@ func syncYAMLConfig(dbPath string) {
 		log.Warn().Err(err).Str("config_path", configPath).Msg("[Config] Failed to load YAML config")
 	}
 	
-	// Get all existing monitors from database
-	var existingMonitors []Monitor
-	db.Find(&existingMonitors)
-	
-	// Create a map of existing monitors by config hash (only YAML-managed monitors)
-	existingByHash := make(map[string]*Monitor)
-	existingByID := make(map[uint]*Monitor)
-	for i := range existingMonitors {
-		existingByID[existingMonitors[i].ID] = &existingMonitors[i]
-		if existingMonitors[i].ConfigHash != "" {
-			existingByHash[existingMonitors[i].ConfigHash] = &existingMonitors[i]
-		}
-	}
-	
 	// If no YAML config found and database is empty, use defaults
 	if len(yamlMonitors) == 0 {
 		var count int64
@@ -128,7 +138,7 @@ func syncYAMLConfig(dbPath string) {
 					Status:       "up",
 					ResponseTime: 229,
 					LastCheck:    "5s ago",
-					CheckInterval: 60,
+					CheckInterval: DefaultCheckInterval,
 				},
 				{
 					Name:         "Google",
@@ -137,7 +147,7 @@ func syncYAMLConfig(dbPath string) {
 					ResponseTime: 2097,
 					LastCheck:    "1s ago",
 					IsThirdParty: true,
-					CheckInterval: 60,
+					CheckInterval: DefaultCheckInterval,
 				},
 			}
 			for _, monitor := range defaultMonitors {
@@ -153,38 +163,96 @@ func syncYAMLConfig(dbPath string) {
 	}
 	
 	log.Info().Int("count", len(yamlMonitors)).Msg("[Config] Syncing monitors from YAML configuration")
+	syncConfigMonitors(ConfigSourceYAML, yamlMonitors, yamlHashes, true)
+	log.Info().Msg("[Config] ✅ YAML configuration synchronized")
+}
+
+// configSyncMu serializes syncs from different config sources
+var configSyncMu sync.Mutex
+
+// ConfigSyncResult counts what a config sync did
+type ConfigSyncResult struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Skipped   int `json:"skipped"` // Monitors created via UI/API or managed by another config source
+	Failed    int `json:"failed"`  // Database errors while saving
+	Removed   int `json:"removed"`
+}
+
+// syncConfigMonitors reconciles the config-managed monitors of one source with the database
+// Monitors are matched by config hash, then by name/URL; with removeMissing, monitors from this source
+// that are no longer configured are removed. Monitors from other sources and UI/API monitors are left alone
+func syncConfigMonitors(source string, configMonitors []Monitor, configHashes []string, removeMissing bool) ConfigSyncResult {
+	configSyncMu.Lock()
+	defer configSyncMu.Unlock()
+
+	// Batch the monitor updates of this sync, including the checks it starts, for SSE clients
+	endBatch := monitorBatcher.begin()
+	var checks sync.WaitGroup
+	defer func() {
+		go func() {
+			checks.Wait()
+			endBatch()
+		}()
+	}()
+
+	var result ConfigSyncResult
+
+	// Get all existing monitors from database
+	var existingMonitors []Monitor
+	db.Find(&existingMonitors)
 	
-	// Track which YAML hashes we've processed
+	// Create a map of existing monitors by config hash (only monitors managed by this source)
+	existingByHash := make(map[string]*Monitor)
+	for i := range existingMonitors {
+		if existingMonitors[i].ConfigHash != "" && monitorConfigSource(&existingMonitors[i]) == source {
+			existingByHash[existingMonitors[i].ConfigHash] = &existingMonitors[i]
+		}
+	}
+
+	// Track which config hashes we've processed
 	processedHashes := make(map[string]bool)
 	
-	// Process each monitor from YAML
-	for i, monitor := range yamlMonitors {
-		hash := yamlHashes[i]
+	// Process each configured monitor
+	for i, monitor := range configMonitors {
+		hash := configHashes[i]
 		processedHashes[hash] = true
 		
 		// Check if a monitor with this hash already exists
 		if _, exists := existingByHash[hash]; exists {
 			// Monitor exists with same hash - no changes needed
 			log.Debug().Str("name", monitor.Name).Str("url", monitor.URL).Str("hash", hash[:8]).Msg("[Config] Monitor unchanged")
+			result.Unchanged++
 			continue
 		}
 		
 		// Check if a monitor with same name/URL exists
 		var existingMonitor Monitor
-		result := db.Where("name = ? AND url = ?", monitor.Name, monitor.URL).First(&existingMonitor)
+		lookup := db.Where("name = ? AND url = ?", monitor.Name, monitor.URL).First(&existingMonitor)
 		
 		// Check if record exists (ignore "record not found" error as it's expected)
-		if result.Error == nil {
+		if lookup.Error == nil {
 			// Monitor with same name/URL exists
 			if existingMonitor.ConfigHash == "" {
 				// Monitor was created via UI/API (no ConfigHash) - skip YAML version to avoid duplicates
 				log.Debug().Str("name", monitor.Name).Str("url", monitor.URL).Msg("[Config] Skipping monitor - already exists (created via UI/API)")
+				result.Skipped++
+				continue
+			}
+			if monitorConfigSource(&existingMonitor) != source {
+				// Another config source already manages this monitor - don't fight over it
+				log.Warn().Str("name", monitor.Name).Str("url", monitor.URL).Str("source", source).
+					Str("managed_by", monitorConfigSource(&existingMonitor)).
+					Msg("[Config] Skipping monitor - already managed by another config source")
+				result.Skipped++
 				continue
 			}
 			
 			// Monitor exists and is YAML-managed - check if hash changed
 			if existingMonitor.ConfigHash == hash {
 				// Hash matches - no update needed (shouldn't reach here due to existingByHash check, but just in case)
+				result.Unchanged++
 				continue
 			}
 			
@@ -200,64 +268,97 @@ func syncYAMLConfig(dbPath string) {
 			monitor.ResponseTime = existingMonitor.ResponseTime
 			monitor.LastCheck = existingMonitor.LastCheck
 			monitor.CreatedAt = existingMonitor.CreatedAt
+			monitor.HeartbeatToken = existingMonitor.HeartbeatToken
+			monitor.ensureHeartbeatToken()
 			
 			if err := db.Save(&monitor).Error; err != nil {
 				log.Error().Err(err).Str("name", monitor.Name).Msg("[Config] Failed to update monitor")
+				result.Failed++
 			} else {
 				log.Info().Str("name", monitor.Name).Str("url", monitor.URL).Msg("[Config] Updated monitor")
-				broadcastUpdate("monitor_update", monitor)
+				result.Updated++
+				broadcastMonitorUpdate(monitor)
 				// Re-check if not paused
 				if !monitor.Paused {
-					go checkService(&monitor)
+					checks.Add(1)
+					go func() {
+						defer checks.Done()
+						checkService(&monitor)
+					}()
 				}
 			}
 		} else {
 			// New monitor - create it
+			monitor.ensureHeartbeatToken()
 			if err := db.Create(&monitor).Error; err != nil {
 				log.Error().Err(err).Str("name", monitor.Name).Msg("[Config] Failed to create monitor")
+				result.Failed++
 			} else {
 				log.Info().Str("name", monitor.Name).Str("url", monitor.URL).Str("hash", hash[:8]).Msg("[Config] Created monitor")
+				result.Created++
 				broadcastUpdate("monitor_added", monitor)
 				// Immediately check the monitor
-				go checkService(&monitor)
+				checks.Add(1)
+				go func() {
+					defer checks.Done()
+					checkService(&monitor)
+				}()
 			}
 		}
 	}
 	
-	// Remove monitors that were in YAML but are no longer present
-	// Only remove monitors that have a config_hash (were created from YAML)
+	// Remove monitors that were in this source but are no longer present
+	// Only remove monitors that have a config_hash (were created from config)
 	for hash, existing := range existingByHash {
-		if !processedHashes[hash] {
+		if removeMissing && !processedHashes[hash] {
 			log.Info().Str("name", existing.Name).Str("url", existing.URL).Str("hash", hash[:8]).
-				Msg("[Config] Removing monitor - no longer in YAML config")
+				Str("source", source).Msg("[Config] Removing monitor - no longer in config")
 			
 			monitorID := existing.ID
 			if err := db.Delete(&Monitor{}, monitorID).Error; err != nil {
 				log.Error().Err(err).Str("name", existing.Name).Msg("[Config] Failed to delete monitor")
 			} else {
 				log.Info().Str("name", existing.Name).Str("url", existing.URL).Msg("[Config] Deleted monitor")
+				alertManager.forget(monitorID)
 				broadcastUpdate("monitor_deleted", map[string]interface{}{"id": monitorID})
+				result.Removed++
 			}
 		}
 	}
 	
 	broadcastStatsIfChanged()
-	log.Info().Msg("[Config] ✅ YAML configuration synchronized")
+	return result
+}
+
+// monitorConfigSource returns the config source of a config-managed monitor
+// Monitors created before remote sources existed have no source and came from YAML
+func monitorConfigSource(monitor *Monitor) string {
+	if monitor.ConfigSource == "" {
+		return ConfigSourceYAML
+	}
+	return monitor.ConfigSource
 }
 
 // createAggregationViews creates SQL views for common aggregations
 func createAggregationViews(db *gorm.DB) error {
+	// Views embed the uptime policy, so recreate them on every start in case it changed
+	for _, view := range []string{"monitor_stats_24h", "global_stats_24h"} {
+		if err := db.Exec("DROP VIEW IF EXISTS " + view).Error; err != nil {
+			return err
+		}
+	}
+
 	// View 1: monitor_stats_24h - Pre-aggregates 24-hour uptime stats per monitor
 	view1SQL := `
 		CREATE VIEW IF NOT EXISTS monitor_stats_24h AS
 		SELECT 
 			monitor_id,
 			COUNT(*) as total_checks,
-			SUM(CASE WHEN status = 'up' THEN 1 ELSE 0 END) as up_checks,
-			CAST(SUM(CASE WHEN status = 'up' THEN 1 ELSE 0 END) AS REAL) / COUNT(*) * 100.0 as uptime_percent,
+			SUM(CASE WHEN ` + uptimeStatusSQL("status") + ` THEN 1 ELSE 0 END) as up_checks,
+			CAST(SUM(CASE WHEN ` + uptimeStatusSQL("status") + ` THEN 1 ELSE 0 END) AS REAL) / COUNT(*) * 100.0 as uptime_percent,
 			AVG(CASE WHEN status = 'up' AND response_time > 0 THEN response_time ELSE NULL END) as avg_response_time
 		FROM check_histories
-		WHERE created_at > datetime('now', '-24 hours')
+		WHERE created_at > datetime('now', '-24 hours') AND in_maintenance = 0
 		GROUP BY monitor_id
 	`
 
@@ -270,7 +371,7 @@ func createAggregationViews(db *gorm.DB) error {
 		CREATE VIEW IF NOT EXISTS global_stats_24h AS
 		SELECT 
 			COUNT(DISTINCT m.id) as total_monitors,
-			SUM(CASE WHEN m.status = 'up' AND m.paused = 0 THEN 1 ELSE 0 END) as services_up,
+			SUM(CASE WHEN ` + uptimeStatusSQL("m.status") + ` AND m.paused = 0 THEN 1 ELSE 0 END) as services_up,
 			SUM(CASE WHEN m.status = 'down' AND m.paused = 0 THEN 1 ELSE 0 END) as services_down,
 			AVG(CASE WHEN m.paused = 0 THEN m.uptime ELSE NULL END) as overall_uptime,
 			AVG(CASE WHEN ch.status = 'up' AND ch.response_time > 0 AND ch.created_at > datetime('now', '-24 hours') 
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB points the global db at a fresh, migrated database in a temporary directory
+// Unlike initDB it seeds no monitors, so nothing is scheduled or checked
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	testDB, err := gorm.Open(sqlite.Dialector{DriverName: "sqlite", DSN: filepath.Join(t.TempDir(), "nanostatus.db")},
+		&gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrateDB(testDB); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := db
+	db = testDB
+	t.Cleanup(func() {
+		// A debounced stats broadcast must not run against the restored database
+		debounceMu.Lock()
+		if statsDebouncer != nil {
+			statsDebouncer.Stop()
+		}
+		debounceMu.Unlock()
+		if sqlDB, err := testDB.DB(); err == nil {
+			sqlDB.Close()
+		}
+		db = previous
+	})
+	return testDB
+}
+
+func TestMigrateDBCreatesActiveMonitorsIndex(t *testing.T) {
+	db := newTestDB(t)
+
+	var indexSQL string
+	if err := db.Raw("SELECT sql FROM sqlite_master WHERE type = 'index' AND name = 'idx_monitors_active'").Scan(&indexSQL).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(indexSQL, "WHERE paused = 0") {
+		t.Fatalf("idx_monitors_active = %q, want a partial index on unpaused monitors", indexSQL)
+	}
+
+	// Migrating an existing database keeps the index
+	if err := migrateDB(db); err != nil {
+		t.Fatalf("second migration failed: %v", err)
+	}
+	var count int64
+	db.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'idx_monitors_active'").Scan(&count)
+	if count != 1 {
+		t.Errorf("%d idx_monitors_active indexes after a second migration, want 1", count)
+	}
+}
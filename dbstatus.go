@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// dbPingTimeout bounds the connectivity check; the single pooled connection may be busy with a long query
+const dbPingTimeout = 5 * time.Second
+
+// DBStatusResponse reports database connectivity and how busy the connection pool is
+type DBStatusResponse struct {
+	Ping      DBPing `json:"ping"`
+	Pool      DBPool `json:"pool"`
+	Rows      DBRows `json:"rows"`
+	SizeBytes int64  `json:"sizeBytes"` // Database file plus WAL
+}
+
+// DBPing is the result of pinging the database through the pool
+type DBPing struct {
+	OK        bool    `json:"ok"`
+	LatencyMs float64 `json:"latencyMs"` // Includes waiting for the pooled connection
+	Error     string  `json:"error,omitempty"`
+}
+
+// DBPool is a snapshot of database/sql's pool statistics
+// The pool holds a single connection, so a growing waitCount or waitDurationMs means queries queue behind each other
+type DBPool struct {
+	MaxOpenConnections int     `json:"maxOpenConnections"`
+	OpenConnections    int     `json:"openConnections"`
+	InUse              int     `json:"inUse"`
+	Idle               int     `json:"idle"`
+	WaitCount          int64   `json:"waitCount"`      // Queries that had to wait for a connection since startup
+	WaitDurationMs     float64 `json:"waitDurationMs"` // Total time spent waiting since startup
+	MaxIdleClosed      int64   `json:"maxIdleClosed"`
+	MaxLifetimeClosed  int64   `json:"maxLifetimeClosed"`
+}
+
+// DBRows counts the rows of the main tables
+type DBRows struct {
+	Monitors       int64 `json:"monitors"`
+	CheckHistories int64 `json:"checkHistories"`
+}
+
+// getDBStatus pings the database and reads the pool statistics and row counts
+func getDBStatus() (DBStatusResponse, error) {
+	var response DBStatusResponse
+	sqlDB, err := db.DB()
+	if err != nil {
+		return response, err
+	}
+
+	// Read the pool before pinging and counting, so the snapshot shows the load from other requests
+	stats := sqlDB.Stats()
+	response.Pool = DBPool{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     float64(stats.WaitDuration.Microseconds()) / 1000,
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbPingTimeout)
+	defer cancel()
+	start := time.Now()
+	err = sqlDB.PingContext(ctx)
+	response.Ping.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		response.Ping.Error = err.Error()
+		return response, nil
+	}
+	response.Ping.OK = true
+
+	db.Model(&Monitor{}).Count(&response.Rows.Monitors)
+	db.Model(&CheckHistory{}).Count(&response.Rows.CheckHistories)
+	response.SizeBytes = databaseSize()
+	return response, nil
+}
+
+// apiAdminDB handles GET requests for database connectivity, pool saturation and row counts
+func apiAdminDB(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !requireAPIKey(w, r) {
+		return
+	}
+
+	status, err := getDBStatus()
+	if err != nil {
+		log.Error().Err(err).Msg("[API] ERROR GET /api/admin/db: Failed to get database handle")
+		http.Error(w, "Failed to get database handle", http.StatusInternalServerError)
+		return
+	}
+	if !status.Ping.OK {
+		log.Warn().Str("error", status.Ping.Error).Msg("[API] GET /api/admin/db: Database ping failed")
+	}
+
+	log.Info().Bool("ping_ok", status.Ping.OK).Float64("ping_ms", status.Ping.LatencyMs).Int("in_use", status.Pool.InUse).
+		Int64("wait_count", status.Pool.WaitCount).Msg("[API] GET /api/admin/db")
+	if err := encodeJSONWithCompression(w, r, status); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding database status")
+	}
+}
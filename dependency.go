@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// StatusSkipped marks a monitor whose check was skipped because the monitor it depends on is down
+const StatusSkipped = "skipped"
+
+// validateDependsOn checks a monitor's dependsOn reference (another monitor's name)
+// The referenced monitor may not exist yet, e.g. when it comes later in the same config file
+func validateDependsOn(name, dependsOn string) error {
+	if dependsOn != "" && strings.EqualFold(strings.TrimSpace(dependsOn), strings.TrimSpace(name)) {
+		return fmt.Errorf("dependsOn must reference another monitor")
+	}
+	return nil
+}
+
+// findDependency loads the monitor a dependsOn name refers to (case-insensitive, lowest ID first)
+func findDependency(name string) (Monitor, bool) {
+	var dependency Monitor
+	if err := db.Select("id", "name", "status", "paused", "depends_on").
+		Where("LOWER(name) = ?", strings.ToLower(strings.TrimSpace(name))).
+		Order("id ASC").Limit(1).Find(&dependency).Error; err != nil || dependency.ID == 0 {
+		return Monitor{}, false
+	}
+	return dependency, true
+}
+
+// blockingDependency returns the monitor that keeps this one from being checked, if any
+// A check is skipped while the monitor it depends on is down. When that monitor was skipped itself,
+// its own dependency decides, so a whole chain goes quiet during an outage at its root. Missing or
+// paused dependencies and cycles never block, so a bad reference can't silence a monitor for good
+func blockingDependency(monitor *Monitor) (Monitor, bool) {
+	visited := map[uint]bool{monitor.ID: true}
+	name := monitor.DependsOn
+	for name != "" {
+		dependency, found := findDependency(name)
+		if !found {
+			log.Debug().Uint("monitor_id", monitor.ID).Str("depends_on", name).Msg("[Check] Dependency not found, checking anyway")
+			return Monitor{}, false
+		}
+		if visited[dependency.ID] || dependency.Paused {
+			return Monitor{}, false
+		}
+		visited[dependency.ID] = true
+
+		switch dependency.Status {
+		case "down":
+			return dependency, true
+		case StatusSkipped:
+			name = dependency.DependsOn
+		default:
+			return Monitor{}, false
+		}
+	}
+	return Monitor{}, false
+}
+
+// skipCheck records that a monitor's check was skipped because of its dependency
+// Nothing is stored in check history and no alert is sent, so the skip counts neither for nor against uptime
+// A monitor that was down when its checks started being skipped has its incident closed and its alert
+// state dropped: transitions compare against "skipped" from then on, so a later recovery wouldn't
+func skipCheck(monitor *Monitor, dependency *Monitor) {
+	log.Debug().Uint("monitor_id", monitor.ID).Uint("dependency_id", dependency.ID).Str("dependency", dependency.Name).
+		Msg("[Check] Dependency is down, skipping check")
+
+	now := time.Now()
+	if monitor.Status == "down" {
+		closeIncident(monitor.ID, now)
+		alertManager.forget(monitor.ID)
+	}
+	updates := map[string]interface{}{
+		"status":     StatusSkipped,
+		"last_check": "just now",
+		"updated_at": now,
+	}
+	if monitor.Status != StatusSkipped {
+		updates["status_since"] = now
+		log.Info().Uint("monitor_id", monitor.ID).Str("name", monitor.Name).Str("dependency", dependency.Name).
+			Msg("[Check] Skipping checks while dependency is down")
+	}
+	db.Model(monitor).Updates(updates)
+
+	if err := db.First(monitor, monitor.ID).Error; err != nil {
+		log.Error().Err(err).Uint("monitor_id", monitor.ID).Msg("Failed to reload monitor after skipping check")
+		return
+	}
+	broadcastUpdate("monitor_update", *monitor)
+	broadcastStatsIfChanged()
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSkippedDownMonitorAlertsAgain(t *testing.T) {
+	db := newTestDB(t)
+	defer func(previous *AlertManager) { alertManager = previous }(alertManager)
+	alertManager = &AlertManager{
+		pending:   make(map[uint]*time.Timer),
+		alerted:   make(map[uint]bool),
+		downSince: make(map[uint]time.Time),
+	}
+
+	dependency := Monitor{Name: "router", Status: "down"}
+	monitor := Monitor{Name: "app", Status: "down", DependsOn: "router", EscalateAfter: 3600}
+	if err := db.Create(&[]*Monitor{&dependency, &monitor}).Error; err != nil {
+		t.Fatal(err)
+	}
+	defer alertManager.forget(monitor.ID)
+
+	// Down: incident opened and an escalation pending
+	openIncident(monitor.ID, time.Now())
+	alertManager.monitorDown(&monitor)
+
+	// Skipped while the dependency is down
+	skipCheck(&monitor, &dependency)
+	var open int64
+	db.Model(&Incident{}).Where("monitor_id = ? AND ended_at IS NULL", monitor.ID).Count(&open)
+	if open != 0 {
+		t.Errorf("%d incidents still open after the monitor was skipped", open)
+	}
+	if _, pending := alertManager.pending[monitor.ID]; pending {
+		t.Error("escalation still pending after the monitor was skipped")
+	}
+
+	// Up, then down again: the transition from skipped runs nothing, and the next outage must alert
+	db.Model(&monitor).UpdateColumn("status", "up")
+	alertManager.monitorDown(&monitor)
+	if _, pending := alertManager.pending[monitor.ID]; !pending {
+		t.Error("second outage didn't alert")
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// validateExpectedIP checks a dns:// monitor's expected address
+func validateExpectedIP(expectedIP string) error {
+	if expectedIP != "" && net.ParseIP(expectedIP) == nil {
+		return fmt.Errorf("expectedIp must be an IP address")
+	}
+	return nil
+}
+
+// checkDNS resolves the hostname of a dns://hostname URL (A and AAAA records) and is up when it resolves
+// within the timeout, with the resolution time as the response time. With ExpectedIP one of the
+// addresses has to match it. Uses the monitor's DNSServer if set, otherwise the system resolver
+func checkDNS(monitor *Monitor, rawURL string) endpointCheck {
+	result := endpointCheck{EndpointResult: EndpointResult{URL: rawURL, Status: "down"}}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Hostname() == "" {
+		log.Debug().Uint("monitor_id", monitor.ID).Str("url", rawURL).Msg("[Check] DNS URL must be dns://hostname")
+		result.ErrorCategory = ErrorCategoryConfig
+		result.Error = ErrorCategoryConfig + ": invalid DNS URL"
+		return result
+	}
+	host := parsedURL.Hostname()
+
+	resolver := resolverForMonitor(monitor)
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), monitor.checkTimeout())
+	defer cancel()
+	start := time.Now()
+	addrs, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		// NXDOMAIN and resolver timeouts both arrive as *net.DNSError, e.g. "dns: no such host"
+		log.Debug().Err(err).Uint("monitor_id", monitor.ID).Str("host", host).Msg("[Check] DNS resolution failed")
+		result.setRequestError(err)
+		return result
+	}
+	result.ResponseTime = int(time.Since(start).Milliseconds())
+	result.TTFB, result.TotalTime = result.ResponseTime, result.ResponseTime
+
+	if monitor.ExpectedIP != "" {
+		expected := net.ParseIP(monitor.ExpectedIP)
+		matched := false
+		for _, addr := range addrs {
+			if ip := net.ParseIP(addr); ip != nil && ip.Equal(expected) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			log.Debug().Uint("monitor_id", monitor.ID).Str("host", host).Strs("addresses", addrs).
+				Str("expected", monitor.ExpectedIP).Msg("[Check] DNS resolved to unexpected addresses")
+			result.ErrorCategory = ErrorCategoryDNS
+			result.Error = fmt.Sprintf("%s: %s resolved to %s, expected %s", ErrorCategoryDNS, host, strings.Join(addrs, ", "), monitor.ExpectedIP)
+			return result
+		}
+	}
+
+	result.Status = "up"
+	return result
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// dnsServerDialTimeout bounds connecting to a monitor's DNS server; the check's own deadline still applies
+const dnsServerDialTimeout = 5 * time.Second
+
+// dnsServerAddress returns a DNS server setting as host:port, defaulting to port 53
+func dnsServerAddress(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(server, "53")
+}
+
+// validateDNSServer checks a monitor's DNS server: an IP address, optionally with a port
+// A hostname isn't accepted, since resolving the resolver would depend on the system resolver
+func validateDNSServer(server string) error {
+	if server == "" {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(dnsServerAddress(server))
+	if err != nil || net.ParseIP(host) == nil {
+		return fmt.Errorf("dnsServer must be an IP address, optionally with a port (e.g. 10.0.0.53 or 10.0.0.53:5353)")
+	}
+	if _, err := net.LookupPort("udp", port); err != nil {
+		return fmt.Errorf("dnsServer has an invalid port %q", port)
+	}
+	return nil
+}
+
+// dnsResolvers caches a resolver per DNS server address
+var dnsResolvers sync.Map
+
+// resolverForMonitor returns a resolver that sends every query to the monitor's DNS server,
+// or nil (the system resolver) when it has none
+func resolverForMonitor(monitor *Monitor) *net.Resolver {
+	if monitor.DNSServer == "" {
+		return nil
+	}
+	address := dnsServerAddress(monitor.DNSServer)
+	if cached, ok := dnsResolvers.Load(address); ok {
+		return cached.(*net.Resolver)
+	}
+	resolver := &net.Resolver{
+		PreferGo: true, // The cgo resolver ignores Dial
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := net.Dialer{Timeout: dnsServerDialTimeout}
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+	actual, _ := dnsResolvers.LoadOrStore(address, resolver)
+	return actual.(*net.Resolver)
+}
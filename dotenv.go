@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// loadDotEnv loads KEY=VALUE pairs from a .env file into the environment
+// The file path defaults to ./.env and can be overridden with ENV_FILE
+// Variables already set in the environment take precedence over the file
+// Returns the path of the loaded file, or "" if no file was loaded
+func loadDotEnv() (string, error) {
+	path := os.Getenv("ENV_FILE")
+	if path == "" {
+		path = ".env"
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		value = parseDotEnvValue(strings.TrimSpace(value))
+
+		// Real environment variables win over the file
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		os.Setenv(key, value)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// parseDotEnvValue strips surrounding quotes and trailing comments from a .env value
+func parseDotEnvValue(value string) string {
+	if len(value) >= 2 {
+		quote := value[0]
+		if (quote == '"' || quote == '\'') && value[len(value)-1] == quote {
+			value = value[1 : len(value)-1]
+			if quote == '"' {
+				value = strings.ReplaceAll(value, `\n`, "\n")
+			}
+			return value
+		}
+	}
+
+	// Unquoted values may carry an inline comment
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = strings.TrimSpace(value[:idx])
+	}
+	return value
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultDowntimeDays = 30
+	MaxDowntimeDays     = 365
+)
+
+// parseDayRange parses a range such as "30d" into a number of days
+func parseDayRange(timeRange string) (int, error) {
+	if timeRange == "" {
+		return DefaultDowntimeDays, nil
+	}
+	days, err := strconv.Atoi(strings.TrimSuffix(timeRange, "d"))
+	if err != nil || days <= 0 {
+		return 0, fmt.Errorf("invalid range %q, expected a number of days such as 30d", timeRange)
+	}
+	if days > MaxDowntimeDays {
+		return 0, fmt.Errorf("range must not exceed %dd", MaxDowntimeDays)
+	}
+	return days, nil
+}
+
+// getDowntimeData calculates per-day downtime minutes (UTC days) for a monitor
+// Raw checks are used where available: each down check counts as down until the next check,
+// capped at two check intervals so gaps while NanoStatus itself was offline aren't billed as downtime.
+// Days older than the raw history retention fall back to an estimate from the hourly buckets.
+func getDowntimeData(monitor *Monitor, days int) DowntimeResponse {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	windowStart := today.AddDate(0, 0, -(days - 1))
+
+	minutes := make([]float64, days)
+	estimated := make([]bool, days)
+
+	// addDowntime spreads a down segment across the UTC days it covers
+	addDowntime := func(start, end time.Time) {
+		if start.Before(windowStart) {
+			start = windowStart
+		}
+		if end.After(now) {
+			end = now
+		}
+		for start.Before(end) {
+			dayIndex := int(start.Sub(windowStart) / (24 * time.Hour))
+			if dayIndex >= days {
+				return
+			}
+			dayEnd := windowStart.AddDate(0, 0, dayIndex+1)
+			segmentEnd := end
+			if dayEnd.Before(segmentEnd) {
+				segmentEnd = dayEnd
+			}
+			minutes[dayIndex] += segmentEnd.Sub(start).Minutes()
+			start = segmentEnd
+		}
+	}
+
+	interval := monitor.CheckInterval
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+	maxSegment := 2 * time.Duration(interval) * time.Second
+
+	// Include the last check before the window so a down state carried into the first day is counted
+	var checks []CheckHistory
+	var previous CheckHistory
+	if err := db.Where("monitor_id = ? AND created_at < ?", monitor.ID, windowStart).
+		Order("created_at DESC").Limit(1).Find(&previous).Error; err == nil && previous.ID != 0 {
+		checks = append(checks, previous)
+	}
+	var inWindow []CheckHistory
+	db.Select("id", "monitor_id", "status", "created_at").
+		Where("monitor_id = ? AND created_at >= ?", monitor.ID, windowStart).
+		Order("created_at ASC").Find(&inWindow)
+	checks = append(checks, inWindow...)
+
+	ongoing := false
+	for i, check := range checks {
+		if check.Status != "down" {
+			continue
+		}
+		end := now
+		if i+1 < len(checks) {
+			end = checks[i+1].CreatedAt
+		} else {
+			ongoing = true
+		}
+		if end.Sub(check.CreatedAt) > maxSegment {
+			end = check.CreatedAt.Add(maxSegment)
+		}
+		addDowntime(check.CreatedAt.UTC(), end.UTC())
+	}
+
+	// Hours before the oldest raw check in the window only survive as hourly buckets
+	rawStart := now
+	if len(inWindow) > 0 {
+		rawStart = inWindow[0].CreatedAt.UTC()
+	}
+	if rawStart.After(windowStart) {
+		var buckets []CheckHistoryBucket
+		db.Where("monitor_id = ? AND bucket_hour >= ? AND bucket_hour < ?",
+			monitor.ID, windowStart.Unix(), rawStart.Truncate(time.Hour).Unix()).
+			Order("bucket_hour ASC").Find(&buckets)
+		for _, bucket := range buckets {
+			if bucket.TotalChecks == 0 {
+				continue
+			}
+			downFraction := float64(bucket.TotalChecks-bucket.UpChecks) / float64(bucket.TotalChecks)
+			hourStart := time.Unix(bucket.BucketHour, 0).UTC()
+			dayIndex := int(hourStart.Sub(windowStart) / (24 * time.Hour))
+			if dayIndex < 0 || dayIndex >= days {
+				continue
+			}
+			minutes[dayIndex] += downFraction * 60
+			estimated[dayIndex] = true
+		}
+	}
+
+	response := DowntimeResponse{
+		MonitorID: monitor.ID,
+		Range:     fmt.Sprintf("%dd", days),
+		Ongoing:   ongoing,
+		Days:      make([]DowntimeDay, days),
+	}
+	for i := 0; i < days; i++ {
+		dayMinutes := float64(int(minutes[i]*100+0.5)) / 100 // Round to 2 decimal places
+		response.Days[i] = DowntimeDay{
+			Date:            windowStart.AddDate(0, 0, i).Format("2006-01-02"),
+			DowntimeMinutes: dayMinutes,
+			Partial:         i == days-1,
+			Estimated:       estimated[i],
+		}
+		response.TotalMinutes += dayMinutes
+	}
+	response.TotalMinutes = float64(int(response.TotalMinutes*100+0.5)) / 100
+
+	return response
+}
+
+// Outage is a period during which a monitor's checks were down
+type Outage struct {
+	MonitorID uint
+	Start     time.Time
+	End       time.Time // Time of the first passing check, or now while ongoing
+	Ongoing   bool
+}
+
+// findOutages groups consecutive down checks from the raw history into outages
+// The raw history is only kept for RAW_RETENTION_DAYS (default 7), so older outages are not available
+func findOutages(monitorID string, since time.Time) []Outage {
+	query := db.Model(&CheckHistory{}).Select("monitor_id", "status", "created_at").
+		Where("created_at >= ?", since)
+	if monitorID != "" {
+		query = query.Where("monitor_id = ?", monitorID)
+	}
+
+	var checks []CheckHistory
+	query.Order("monitor_id ASC, created_at ASC").Find(&checks)
+
+	var outages []Outage
+	var current *Outage
+	for _, check := range checks {
+		if current != nil && current.MonitorID != check.MonitorID {
+			// History for the previous monitor ended while it was still down
+			current.End = time.Now()
+			current.Ongoing = true
+			outages = append(outages, *current)
+			current = nil
+		}
+		if check.Status == "down" {
+			if current == nil {
+				current = &Outage{MonitorID: check.MonitorID, Start: check.CreatedAt}
+			}
+			continue
+		}
+		if current != nil {
+			current.End = check.CreatedAt
+			outages = append(outages, *current)
+			current = nil
+		}
+	}
+	if current != nil {
+		current.End = time.Now()
+		current.Ongoing = true
+		outages = append(outages, *current)
+	}
+	return outages
+}
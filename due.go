@@ -0,0 +1,127 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DueMonitor is a scheduled monitor and when its next check runs
+type DueMonitor struct {
+	MonitorID     uint    `json:"monitorId"`
+	Name          string  `json:"name"`
+	URL           string  `json:"url"`
+	CheckInterval int     `json:"checkInterval"`     // Configured interval in seconds
+	Interval      int     `json:"scheduledInterval"` // Interval the job runs at, after adaptive intervals and the rate budget
+	NextRun       *string `json:"nextRun,omitempty"` // ISO 8601, unset if the scheduler doesn't know yet
+	DueInSeconds  float64 `json:"dueInSeconds"`      // Negative when the run is overdue (e.g. waiting for a check slot)
+	LastRun       *string `json:"lastRun,omitempty"` // ISO 8601
+}
+
+// DueResponse lists scheduled monitors ordered by their next check
+type DueResponse struct {
+	Now      string       `json:"now"`
+	Total    int          `json:"total"` // Scheduled jobs, before the limit
+	Monitors []DueMonitor `json:"monitors"`
+}
+
+// dueMonitors reads the next and last run of every scheduled job, soonest first
+// Jobs whose next run is unknown are listed last
+func (ms *MonitorScheduler) dueMonitors(now time.Time) []DueMonitor {
+	ms.mu.RLock()
+	due := make([]DueMonitor, 0, len(ms.jobs))
+	nextRuns := make(map[uint]time.Time, len(ms.jobs))
+	for monitorID, job := range ms.jobs {
+		entry := DueMonitor{MonitorID: monitorID, Interval: ms.intervals[monitorID]}
+		if next, err := job.NextRun(); err == nil && !next.IsZero() {
+			formatted := next.UTC().Format(time.RFC3339)
+			entry.NextRun = &formatted
+			entry.DueInSeconds = next.Sub(now).Seconds()
+			nextRuns[monitorID] = next
+		}
+		if last, err := job.LastRun(); err == nil && !last.IsZero() {
+			formatted := last.UTC().Format(time.RFC3339)
+			entry.LastRun = &formatted
+		}
+		due = append(due, entry)
+	}
+	ms.mu.RUnlock()
+
+	sort.Slice(due, func(i, j int) bool {
+		nextI, knownI := nextRuns[due[i].MonitorID]
+		nextJ, knownJ := nextRuns[due[j].MonitorID]
+		if knownI != knownJ {
+			return knownI
+		}
+		if !nextI.Equal(nextJ) {
+			return nextI.Before(nextJ)
+		}
+		return due[i].MonitorID < due[j].MonitorID
+	})
+	return due
+}
+
+// apiAdminDue handles GET requests listing scheduled monitors by their next check time, with ?limit=N
+func apiAdminDue(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !requireAPIKey(w, r) {
+		return
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			log.Warn().Str("limit", value).Msg("[API] ERROR GET /api/admin/due: Invalid limit")
+			http.Error(w, "limit must be a non-negative number", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	now := time.Now()
+	due := monitorScheduler.dueMonitors(now)
+	response := DueResponse{Now: now.UTC().Format(time.RFC3339), Total: len(due)}
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	ids := make([]uint, 0, len(due))
+	for _, entry := range due {
+		ids = append(ids, entry.MonitorID)
+	}
+	var monitors []Monitor
+	if len(ids) > 0 {
+		if err := db.Select("id", "name", "url", "check_interval").Where("id IN ?", ids).Find(&monitors).Error; err != nil {
+			log.Error().Err(err).Msg("[API] ERROR GET /api/admin/due: Failed to load monitors")
+			http.Error(w, "Failed to load monitors", http.StatusInternalServerError)
+			return
+		}
+	}
+	byID := make(map[uint]Monitor, len(monitors))
+	for _, monitor := range monitors {
+		byID[monitor.ID] = monitor
+	}
+	for i := range due {
+		monitor := byID[due[i].MonitorID]
+		due[i].Name, due[i].URL, due[i].CheckInterval = monitor.Name, monitor.URL, monitor.CheckInterval
+	}
+	response.Monitors = due
+
+	log.Info().Int("scheduled", response.Total).Int("returned", len(due)).Msg("[API] GET /api/admin/due")
+	if err := encodeJSONWithCompression(w, r, response); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding due monitors")
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// QueryPlanStep is one row of SQLite's EXPLAIN QUERY PLAN output
+type QueryPlanStep struct {
+	ID     int    `json:"id"`
+	Parent int    `json:"parent"`
+	Detail string `json:"detail"` // e.g. "SEARCH check_histories USING INDEX idx_monitor_created (monitor_id=? AND created_at>?)"
+}
+
+// QueryPlan is the plan SQLite chose for one of the key queries
+type QueryPlan struct {
+	Name     string          `json:"name"`
+	SQL      string          `json:"sql"`
+	Plan     []QueryPlanStep `json:"plan,omitempty"`
+	FullScan bool            `json:"fullScan"` // Some table is scanned without an index
+	Error    string          `json:"error,omitempty"`
+}
+
+// ExplainResponse holds the query plans of the key queries
+type ExplainResponse struct {
+	Queries []QueryPlan `json:"queries"`
+}
+
+// keyQueries returns the performance-critical queries with representative arguments, in the same
+// shape the stats, history, uptime and bucketing code issues them
+func keyQueries() []struct {
+	name string
+	sql  string
+	args []interface{}
+} {
+	now := time.Now()
+	dayAgo := now.Add(-24 * time.Hour)
+
+	var monitorID uint = 1
+	var monitor Monitor
+	if err := db.Select("id").Order("id ASC").Limit(1).Find(&monitor).Error; err == nil && monitor.ID != 0 {
+		monitorID = monitor.ID
+	}
+
+	// Build the GORM queries as SQL (with their arguments inlined) without running them
+	statsSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var result []struct{ UnpausedCount int64 }
+		return tx.Model(&Monitor{}).
+			Select(`COUNT(*) as unpaused_count, SUM(CASE WHEN ` + uptimeStatusSQL("status") + ` THEN 1 ELSE 0 END) as up_count, SUM(uptime) as total_uptime`).
+			Where("paused = ?", false).Find(&result)
+	})
+	historySQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var checks []CheckHistory
+		return tx.Where("monitor_id = ? AND created_at > ?", monitorID, dayAgo).Order("created_at ASC").Limit(288).Find(&checks)
+	})
+	uptimeSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var result []struct{ TotalCount int64 }
+		return tx.Model(&CheckHistory{}).
+			Select("COUNT(*) as total_count, SUM(CASE WHEN "+uptimeStatusSQL("status")+" THEN 1 ELSE 0 END) as up_count").
+			Where("monitor_id = ? AND created_at > ? AND in_maintenance = ?", monitorID, dayAgo, false).Find(&result)
+	})
+
+	return []struct {
+		name string
+		sql  string
+		args []interface{}
+	}{
+		{"stats_aggregate", statsSQL, nil},
+		{"stats_avg_response_time", avgResponseTimeSQL, []interface{}{dayAgo, "up"}},
+		{"response_time_history", historySQL, nil},
+		{"uptime_view", "SELECT total_checks, up_checks, uptime_percent FROM monitor_stats_24h WHERE monitor_id = ?", []interface{}{monitorID}},
+		{"uptime_fallback", uptimeSQL, nil},
+		{"bucketing_aggregate", bucketAggregateSQL(), []interface{}{dayAgo}},
+	}
+}
+
+// explainQuery runs EXPLAIN QUERY PLAN for a query
+func explainQuery(name, query string, args []interface{}) QueryPlan {
+	plan := QueryPlan{Name: name, SQL: strings.Join(strings.Fields(query), " ")}
+
+	rows, err := db.Raw("EXPLAIN QUERY PLAN "+query, args...).Rows()
+	if err != nil {
+		plan.Error = err.Error()
+		return plan
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var step QueryPlanStep
+		var notUsed int
+		if err := rows.Scan(&step.ID, &step.Parent, &notUsed, &step.Detail); err != nil {
+			plan.Error = err.Error()
+			return plan
+		}
+		if strings.HasPrefix(step.Detail, "SCAN ") && !strings.Contains(step.Detail, "INDEX") {
+			plan.FullScan = true
+		}
+		plan.Plan = append(plan.Plan, step)
+	}
+	if err := rows.Err(); err != nil {
+		plan.Error = err.Error()
+	}
+	return plan
+}
+
+// apiAdminExplain handles GET requests for the query plans of the key queries
+func apiAdminExplain(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !requireAPIKey(w, r) {
+		return
+	}
+
+	queries := keyQueries()
+	response := ExplainResponse{Queries: make([]QueryPlan, 0, len(queries))}
+	fullScans := 0
+	for _, query := range queries {
+		plan := explainQuery(query.name, query.sql, query.args)
+		if plan.FullScan {
+			fullScans++
+		}
+		response.Queries = append(response.Queries, plan)
+	}
+
+	log.Info().Int("queries", len(response.Queries)).Int("full_scans", fullScans).Msg("[API] GET /api/admin/explain")
+	if err := encodeJSONWithCompression(w, r, response); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding query plans")
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import "fmt"
+
+// maxFailureThreshold caps how many consecutive failures a monitor may wait for before it is down
+const maxFailureThreshold = 100
+
+// validateFailureThreshold checks a monitor's consecutive failure threshold (0 and 1 mark it down on the first failure)
+func validateFailureThreshold(threshold int) error {
+	if threshold < 0 || threshold > maxFailureThreshold {
+		return fmt.Errorf("failureThreshold must be between 0 and %d", maxFailureThreshold)
+	}
+	return nil
+}
+
+// failureThreshold returns how many consecutive failed checks mark the monitor down (default 1)
+func (m *Monitor) failureThreshold() int {
+	if m.FailureThreshold < 1 {
+		return 1
+	}
+	return m.FailureThreshold
+}
+
+// consecutiveFailures counts the monitor's failed checks in a row including this one; any other result resets it
+func consecutiveFailures(monitor *Monitor, status string) int {
+	if status != "down" {
+		return 0
+	}
+	return monitor.ConsecutiveFailures + 1
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConsecutiveFailures(t *testing.T) {
+	monitor := &Monitor{ConsecutiveFailures: 2}
+	if got := consecutiveFailures(monitor, "down"); got != 3 {
+		t.Errorf("failure after 2 failures counts %d, want 3", got)
+	}
+	for _, status := range []string{"up", StatusDegraded} {
+		if got := consecutiveFailures(monitor, status); got != 0 {
+			t.Errorf("%s result leaves the count at %d, want 0", status, got)
+		}
+	}
+}
+
+func TestFailuresBelowThreshold(t *testing.T) {
+	db := newTestDB(t)
+	// A push monitor whose heartbeat is overdue fails every check without any network requests
+	monitor := Monitor{Name: "cron", Type: MonitorTypePush, CheckInterval: 60, FailureThreshold: 3,
+		Status: "unknown", CreatedAt: time.Now().Add(-2 * time.Hour)}
+	if err := db.Create(&monitor).Error; err != nil {
+		t.Fatal(err)
+	}
+	overdueCheck := func() Monitor {
+		t.Helper()
+		db.Model(&monitor).UpdateColumn("last_heartbeat_at", time.Now().Add(-time.Hour))
+		checkService(monitor.ID)
+		var stored Monitor
+		db.First(&stored, monitor.ID)
+		return stored
+	}
+
+	checkService(pushHeartbeat{monitorID: monitor.ID})
+	overdueCheck()
+	stored := overdueCheck()
+	if stored.Status != "pending" || stored.ConsecutiveFailures != 2 {
+		t.Fatalf("after 2 failures: status %q with %d failures, want pending with 2", stored.Status, stored.ConsecutiveFailures)
+	}
+
+	// Pending is only what the dashboard shows: history records the failures, so uptime drops
+	var down int64
+	db.Model(&CheckHistory{}).Where("monitor_id = ? AND status = ?", monitor.ID, "down").Count(&down)
+	if down != 2 {
+		t.Errorf("%d down checks in history, want 2", down)
+	}
+	if stored.Uptime >= 100 {
+		t.Errorf("uptime = %.1f%% with failures below the threshold, want it to drop", stored.Uptime)
+	}
+
+	// A success resets the count, so the next failure starts over
+	checkService(pushHeartbeat{monitorID: monitor.ID})
+	db.First(&stored, monitor.ID)
+	if stored.ConsecutiveFailures != 0 {
+		t.Errorf("failures after a success = %d, want 0", stored.ConsecutiveFailures)
+	}
+	if stored = overdueCheck(); stored.Status != "pending" || stored.ConsecutiveFailures != 1 {
+		t.Errorf("failure after a success: status %q with %d failures, want pending with 1", stored.Status, stored.ConsecutiveFailures)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxGroupLength caps the length of a monitor's group name
+const maxGroupLength = 64
+
+// validateGroup checks a monitor's group name (empty for no group)
+func validateGroup(group string) error {
+	if len(strings.TrimSpace(group)) > maxGroupLength {
+		return fmt.Errorf("group must be at most %d characters", maxGroupLength)
+	}
+	return nil
+}
+
+// filterMonitors narrows a monitor query to a group and to monitors carrying every one of the tags
+// Without a group or tags the query is unchanged, so untagged and ungrouped monitors are included
+func filterMonitors(query *gorm.DB, tags []string, group string) *gorm.DB {
+	if group = strings.TrimSpace(group); group != "" {
+		query = query.Where("monitor_group = ?", group)
+	}
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			query = query.Where("EXISTS (SELECT 1 FROM json_each(monitors.tags) WHERE json_each.value = ?)", tag)
+		}
+	}
+	return query
+}
+
+// getGroupStats calculates the overall statistics of the unpaused monitors in a group, like getStats
+func getGroupStats(group string) StatsResponse {
+	var stats struct {
+		UnpausedCount int64
+		UpCount       int64
+		DownCount     int64
+		DegradedCount int64
+		TotalUptime   float64
+	}
+	db.Model(&Monitor{}).
+		Select(`
+			COUNT(*) as unpaused_count,
+			SUM(CASE WHEN ` + uptimeStatusSQL("status") + ` THEN 1 ELSE 0 END) as up_count,
+			SUM(CASE WHEN status = 'down' THEN 1 ELSE 0 END) as down_count,
+			SUM(CASE WHEN status = '` + StatusDegraded + `' THEN 1 ELSE 0 END) as degraded_count,
+			SUM(uptime) as total_uptime
+		`).
+		Where("paused = ? AND monitor_group = ?", false, group).
+		Scan(&stats)
+
+	response := StatsResponse{ServicesUp: int(stats.UpCount), ServicesDown: int(stats.DownCount), ServicesDegraded: int(stats.DegradedCount)}
+	if stats.UnpausedCount > 0 {
+		response.OverallUptime = stats.TotalUptime / float64(stats.UnpausedCount)
+	}
+
+	var avgResult sql.NullFloat64
+	err := db.Raw(`
+		SELECT AVG(response_time) FROM check_histories
+		WHERE created_at > ? AND response_time > 0 AND status = ?
+		AND monitor_id IN (SELECT id FROM monitors WHERE monitor_group = ? AND paused = 0)
+	`, time.Now().Add(-24*time.Hour), "up", group).Row().Scan(&avgResult)
+	if err == nil && avgResult.Valid {
+		response.AvgResponseTime = int(avgResult.Float64)
+	}
+	response.P50ResponseTime, response.P90ResponseTime, response.P99ResponseTime = upCheckPercentiles(
+		time.Now().Add(-24*time.Hour), "monitor_id IN (SELECT id FROM monitors WHERE monitor_group = ? AND paused = 0)", group)
+	return response
+}
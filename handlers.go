@@ -4,18 +4,21 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 
 	"github.com/mailru/easyjson"
 	"github.com/rs/zerolog/log"
 	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
 )
 
 // Compile regex once at package level for better performance
@@ -35,6 +38,12 @@ func setJSONHeaders(w http.ResponseWriter) {
 // encodeJSONWithCompression encodes data as JSON with gzip compression if supported
 // Uses easyjson when possible for maximum performance
 func encodeJSONWithCompression(w http.ResponseWriter, r *http.Request, data interface{}) error {
+	return encodeJSONWithStatus(w, r, http.StatusOK, data)
+}
+
+// encodeJSONWithStatus is encodeJSONWithCompression with a status code other than 200; the status is only
+// written once the compression headers are set, which calling WriteHeader first would drop
+func encodeJSONWithStatus(w http.ResponseWriter, r *http.Request, status int, data interface{}) error {
 	// Check if client accepts gzip
 	acceptsGzip := strings.Contains(r.Header.Get("Accept-Encoding"), "gzip")
 
@@ -82,33 +91,42 @@ func encodeJSONWithCompression(w http.ResponseWriter, r *http.Request, data inte
 	}
 
 	w.Header().Set("Content-Length", fmt.Sprintf("%d", buf.Len()))
+	w.WriteHeader(status)
 	_, err := w.Write(buf.Bytes())
 	return err
 }
 
-// getResponseTimeData retrieves response time history for a monitor within a time range
-func getResponseTimeData(monitorID string, timeRange string) []ResponseTimeData {
-	id, err := strconv.ParseUint(monitorID, 10, 32)
-	if err != nil {
-		return []ResponseTimeData{}
-	}
-
-	// Calculate time cutoff based on range
-	var cutoffTime time.Time
-	now := time.Now()
-	
+// responseTimeCutoff returns the start of a response time range: 1h, 12h, 24h (default), 1w, 30d or 1y
+func responseTimeCutoff(timeRange string, now time.Time) time.Time {
 	switch timeRange {
 	case "1h":
-		cutoffTime = now.Add(-1 * time.Hour)
+		return now.Add(-1 * time.Hour)
 	case "12h":
-		cutoffTime = now.Add(-12 * time.Hour)
+		return now.Add(-12 * time.Hour)
 	case "1w":
-		cutoffTime = now.Add(-7 * 24 * time.Hour)
+		return now.Add(-7 * 24 * time.Hour)
+	case "30d":
+		return now.AddDate(0, 0, -30)
 	case "1y":
-		cutoffTime = now.Add(-365 * 24 * time.Hour)
+		return now.Add(-365 * 24 * time.Hour)
 	default:
 		// Default to 24 hours
-		cutoffTime = now.Add(-24 * time.Hour)
+		return now.Add(-24 * time.Hour)
+	}
+}
+
+// getResponseTimeData retrieves response time history for a monitor within a time range
+func getResponseTimeData(monitorID string, timeRange string) []ResponseTimeData {
+	id, err := strconv.ParseUint(monitorID, 10, 32)
+	if err != nil {
+		return []ResponseTimeData{}
+	}
+
+	cutoffTime := responseTimeCutoff(timeRange, time.Now())
+
+	// Long ranges reach past the raw retention, so they are served from hourly aggregates
+	if slot, ok := responseTimeSlots[timeRange]; ok {
+		return aggregatedResponseTimeData(uint(id), cutoffTime, slot, timeRange)
 	}
 
 	// Get checks within time range, ordered by creation time
@@ -124,10 +142,6 @@ func getResponseTimeData(monitorID string, timeRange string) []ResponseTimeData
 		query = query.Limit(144) // Max 144 points for 12 hours
 	case "24h":
 		query = query.Limit(288) // Max 288 points for 24 hours
-	case "1w":
-		query = query.Limit(168) // Max 168 points for 1 week
-	case "1y":
-		query = query.Limit(365) // Max 365 points for 1 year
 	default:
 		query = query.Limit(50)
 	}
@@ -143,25 +157,9 @@ func getResponseTimeData(monitorID string, timeRange string) []ResponseTimeData
 	// Send ISO 8601 timestamps and let the frontend format them in the user's timezone
 	data := make([]ResponseTimeData, len(checks))
 	for i, check := range checks {
-		// Send ISO 8601 timestamp (UTC) - frontend will format in user's timezone
-		isoTimestamp := check.CreatedAt.Format(time.RFC3339)
-		
-		// Also provide a fallback formatted string (UTC) for backwards compatibility
-		var timeStr string
-		switch timeRange {
-		case "1h", "12h", "24h":
-			timeStr = check.CreatedAt.Format("03:04 PM")
-		case "1w":
-			timeStr = check.CreatedAt.Format("Mon 03:04 PM")
-		case "1y":
-			timeStr = check.CreatedAt.Format("Jan 2")
-		default:
-			timeStr = check.CreatedAt.Format("03:04 PM")
-		}
-		
 		data[i] = ResponseTimeData{
-			Time:         timeStr,      // Fallback (will be overridden by frontend)
-			Timestamp:    isoTimestamp, // ISO 8601 timestamp for client-side formatting
+			Time:         chartTimeLabel(check.CreatedAt, timeRange), // Fallback (will be overridden by frontend)
+			Timestamp:    check.CreatedAt.Format(time.RFC3339),       // ISO 8601 timestamp for client-side formatting
 			ResponseTime: float64(check.ResponseTime),
 		}
 	}
@@ -169,6 +167,22 @@ func getResponseTimeData(monitorID string, timeRange string) []ResponseTimeData
 	return data
 }
 
+// chartTimeLabel formats a chart point's time in DISPLAY_TIMEZONE, kept for backwards compatibility;
+// the frontend formats the ISO timestamp in the user's timezone instead
+func chartTimeLabel(t time.Time, timeRange string) string {
+	local := t.In(displayLocation)
+	switch timeRange {
+	case "1w":
+		return local.Format("Mon 03:04 PM")
+	case "30d":
+		return local.Format("Jan 2 03:04 PM")
+	case "1y":
+		return local.Format("Jan 2")
+	default:
+		return local.Format("03:04 PM")
+	}
+}
+
 // apiMonitors handles GET requests to list all monitors
 func apiMonitors(w http.ResponseWriter, r *http.Request) {
 	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
@@ -176,13 +190,33 @@ func apiMonitors(w http.ResponseWriter, r *http.Request) {
 	setJSONHeaders(w)
 
 	if r.Method == http.MethodGet {
+		// Optionally filter by ?group= and one or more ?tag= (a monitor must carry all of them)
+		tags, group := r.URL.Query()["tag"], r.URL.Query().Get("group")
+
+		// Without ?limit= every matching monitor is returned, as before pagination existed
+		page, err := parseMonitorPage(r.URL.Query().Get("limit"), r.URL.Query().Get("offset"), r.URL.Query().Get("sort"))
+		if err != nil {
+			log.Warn().Err(err).Msg("[API] ERROR GET /api/monitors: Invalid pagination")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var total int64
+		if err := filterMonitors(db.Model(&Monitor{}), tags, group).Count(&total).Error; err != nil {
+			log.Error().Err(err).Msg("[API] ERROR GET /api/monitors: Failed to count monitors")
+			http.Error(w, "Failed to fetch monitors", http.StatusInternalServerError)
+			return
+		}
+
 		var monitors []Monitor
-		if err := db.Find(&monitors).Error; err != nil {
+		if err := page.apply(filterMonitors(db.Model(&Monitor{}), tags, group)).Find(&monitors).Error; err != nil {
 			log.Error().Err(err).Msg("[API] ERROR GET /api/monitors")
 			http.Error(w, "Failed to fetch monitors", http.StatusInternalServerError)
 			return
 		}
-		log.Info().Int("count", len(monitors)).Msg("[API] GET /api/monitors")
+		w.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+		w.Header().Set("Access-Control-Expose-Headers", "X-Total-Count")
+		log.Info().Int("count", len(monitors)).Int64("total", total).Strs("tags", tags).Str("group", group).Msg("[API] GET /api/monitors")
 		if err := encodeJSONWithCompression(w, r, monitors); err != nil {
 			log.Error().Err(err).Msg("[API] ERROR encoding monitors")
 		}
@@ -193,13 +227,352 @@ func apiMonitors(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 }
 
+// maxSearchResults caps how many monitors a search returns
+const maxSearchResults = 50
+
+// likeEscaper escapes LIKE wildcards so search terms match literally (used with ESCAPE '\')
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// apiSearchMonitors handles GET requests finding monitors whose name or URL contains ?q=, case-insensitively
+func apiSearchMonitors(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("q", q).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	monitors := []Monitor{}
+	if q != "" {
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(q)) + "%"
+		if err := db.Where(`LOWER(name) LIKE ? ESCAPE '\' OR LOWER(url) LIKE ? ESCAPE '\'`, pattern, pattern).
+			Order("name ASC").Limit(maxSearchResults).Find(&monitors).Error; err != nil {
+			log.Error().Err(err).Str("q", q).Msg("[API] ERROR GET /api/monitors/search")
+			http.Error(w, "Failed to search monitors", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	log.Info().Str("q", q).Int("count", len(monitors)).Msg("[API] GET /api/monitors/search")
+	if err := encodeJSONWithCompression(w, r, monitors); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding search results")
+	}
+}
+
+// validateMonitorRequest checks a create/update request, returning a client-facing error
+func validateMonitorRequest(req *CreateMonitorRequest) error {
+	// Push monitors aren't polled, so their URL is optional
+	if req.Name == "" || (req.URL == "" && req.Type != MonitorTypePush) {
+		return fmt.Errorf("Name and URL are required")
+	}
+	if err := validateMonitorType(req.Type); err != nil {
+		return err
+	}
+	if err := validateHeartbeatGrace(req.HeartbeatGrace); err != nil {
+		return err
+	}
+	if req.URL != "" {
+		if err := validateURLScheme(req.URL); err != nil {
+			return err
+		}
+	}
+	if err := validateCheckInterval(req.CheckInterval); err != nil {
+		return err
+	}
+	if err := validateTimeoutSeconds(req.TimeoutSeconds); err != nil {
+		return err
+	}
+	if !isValidRedirectPolicy(req.RedirectPolicy) {
+		return fmt.Errorf("redirectPolicy must be follow, redirect, or down")
+	}
+	if err := validateMaxRedirects(req.MaxRedirects); err != nil {
+		return err
+	}
+	if err := validateFollowRedirects(req.FollowRedirects, req.RedirectPolicy, req.MaxRedirects); err != nil {
+		return err
+	}
+	if err := validateCacheAgeRange(req.MinCacheAge, req.MaxCacheAge); err != nil {
+		return err
+	}
+	if err := validateLatencyWindow(req.LatencyWindow, req.LatencyThreshold); err != nil {
+		return err
+	}
+	if err := validateDegradedThreshold(req.DegradedThresholdMs); err != nil {
+		return err
+	}
+	if err := validateSampleRate(req.SampleRate); err != nil {
+		return err
+	}
+	if err := validateStatusOutcomes(req.StatusOutcomes); err != nil {
+		return err
+	}
+	if err := validateExpectedStatusCodes(req.ExpectedStatusCodes); err != nil {
+		return err
+	}
+	if err := validateExtractLabel(strings.TrimSpace(req.ExtractLabel)); err != nil {
+		return err
+	}
+	if err := validateTags(req.Tags); err != nil {
+		return err
+	}
+	if err := validateGroup(req.Group); err != nil {
+		return err
+	}
+	if err := validateEndpoints(req.Endpoints); err != nil {
+		return err
+	}
+	if err := validateUDPProbe(req.UDPProbe, req.UDPExpect); err != nil {
+		return err
+	}
+	if err := validateAdaptiveBounds(req.MinAdaptiveInterval, req.MaxAdaptiveInterval); err != nil {
+		return err
+	}
+	if err := validateRequestBody(req.RequestBody); err != nil {
+		return err
+	}
+	if err := validateDependsOn(req.Name, req.DependsOn); err != nil {
+		return err
+	}
+	if err := validateMethod(req.Method, req.RequestBody); err != nil {
+		return err
+	}
+	if err := validateContentType(req.ContentType); err != nil {
+		return err
+	}
+	if err := validateExpectKeyword(req.ExpectKeyword, req.ExpectKeywordAbsent, req.Method); err != nil {
+		return err
+	}
+	if err := validateLightCheck(req.LightCheck, req.Method, req.RequestBody, req.ExpectKeyword, req.JSONSchema, req.ExtractLabel); err != nil {
+		return err
+	}
+	if err := validateHTTP3(req.HTTP3, req.Type, req.URL, req.Endpoints, req.PreCheckURL); err != nil {
+		return err
+	}
+	if err := validateMaxBodyBytes(req.MaxBodyBytes); err != nil {
+		return err
+	}
+	if err := validateSLATarget(req.SLATarget); err != nil {
+		return err
+	}
+	if err := validateSourceIP(req.SourceIP); err != nil {
+		return err
+	}
+	if err := validateDNSServer(strings.TrimSpace(req.DNSServer)); err != nil {
+		return err
+	}
+	if err := validateExpectedIP(strings.TrimSpace(req.ExpectedIP)); err != nil {
+		return err
+	}
+	if err := validateAutoRetire(req.AutoRetireAfter, req.AutoRetireAction); err != nil {
+		return err
+	}
+	if !isValidResponseTimeMode(req.ResponseTimeMode) {
+		return fmt.Errorf("responseTimeMode must be ttfb or total")
+	}
+	if err := validateJSONSchema(req.JSONSchema); err != nil {
+		return err
+	}
+	if err := validatePreCheck(req.PreCheckURL, req.PreCheckMethod, req.PreCheckExtract, req.PreCheckInject, req.PreCheckTTL); err != nil {
+		return err
+	}
+	if req.GracePeriod < 0 {
+		return fmt.Errorf("gracePeriod must not be negative")
+	}
+	if err := validateFailureThreshold(req.FailureThreshold); err != nil {
+		return err
+	}
+	if err := validateRetries(req.RetryCount, req.RetryDelayMs); err != nil {
+		return err
+	}
+	if req.EscalateAfter < 0 {
+		return fmt.Errorf("escalateAfter must not be negative")
+	}
+	return nil
+}
+
+// applyMonitorRequest copies the settings from a create/update request onto a monitor
+func applyMonitorRequest(monitor *Monitor, req *CreateMonitorRequest) {
+	urlChanged := req.URL != monitor.URL
+	monitor.Name = req.Name
+	monitor.URL = req.URL
+	monitor.IsThirdParty = req.IsThirdParty
+	monitor.Icon = req.Icon
+	monitor.TimeoutSeconds = req.TimeoutSeconds
+	monitor.RedirectPolicy = req.RedirectPolicy
+	monitor.MaxRedirects = req.MaxRedirects
+	monitor.FollowRedirects = req.FollowRedirects
+	monitor.StatusOutcomes = req.StatusOutcomes
+	monitor.ExpectedStatusCodes = strings.TrimSpace(req.ExpectedStatusCodes)
+	monitor.MinCacheAge = req.MinCacheAge
+	monitor.MaxCacheAge = req.MaxCacheAge
+	monitor.Tags = uniqueTags(req.Tags)
+	monitor.Group = strings.TrimSpace(req.Group)
+	monitor.Endpoints = req.Endpoints
+	monitor.ParallelEndpoints = req.ParallelEndpoints
+	if len(monitor.Endpoints) == 0 {
+		monitor.EndpointResults = nil
+	}
+	monitor.GracePeriod = req.GracePeriod
+	monitor.EscalateAfter = req.EscalateAfter
+	monitor.FailureThreshold = req.FailureThreshold
+	monitor.RetryCount = req.RetryCount
+	monitor.RetryDelayMs = req.RetryDelayMs
+	monitor.Type = req.Type
+	monitor.HeartbeatGrace = req.HeartbeatGrace
+	monitor.StoreHeaders = req.StoreHeaders
+	monitor.LightCheck = req.LightCheck
+	monitor.HTTP3 = req.HTTP3
+	monitor.UDPProbe = req.UDPProbe
+	monitor.UDPExpect = req.UDPExpect
+	monitor.WebSocketPing = req.WebSocketPing
+	monitor.AdaptiveInterval = req.AdaptiveInterval
+	monitor.MinAdaptiveInterval = req.MinAdaptiveInterval
+	monitor.MaxAdaptiveInterval = req.MaxAdaptiveInterval
+	// Restart adaptation from the (possibly new) base interval
+	monitor.EffectiveInterval = 0
+	monitor.EffectiveIntervalSince = nil
+	if versionHeader := strings.TrimSpace(req.VersionHeader); versionHeader != monitor.VersionHeader {
+		// A different header means the previously observed version no longer applies
+		monitor.VersionHeader = versionHeader
+		monitor.CurrentVersion = ""
+		monitor.VersionChangedAt = nil
+	}
+	if extractLabel := strings.TrimSpace(req.ExtractLabel); extractLabel != monitor.ExtractLabel {
+		monitor.ExtractLabel = extractLabel
+		monitor.LastLabel = ""
+	}
+	if req.WatchValidators != monitor.WatchValidators || !req.WatchValidators {
+		// Start from a fresh baseline so re-enabling the watch doesn't alert on changes made meanwhile
+		monitor.LastETag = ""
+		monitor.LastModified = ""
+		monitor.ValidatorChangedAt = nil
+	}
+	monitor.WatchValidators = req.WatchValidators
+	if req.WatchCertificate != monitor.WatchCertificate || !req.WatchCertificate || urlChanged {
+		// Another host presents another certificate, so only a change at the same URL is alerted
+		monitor.CertFingerprint = ""
+		monitor.PreviousCertFingerprint = ""
+		monitor.CertFingerprintChangedAt = nil
+	}
+	monitor.WatchCertificate = req.WatchCertificate
+	monitor.DependsOn = strings.TrimSpace(req.DependsOn)
+	monitor.Method = strings.ToUpper(strings.TrimSpace(req.Method))
+	monitor.RequestBody = req.RequestBody
+	monitor.ContentType = strings.TrimSpace(req.ContentType)
+	monitor.ExpectKeyword = req.ExpectKeyword
+	monitor.ExpectKeywordAbsent = req.ExpectKeywordAbsent
+	monitor.MaxBodyBytes = req.MaxBodyBytes
+	monitor.SLATarget = req.SLATarget
+	monitor.SourceIP = req.SourceIP
+	monitor.DNSServer = strings.TrimSpace(req.DNSServer)
+	monitor.ExpectedIP = strings.TrimSpace(req.ExpectedIP)
+	monitor.AutoRetireAfter = req.AutoRetireAfter
+	monitor.AutoRetireAction = req.AutoRetireAction
+	monitor.ResponseTimeMode = req.ResponseTimeMode
+	monitor.LatencyWindow = req.LatencyWindow
+	monitor.LatencyThreshold = req.LatencyThreshold
+	monitor.DegradedThresholdMs = req.DegradedThresholdMs
+	monitor.SampleRate = req.SampleRate
+	monitor.StoreHistory = req.StoreHistory
+	monitor.PreCheckURL = req.PreCheckURL
+	monitor.PreCheckMethod = strings.ToUpper(req.PreCheckMethod)
+	monitor.PreCheckExtract = req.PreCheckExtract
+	monitor.PreCheckTTL = req.PreCheckTTL
+	applyPreCheckSecrets(monitor, req.PreCheckBody, req.PreCheckInject)
+	if jsonSchema := strings.TrimSpace(req.JSONSchema); jsonSchema != monitor.JSONSchema {
+		monitor.JSONSchema = jsonSchema
+		monitor.SchemaError = ""
+	}
+
+	// Only update CheckInterval if explicitly provided (non-zero)
+	// This allows updating other fields without resetting the interval
+	if req.CheckInterval > 0 {
+		monitor.CheckInterval = req.CheckInterval
+	}
+	monitor.ensureHeartbeatToken()
+}
+
+// monitorToConfig converts a monitor to its YAML configuration form
+func monitorToConfig(monitor *Monitor) MonitorConfig {
+	return MonitorConfig{
+		Name:         monitor.Name,
+		URL:          monitor.URL,
+		Icon:         monitor.Icon,
+		CheckInterval: monitor.CheckInterval,
+		TimeoutSeconds: monitor.TimeoutSeconds,
+		IsThirdParty: monitor.IsThirdParty,
+		Paused:       monitor.Paused,
+		RedirectPolicy: monitor.RedirectPolicy,
+		MaxRedirects: monitor.MaxRedirects,
+		FollowRedirects: monitor.FollowRedirects,
+		StatusOutcomes: monitor.StatusOutcomes,
+		ExpectedStatusCodes: monitor.ExpectedStatusCodes,
+		MinCacheAge:  monitor.MinCacheAge,
+		MaxCacheAge:  monitor.MaxCacheAge,
+		Tags:         monitor.Tags,
+		Group:        monitor.Group,
+		Endpoints:    monitor.Endpoints,
+		ParallelEndpoints: monitor.ParallelEndpoints,
+		GracePeriod:  monitor.GracePeriod,
+		EscalateAfter: monitor.EscalateAfter,
+		FailureThreshold: monitor.FailureThreshold,
+		RetryCount:   monitor.RetryCount,
+		RetryDelayMs: monitor.RetryDelayMs,
+		Type:         monitor.Type,
+		HeartbeatGrace: monitor.HeartbeatGrace,
+		StoreHeaders: monitor.StoreHeaders,
+		LightCheck:   monitor.LightCheck,
+		HTTP3:        monitor.HTTP3,
+		UDPProbe:     monitor.UDPProbe,
+		UDPExpect:    monitor.UDPExpect,
+		WebSocketPing: monitor.WebSocketPing,
+		AdaptiveInterval: monitor.AdaptiveInterval,
+		MinAdaptiveInterval: monitor.MinAdaptiveInterval,
+		MaxAdaptiveInterval: monitor.MaxAdaptiveInterval,
+		VersionHeader: monitor.VersionHeader,
+		ExtractLabel: monitor.ExtractLabel,
+		WatchValidators: monitor.WatchValidators,
+		WatchCertificate: monitor.WatchCertificate,
+		DependsOn:    monitor.DependsOn,
+		Method:       monitor.Method,
+		RequestBody:  monitor.RequestBody,
+		ContentType:  monitor.ContentType,
+		ExpectKeyword: monitor.ExpectKeyword,
+		ExpectKeywordAbsent: monitor.ExpectKeywordAbsent,
+		MaxBodyBytes: monitor.MaxBodyBytes,
+		JSONSchema:   monitor.JSONSchema,
+		SLATarget:    monitor.SLATarget,
+		SourceIP:     monitor.SourceIP,
+		DNSServer:    monitor.DNSServer,
+		ExpectedIP:   monitor.ExpectedIP,
+		AutoRetireAfter: monitor.AutoRetireAfter,
+		AutoRetireAction: monitor.AutoRetireAction,
+		ResponseTimeMode: monitor.ResponseTimeMode,
+		LatencyWindow: monitor.LatencyWindow,
+		LatencyThreshold: monitor.LatencyThreshold,
+		DegradedThresholdMs: monitor.DegradedThresholdMs,
+		SampleRate:   monitor.SampleRate,
+		StoreHistory: monitor.StoreHistory,
+		PreCheckURL:  monitor.PreCheckURL,
+		PreCheckMethod: monitor.PreCheckMethod,
+		PreCheckBody: monitor.PreCheckBody,
+		PreCheckExtract: monitor.PreCheckExtract,
+		PreCheckInject: monitor.PreCheckInject,
+		PreCheckTTL:  monitor.PreCheckTTL,
+	}
+}
+
 // apiCreateMonitor handles POST requests to create a new monitor
 func apiCreateMonitor(w http.ResponseWriter, r *http.Request) {
 	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
 	
 	setJSONHeaders(w)
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Idempotency-Key, X-API-Key, Authorization")
 
 	if r.Method == http.MethodOptions {
 		log.Debug().Msg("[API] OPTIONS /api/monitors/create: CORS preflight")
@@ -226,35 +599,73 @@ func apiCreateMonitor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Name == "" || req.URL == "" {
-		log.Warn().Str("name", req.Name).Str("url", req.URL).Msg("[API] ERROR POST /api/monitors/create: Missing required fields")
-		http.Error(w, "Name and URL are required", http.StatusBadRequest)
+	if err := validateMonitorRequest(&req); err != nil {
+		log.Warn().Err(err).Str("name", req.Name).Str("url", req.URL).Msg("[API] ERROR POST /api/monitors/create: Invalid monitor")
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	// Set default check interval to 60 seconds if not provided
-	checkInterval := req.CheckInterval
-	if checkInterval <= 0 {
-		checkInterval = 60
+	// Honor Idempotency-Key so retried provisioning requests don't create duplicates
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	if idempotencyKey != "" {
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			log.Warn().Int("length", len(idempotencyKey)).Msg("[API] ERROR POST /api/monitors/create: Idempotency-Key too long")
+			http.Error(w, "Idempotency-Key is too long", http.StatusBadRequest)
+			return
+		}
+
+		existingID, inProgress := idempotencyStore.begin(idempotencyKey)
+		if inProgress {
+			log.Warn().Str("idempotency_key", idempotencyKey).Msg("[API] ERROR POST /api/monitors/create: Request with this Idempotency-Key is in progress")
+			http.Error(w, "A request with this Idempotency-Key is already in progress", http.StatusConflict)
+			return
+		}
+		if existingID != 0 {
+			var existing Monitor
+			if err := db.First(&existing, existingID).Error; err == nil {
+				log.Info().Uint("id", existing.ID).Str("idempotency_key", idempotencyKey).
+					Msg("[API] POST /api/monitors/create: Returning monitor for repeated Idempotency-Key")
+				w.Header().Set("Idempotent-Replayed", "true")
+				if err := encodeJSONWithCompression(w, r, existing); err != nil {
+					log.Error().Err(err).Msg("[API] ERROR encoding monitor")
+				}
+				return
+			}
+			// The original monitor was deleted since - treat this as a fresh request
+			idempotencyStore.abort(idempotencyKey)
+			idempotencyStore.begin(idempotencyKey)
+		}
+	}
+
+	if monitorNameTaken(req.Name, 0) {
+		if idempotencyKey != "" {
+			idempotencyStore.abort(idempotencyKey)
+		}
+		log.Warn().Str("name", req.Name).Msg("[API] ERROR POST /api/monitors/create: Monitor name already in use")
+		writeFieldError(w, http.StatusConflict, "name", "A monitor with this name already exists")
+		return
 	}
 
 	monitor := Monitor{
-		Name:         req.Name,
-		URL:          req.URL,
-		IsThirdParty: req.IsThirdParty,
-		Icon:         req.Icon,
-		Status:       "unknown",
-		Uptime:       0,
-		ResponseTime: 0,
-		LastCheck:    "never",
-		CheckInterval: checkInterval,
+		Status:        "unknown",
+		Uptime:        0,
+		ResponseTime:  0,
+		LastCheck:     "never",
+		CheckInterval: DefaultCheckInterval, // Default check interval if not provided
 	}
+	applyMonitorRequest(&monitor, &req)
 
 	if err := db.Create(&monitor).Error; err != nil {
+		if idempotencyKey != "" {
+			idempotencyStore.abort(idempotencyKey)
+		}
 		log.Error().Err(err).Msg("[API] ERROR POST /api/monitors/create: Failed to create monitor")
 		http.Error(w, "Failed to create monitor", http.StatusInternalServerError)
 		return
 	}
+	if idempotencyKey != "" {
+		idempotencyStore.complete(idempotencyKey, monitor.ID)
+	}
 
 	log.Info().Uint("id", monitor.ID).Str("name", monitor.Name).Str("url", monitor.URL).
 		Int("check_interval", monitor.CheckInterval).Msg("[API] POST /api/monitors/create: Created monitor")
@@ -280,6 +691,128 @@ func apiCreateMonitor(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// upsertMu serializes upserts so concurrent provisioning can't create duplicates
+var upsertMu sync.Mutex
+
+// apiUpsertMonitor handles PUT requests that create a monitor or update the one with the same name and URL
+func apiUpsertMonitor(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodPut {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req CreateMonitorRequest
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Error().Err(err).Msg("[API] ERROR PUT /api/monitors/upsert: Failed to read body")
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+	if err := easyjson.Unmarshal(bodyBytes, &req); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR PUT /api/monitors/upsert: Invalid request body")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validateMonitorRequest(&req); err != nil {
+		log.Warn().Err(err).Str("name", req.Name).Str("url", req.URL).Msg("[API] ERROR PUT /api/monitors/upsert: Invalid monitor")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	upsertMu.Lock()
+	defer upsertMu.Unlock()
+
+	var existing Monitor
+	result := db.Where("url = ? AND name = ?", req.URL, req.Name).First(&existing)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		log.Error().Err(result.Error).Msg("[API] ERROR PUT /api/monitors/upsert: Failed to look up monitor")
+		http.Error(w, "Failed to look up monitor", http.StatusInternalServerError)
+		return
+	}
+
+	response := UpsertMonitorResponse{}
+	if result.Error == nil {
+		if existing.ConfigHash != "" {
+			log.Warn().Uint("id", existing.ID).Msg("[API] ERROR PUT /api/monitors/upsert: Monitor is managed by monitors.yaml")
+			http.Error(w, "Monitor is managed by monitors.yaml", http.StatusConflict)
+			return
+		}
+
+		if monitorNameTaken(req.Name, existing.ID) {
+			log.Warn().Uint("id", existing.ID).Str("name", req.Name).Msg("[API] ERROR PUT /api/monitors/upsert: Monitor name already in use")
+			writeFieldError(w, http.StatusConflict, "name", "A monitor with this name already exists")
+			return
+		}
+
+		// Compare config hashes so a repeated upsert with the same settings is a no-op
+		updated := existing
+		applyMonitorRequest(&updated, &req)
+		existingConfig, updatedConfig := monitorToConfig(&existing), monitorToConfig(&updated)
+		if calculateConfigHash(existingConfig) == calculateConfigHash(updatedConfig) {
+			log.Info().Uint("id", existing.ID).Msg("[API] PUT /api/monitors/upsert: Monitor unchanged")
+			response.Action = "unchanged"
+			response.Monitor = existing
+		} else {
+			if err := db.Save(&updated).Error; err != nil {
+				log.Error().Err(err).Uint("id", existing.ID).Msg("[API] ERROR PUT /api/monitors/upsert: Failed to update monitor")
+				http.Error(w, "Failed to update monitor", http.StatusInternalServerError)
+				return
+			}
+			log.Info().Uint("id", updated.ID).Str("name", updated.Name).Str("url", updated.URL).
+				Msg("[API] PUT /api/monitors/upsert: Updated monitor")
+			response.Action = "updated"
+			response.Monitor = updated
+			broadcastUpdate("monitor_update", updated)
+		}
+	} else {
+		if monitorNameTaken(req.Name, 0) {
+			log.Warn().Str("name", req.Name).Msg("[API] ERROR PUT /api/monitors/upsert: Monitor name already in use")
+			writeFieldError(w, http.StatusConflict, "name", "A monitor with this name already exists")
+			return
+		}
+
+		monitor := Monitor{
+			Status:        "unknown",
+			LastCheck:     "never",
+			CheckInterval: DefaultCheckInterval,
+		}
+		applyMonitorRequest(&monitor, &req)
+		if err := db.Create(&monitor).Error; err != nil {
+			log.Error().Err(err).Msg("[API] ERROR PUT /api/monitors/upsert: Failed to create monitor")
+			http.Error(w, "Failed to create monitor", http.StatusInternalServerError)
+			return
+		}
+		log.Info().Uint("id", monitor.ID).Str("name", monitor.Name).Str("url", monitor.URL).
+			Msg("[API] PUT /api/monitors/upsert: Created monitor")
+		response.Action = "created"
+		response.Monitor = monitor
+		broadcastUpdate("monitor_added", monitor)
+	}
+
+	if response.Action != "unchanged" {
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			monitorScheduler.refreshScheduler()
+		}()
+		monitor := response.Monitor
+		go checkService(&monitor)
+		broadcastStatsIfChanged()
+	}
+
+	if response.Action == "created" {
+		w.WriteHeader(http.StatusCreated)
+	}
+	if err := encodeJSONWithCompression(w, r, response); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding upsert response")
+	}
+}
+
 // apiStats handles GET requests to retrieve overall statistics
 func apiStats(w http.ResponseWriter, r *http.Request) {
 	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
@@ -292,14 +825,113 @@ func apiStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	stats := getStats()
-	log.Info().Float64("uptime", stats.OverallUptime).Int("up", stats.ServicesUp).
+	unit, unitRequested, err := parseResponseTimeUnit(r)
+	if err != nil {
+		log.Warn().Str("unit", r.URL.Query().Get("unit")).Msg("[API] ERROR GET /api/stats: Invalid unit")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var stats StatsResponse
+	if group := strings.TrimSpace(r.URL.Query().Get("group")); group != "" {
+		stats = getGroupStats(group)
+	} else {
+		stats = getStats()
+	}
+	log.Info().Str("group", r.URL.Query().Get("group")).Float64("uptime", stats.OverallUptime).Int("up", stats.ServicesUp).
 		Int("down", stats.ServicesDown).Int("avg_ms", stats.AvgResponseTime).Msg("[API] GET /api/stats")
-	if err := encodeJSONWithCompression(w, r, stats); err != nil {
+	var response interface{} = stats
+	if unitRequested {
+		response = statsInUnit(stats, unit)
+	}
+	if err := encodeJSONWithCompression(w, r, response); err != nil {
 		log.Error().Err(err).Msg("[API] ERROR encoding stats")
 	}
 }
 
+// apiStatsHistory handles GET requests for the periodic snapshots of the overall stats
+func apiStatsHistory(w http.ResponseWriter, r *http.Request) {
+	timeRange := r.URL.Query().Get("range")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("range", timeRange).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	days, err := parseDayRange(timeRange)
+	if err != nil {
+		log.Warn().Err(err).Str("range", timeRange).Msg("[API] ERROR GET /api/stats/history: Invalid range")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	history := getStatsHistory(days)
+	log.Info().Int("snapshots", len(history.Snapshots)).Str("range", history.Range).Msg("[API] GET /api/stats/history")
+	if err := encodeJSONWithCompression(w, r, history); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding stats history")
+	}
+}
+
+// apiFleetSLA handles GET requests for the fleet-wide availability and SLA target compliance
+func apiFleetSLA(w http.ResponseWriter, r *http.Request) {
+	timeRange := r.URL.Query().Get("range")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("range", timeRange).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	days, err := parseDayRange(timeRange)
+	if err != nil {
+		log.Warn().Err(err).Str("range", timeRange).Msg("[API] ERROR GET /api/stats/sla: Invalid range")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sla := getFleetSLA(days)
+	log.Info().Float64("availability", sla.Availability).Int("meeting", sla.MeetingTarget).
+		Int("missing", sla.MissingTarget).Str("range", sla.Range).Msg("[API] GET /api/stats/sla")
+	if err := encodeJSONWithCompression(w, r, sla); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding SLA")
+	}
+}
+
+// apiConfigDefaults handles GET requests for the server's monitor defaults and validation bounds
+func apiConfigDefaults(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	defaults := ConfigDefaultsResponse{
+		CheckInterval:    DefaultCheckInterval,
+		MinCheckInterval: MinCheckInterval,
+		MaxCheckInterval: MaxCheckInterval,
+		TimeoutSeconds:   DefaultTimeoutSeconds,
+		MaxTimeoutSeconds: MaxTimeoutSeconds,
+		AllowedSchemes:   AllowedSchemes,
+		RedirectPolicies: []string{RedirectPolicyFollow, RedirectPolicyRedirect, RedirectPolicyDown},
+		DefaultScheme:    "https",
+	}
+
+	if err := encodeJSONWithCompression(w, r, defaults); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding config defaults")
+	}
+}
+
 // apiResponseTime handles GET requests to retrieve response time history
 func apiResponseTime(w http.ResponseWriter, r *http.Request) {
 	monitorID := r.URL.Query().Get("id")
@@ -320,35 +952,285 @@ func apiResponseTime(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	unit, unitRequested, err := parseResponseTimeUnit(r)
+	if err != nil {
+		log.Warn().Str("unit", r.URL.Query().Get("unit")).Msg("[API] ERROR GET /api/response-time: Invalid unit")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	data := getResponseTimeData(monitorID, timeRange)
-	log.Info().Str("id", monitorID).Str("range", timeRange).Int("points", len(data)).Msg("[API] GET /api/response-time")
+	if unitRequested {
+		data = responseTimeDataInUnit(data, unit)
+	}
+	log.Info().Str("id", monitorID).Str("range", timeRange).Str("unit", unit).Int("points", len(data)).Msg("[API] GET /api/response-time")
 	if err := encodeJSONWithCompression(w, r, data); err != nil {
 		log.Error().Err(err).Msg("[API] ERROR encoding response time data")
 	}
 }
 
+// apiMonitorDowntime handles GET requests for per-day downtime minutes of a monitor
+func apiMonitorDowntime(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	timeRange := r.URL.Query().Get("range")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", id).Str("range", timeRange).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id == "" {
+		log.Warn().Msg("[API] ERROR GET /api/monitor/downtime: Missing monitor ID")
+		http.Error(w, "Monitor ID is required", http.StatusBadRequest)
+		return
+	}
+
+	days, err := parseDayRange(timeRange)
+	if err != nil {
+		log.Warn().Err(err).Str("range", timeRange).Msg("[API] ERROR GET /api/monitor/downtime: Invalid range")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var monitor Monitor
+	if err := db.First(&monitor, id).Error; err != nil {
+		log.Warn().Str("id", id).Err(err).Msg("[API] ERROR GET /api/monitor/downtime: Monitor not found")
+		http.Error(w, "Monitor not found", http.StatusNotFound)
+		return
+	}
+
+	data := getDowntimeData(&monitor, days)
+	log.Info().Str("id", id).Int("days", days).Float64("total_minutes", data.TotalMinutes).Msg("[API] GET /api/monitor/downtime")
+	if err := encodeJSONWithCompression(w, r, data); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding downtime data")
+	}
+}
+
+// apiMonitorFailures handles GET requests for a monitor's most recent failed checks
+func apiMonitorFailures(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", id).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id == "" {
+		log.Warn().Msg("[API] ERROR GET /api/monitor/failures: Missing monitor ID")
+		http.Error(w, "Monitor ID is required", http.StatusBadRequest)
+		return
+	}
+
+	limit := 50
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > 500 {
+			log.Warn().Str("limit", value).Msg("[API] ERROR GET /api/monitor/failures: Invalid limit")
+			http.Error(w, "limit must be between 1 and 500", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	var checks []CheckHistory
+	if err := db.Where("monitor_id = ? AND status <> ?", id, "up").
+		Order("created_at DESC").Limit(limit).Find(&checks).Error; err != nil {
+		log.Error().Err(err).Str("id", id).Msg("[API] ERROR GET /api/monitor/failures: Failed to query check history")
+		http.Error(w, "Failed to load failures", http.StatusInternalServerError)
+		return
+	}
+
+	failures := make([]CheckFailure, len(checks))
+	for i, check := range checks {
+		failures[i] = CheckFailure{
+			Timestamp:    check.CreatedAt.Format(time.RFC3339),
+			Status:       check.Status,
+			ResponseTime: check.ResponseTime,
+			ErrorCategory: check.ErrorCategory,
+		}
+		if check.Headers != nil {
+			var headers map[string][]string
+			if err := json.Unmarshal([]byte(*check.Headers), &headers); err == nil {
+				failures[i].Headers = headers
+			}
+		}
+	}
+
+	log.Info().Str("id", id).Int("failures", len(failures)).Msg("[API] GET /api/monitor/failures")
+	if err := encodeJSONWithCompression(w, r, failures); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding failures")
+	}
+}
+
+// apiMonitorVersions handles GET requests for a monitor's version change history
+func apiMonitorVersions(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", id).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id == "" {
+		log.Warn().Msg("[API] ERROR GET /api/monitor/versions: Missing monitor ID")
+		http.Error(w, "Monitor ID is required", http.StatusBadRequest)
+		return
+	}
+
+	changes := []VersionChange{}
+	if err := db.Where("monitor_id = ?", id).Order("created_at DESC").Limit(100).Find(&changes).Error; err != nil {
+		log.Error().Err(err).Str("id", id).Msg("[API] ERROR GET /api/monitor/versions: Failed to query version changes")
+		http.Error(w, "Failed to load version history", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info().Str("id", id).Int("changes", len(changes)).Msg("[API] GET /api/monitor/versions")
+	if err := encodeJSONWithCompression(w, r, changes); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding version changes")
+	}
+}
+
+// apiIncidentAnnotations handles GET requests for outages as Grafana annotations
+// Outages are derived from consecutive down checks in the raw check history
+func apiIncidentAnnotations(w http.ResponseWriter, r *http.Request) {
+	monitorID := r.URL.Query().Get("id")
+	timeRange := r.URL.Query().Get("range")
+	if timeRange == "" {
+		timeRange = "24h"
+	}
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", monitorID).Str("range", timeRange).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var window time.Duration
+	switch timeRange {
+	case "1h":
+		window = time.Hour
+	case "12h":
+		window = 12 * time.Hour
+	case "24h":
+		window = 24 * time.Hour
+	case "1w", "7d":
+		window = 7 * 24 * time.Hour
+	default:
+		log.Warn().Str("range", timeRange).Msg("[API] ERROR GET /api/incidents/annotations: Invalid range")
+		http.Error(w, "range must be 1h, 12h, 24h or 1w", http.StatusBadRequest)
+		return
+	}
+
+	var monitors []Monitor
+	db.Select("id", "name").Find(&monitors)
+	names := make(map[uint]string, len(monitors))
+	for _, monitor := range monitors {
+		names[monitor.ID] = monitor.Name
+	}
+
+	outages := findOutages(monitorID, time.Now().Add(-window))
+	annotations := make([]GrafanaAnnotation, 0, len(outages))
+	for _, outage := range outages {
+		name, exists := names[outage.MonitorID]
+		if !exists {
+			continue // Monitor was deleted
+		}
+		duration := outage.End.Sub(outage.Start).Round(time.Second)
+		text := fmt.Sprintf("%s was down for %s", name, duration)
+		tags := []string{"nanostatus", "outage", "monitor:" + name}
+		if outage.Ongoing {
+			text = fmt.Sprintf("%s is down (ongoing, %s so far)", name, duration)
+			tags = append(tags, "ongoing")
+		}
+		annotations = append(annotations, GrafanaAnnotation{
+			Time:    outage.Start.UnixMilli(),
+			TimeEnd: outage.End.UnixMilli(),
+			Title:   name + " outage",
+			Text:    text,
+			Tags:    tags,
+		})
+	}
+
+	log.Info().Str("range", timeRange).Int("annotations", len(annotations)).Msg("[API] GET /api/incidents/annotations")
+	if err := encodeJSONWithCompression(w, r, annotations); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding annotations")
+	}
+}
+
 // apiSSE handles Server-Sent Events connections
 func apiSSE(w http.ResponseWriter, r *http.Request) {
-	log.Info().Str("remote_addr", r.RemoteAddr).Str("user_agent", r.UserAgent()).Msg("[SSE] New connection request")
+	log.Info().Str("client_ip", clientIP(r)).Str("user_agent", r.UserAgent()).Msg("[SSE] New connection request")
 	
 	// Set headers for SSE
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Cache-Control")
+	w.Header().Set("Access-Control-Allow-Headers", "Cache-Control, Last-Event-ID")
+
+	// Browsers send the ID of the last event they received when reconnecting
+	var lastEventID *uint64
+	if value := r.Header.Get("Last-Event-ID"); value != "" {
+		if parsed, err := strconv.ParseUint(value, 10, 64); err == nil {
+			lastEventID = &parsed
+		} else {
+			log.Debug().Str("last_event_id", value).Msg("[SSE] Ignoring invalid Last-Event-ID")
+		}
+	}
+
+	// ?monitorId= limits the stream to one monitor's events, e.g. for a detail page
+	var monitorID uint
+	if value := r.URL.Query().Get("monitorId"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || parsed == 0 {
+			log.Warn().Str("monitor_id", value).Msg("[SSE] ERROR Invalid monitorId")
+			http.Error(w, "monitorId must be a monitor ID", http.StatusBadRequest)
+			return
+		}
+		monitorID = uint(parsed)
+	}
 
 	// Create client
-	clientID := fmt.Sprintf("%s-%d", r.RemoteAddr, time.Now().UnixNano())
-	client := sseBroadcaster.addClient(clientID)
+	clientID := fmt.Sprintf("%s-%d", clientIP(r), time.Now().UnixNano())
+	client, replay, baseline, complete := sseBroadcaster.addClient(clientID, monitorID, lastEventID)
 	defer func() {
 		sseBroadcaster.removeClient(clientID)
 		log.Debug().Str("client_id", clientID).Msg("[SSE] Cleanup completed")
 	}()
 
-	// Send initial connection message
+	// Send initial connection message, which sets the client's baseline event ID. When replaying, the
+	// baseline stays at the client's last event until the replayed events move it forward; when the
+	// missed events are no longer buffered, resync tells the client to reload its state
 	connectMsg := `{"type":"connected"}`
-	fmt.Fprintf(w, "data: %s\n\n", connectMsg)
+	connectID := baseline
+	if len(replay) > 0 {
+		connectID = *lastEventID
+	} else if !complete {
+		connectMsg = `{"type":"connected","data":{"resync":true}}`
+	}
+	fmt.Fprintf(w, "id: %d\ndata: %s\n\n", connectID, connectMsg)
+	for _, event := range replay {
+		fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event.ID, string(event.Data))
+	}
+	if len(replay) > 0 || !complete {
+		log.Info().Str("client_id", clientID).Uint64("last_event_id", *lastEventID).Int("replayed", len(replay)).
+			Bool("resync", !complete).Msg("[SSE] Client reconnected")
+	}
 	if flusher, ok := w.(http.Flusher); ok {
 		flusher.Flush()
 		log.Debug().Str("client_id", clientID).Msg("[SSE] Sent connection confirmation")
@@ -366,13 +1248,13 @@ func apiSSE(w http.ResponseWriter, r *http.Request) {
 
 	for {
 		select {
-		case message := <-client.Send:
+		case event := <-client.Send:
 			messageCount++
-			fmt.Fprintf(w, "data: %s\n\n", string(message))
+			fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event.ID, string(event.Data))
 			if flusher, ok := w.(http.Flusher); ok {
 				flusher.Flush()
 				log.Debug().Int("message_num", messageCount).Str("client_id", clientID).
-					Int("bytes", len(message)).Msg("[SSE] Sent message")
+					Int("bytes", len(event.Data)).Msg("[SSE] Sent message")
 			} else {
 				log.Error().Str("client_id", clientID).Msg("[SSE] ERROR: Cannot flush message")
 			}
@@ -394,6 +1276,53 @@ func apiSSE(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// apiNotificationSilence handles GET, POST, and DELETE requests for the global notification silence
+func apiNotificationSilence(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	setCORSHeaders(w)
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-API-Key, Authorization")
+
+	switch r.Method {
+	case http.MethodOptions:
+		log.Debug().Msg("[API] OPTIONS /api/notifications/silence: CORS preflight")
+		w.WriteHeader(http.StatusOK)
+		return
+	case http.MethodGet:
+	case http.MethodPost:
+		minutes, err := strconv.Atoi(r.URL.Query().Get("minutes"))
+		if err != nil || minutes <= 0 || minutes > MaxSilenceMinutes {
+			log.Warn().Str("minutes", r.URL.Query().Get("minutes")).Msg("[API] ERROR POST /api/notifications/silence: Invalid minutes")
+			http.Error(w, fmt.Sprintf("minutes must be between 1 and %d", MaxSilenceMinutes), http.StatusBadRequest)
+			return
+		}
+		until := time.Now().Add(time.Duration(minutes) * time.Minute)
+		if err := notificationSilence.set(until); err != nil {
+			log.Error().Err(err).Msg("[API] ERROR POST /api/notifications/silence: Failed to save silence")
+			http.Error(w, "Failed to save silence", http.StatusInternalServerError)
+			return
+		}
+		log.Warn().Int("minutes", minutes).Time("until", until).Msg("[API] POST /api/notifications/silence: Notifications silenced")
+	case http.MethodDelete:
+		if err := notificationSilence.clear(); err != nil {
+			log.Error().Err(err).Msg("[API] ERROR DELETE /api/notifications/silence: Failed to clear silence")
+			http.Error(w, "Failed to clear silence", http.StatusInternalServerError)
+			return
+		}
+		log.Info().Msg("[API] DELETE /api/notifications/silence: Notifications unsilenced")
+	default:
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	setJSONHeaders(w)
+	if err := encodeJSONWithCompression(w, r, notificationSilence.status()); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding notification silence")
+	}
+}
+
 // apiMonitor handles GET, PUT, and DELETE requests for individual monitors
 func apiMonitor(w http.ResponseWriter, r *http.Request) {
 	id := r.URL.Query().Get("id")
@@ -401,7 +1330,7 @@ func apiMonitor(w http.ResponseWriter, r *http.Request) {
 	
 	setCORSHeaders(w)
 	w.Header().Set("Access-Control-Allow-Methods", "GET, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-API-Key, Authorization")
 
 	if r.Method == http.MethodOptions {
 		log.Debug().Msg("[API] OPTIONS /api/monitor: CORS preflight")
@@ -431,6 +1360,9 @@ func apiMonitor(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		monitor.P50ResponseTime, monitor.P90ResponseTime, monitor.P99ResponseTime = upCheckPercentiles(
+			time.Now().Add(-24*time.Hour), "monitor_id = ?", monitor.ID)
+
 		log.Info().Str("id", id).Str("name", monitor.Name).Msg("[API] GET /api/monitor")
 		if err := encodeJSONWithCompression(w, r, monitor); err != nil {
 			log.Error().Err(err).Msg("[API] ERROR encoding monitor")
@@ -482,6 +1414,9 @@ func apiMonitor(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			log.Info().Str("id", id).Bool("paused", monitor.Paused).Msg("[API] PUT /api/monitor: Updated paused state")
+			if monitor.Paused {
+				alertManager.forget(monitor.ID)
+			}
 			
 			// Trigger immediate scheduler refresh to pick up pause state changes
 			go func() {
@@ -499,6 +1434,33 @@ func apiMonitor(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		// Check if this is a warming request - restarts the grace period from now
+		var warmingReq struct {
+			Warming *bool `json:"warming"`
+		}
+		if err := json.Unmarshal(bodyBytes, &warmingReq); err == nil && warmingReq.Warming != nil {
+			if *warmingReq.Warming {
+				now := time.Now()
+				monitor.GraceStartedAt = &now
+			} else {
+				monitor.GraceStartedAt = nil
+			}
+			if err := db.Save(&monitor).Error; err != nil {
+				log.Error().Err(err).Str("id", id).Msg("[API] ERROR PUT /api/monitor: Failed to update warming state")
+				http.Error(w, "Failed to update warming state", http.StatusInternalServerError)
+				return
+			}
+			log.Info().Str("id", id).Bool("warming", *warmingReq.Warming).Int("grace_period", monitor.GracePeriod).
+				Msg("[API] PUT /api/monitor: Updated warming state")
+
+			broadcastUpdate("monitor_update", monitor)
+
+			if err := encodeJSONWithCompression(w, r, monitor); err != nil {
+				log.Error().Err(err).Msg("[API] ERROR encoding monitor")
+			}
+			return
+		}
+
 		// Regular update request
 		var req CreateMonitorRequest
 		if err := easyjson.Unmarshal(bodyBytes, &req); err != nil {
@@ -507,24 +1469,21 @@ func apiMonitor(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if req.Name == "" || req.URL == "" {
-			log.Warn().Str("id", id).Msg("[API] ERROR PUT /api/monitor: Missing required fields")
-			http.Error(w, "Name and URL are required", http.StatusBadRequest)
+		if err := validateMonitorRequest(&req); err != nil {
+			log.Warn().Err(err).Str("id", id).Msg("[API] ERROR PUT /api/monitor: Invalid monitor")
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 
-		// Update monitor fields
-		monitor.Name = req.Name
-		monitor.URL = req.URL
-		monitor.IsThirdParty = req.IsThirdParty
-		monitor.Icon = req.Icon
-		
-		// Only update CheckInterval if explicitly provided (non-zero)
-		// This allows updating other fields without resetting the interval
-		if req.CheckInterval > 0 {
-			monitor.CheckInterval = req.CheckInterval
+		if monitorNameTaken(req.Name, monitor.ID) {
+			log.Warn().Str("id", id).Str("name", req.Name).Msg("[API] ERROR PUT /api/monitor: Monitor name already in use")
+			writeFieldError(w, http.StatusConflict, "name", "A monitor with this name already exists")
+			return
 		}
 
+		// Update monitor fields
+		applyMonitorRequest(&monitor, &req)
+
 		if err := db.Save(&monitor).Error; err != nil {
 			log.Error().Err(err).Str("id", id).Msg("[API] ERROR PUT /api/monitor: Failed to update monitor")
 			http.Error(w, "Failed to update monitor", http.StatusInternalServerError)
@@ -591,6 +1550,8 @@ func apiMonitor(w http.ResponseWriter, r *http.Request) {
 		}
 
 		log.Info().Str("id", id).Str("name", monitor.Name).Msg("[API] DELETE /api/monitor: Successfully deleted monitor")
+		checkSampler.forget(uint(monitorID))
+		alertManager.forget(uint(monitorID))
 		
 		// Broadcast deletion via SSE
 		broadcastUpdate("monitor_deleted", map[string]interface{}{"id": monitorID})
@@ -632,14 +1593,9 @@ func apiExportMonitors(w http.ResponseWriter, r *http.Request) {
 	}
 
 	for _, monitor := range monitors {
-		monitorConfig := MonitorConfig{
-			Name:         monitor.Name,
-			URL:          monitor.URL,
-			Icon:         monitor.Icon,
-			CheckInterval: monitor.CheckInterval,
-			IsThirdParty: monitor.IsThirdParty,
-			Paused:       monitor.Paused,
-		}
+		monitorConfig := monitorToConfig(&monitor)
+		// The export is public, so pre-check credentials are left out
+		monitorConfig.PreCheckBody, monitorConfig.PreCheckInject = "", ""
 		config.Monitors = append(config.Monitors, monitorConfig)
 	}
 
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HeaderCapture controls opt-in persistence of response headers on check history rows
+type HeaderCapture struct {
+	maxBytes  int                 // Cap on the serialized headers per check
+	retention time.Duration       // Stored headers are cleared after this long
+	redacted  map[string]struct{} // Canonical header names whose values are never stored
+}
+
+var headerCapture *HeaderCapture
+
+// defaultRedactedHeaders are redacted unless HEADER_CAPTURE_REDACT overrides them
+var defaultRedactedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie", "WWW-Authenticate"}
+
+// initHeaderCapture configures header capture limits from the environment
+func initHeaderCapture() {
+	maxBytes := getEnvInt("HEADER_CAPTURE_MAX_BYTES", 4096)
+	if maxBytes <= 0 {
+		maxBytes = 4096
+	}
+	retentionHours := getEnvInt("HEADER_RETENTION_HOURS", 24)
+	if retentionHours <= 0 {
+		retentionHours = 24
+	}
+
+	names := defaultRedactedHeaders
+	if value, ok := os.LookupEnv("HEADER_CAPTURE_REDACT"); ok {
+		names = strings.Split(value, ",")
+	}
+	redacted := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			redacted[http.CanonicalHeaderKey(name)] = struct{}{}
+		}
+	}
+
+	headerCapture = &HeaderCapture{
+		maxBytes:  maxBytes,
+		retention: time.Duration(retentionHours) * time.Hour,
+		redacted:  redacted,
+	}
+}
+
+// capture serializes response headers for storage, redacting sensitive values
+// Headers are added in name order until the size cap is reached; a truncated
+// result carries an X-NanoStatus-Truncated marker
+func (c *HeaderCapture) capture(header http.Header) *string {
+	if len(header) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const truncatedMarker = "X-NanoStatus-Truncated"
+	stored := make(map[string][]string, len(names))
+	size := 2 // Enclosing braces
+	for _, name := range names {
+		values := header[name]
+		if _, ok := c.redacted[http.CanonicalHeaderKey(name)]; ok {
+			values = []string{"[REDACTED]"}
+		}
+
+		// Approximate the JSON size of "name":["v1","v2"],
+		entrySize := len(name) + 6
+		for _, value := range values {
+			entrySize += len(value) + 3
+		}
+		if size+entrySize > c.maxBytes-len(truncatedMarker)-12 {
+			stored[truncatedMarker] = []string{"true"}
+			break
+		}
+		stored[name] = values
+		size += entrySize
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil
+	}
+	result := string(data)
+	return &result
+}
+
+// expireCapturedHeaders clears stored headers older than the header retention window
+func expireCapturedHeaders() {
+	cutoff := time.Now().Add(-headerCapture.retention)
+	result := db.Model(&CheckHistory{}).
+		Where("headers IS NOT NULL AND created_at < ?", cutoff).
+		Update("headers", nil)
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("[Cleanup] Failed to expire captured headers")
+		return
+	}
+	if result.RowsAffected > 0 {
+		log.Info().Int64("cleared", result.RowsAffected).Time("cutoff", cutoff).Msg("[Cleanup] Expired captured response headers")
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// healthPingTimeout bounds the database ping of /healthz, below the usual probe timeouts
+const healthPingTimeout = 2 * time.Second
+
+// HealthResponse is NanoStatus's own health for liveness and readiness probes
+type HealthResponse struct {
+	Status   string `json:"status"` // ok or error
+	DB       string `json:"db"`     // ok or error
+	Monitors int64  `json:"monitors"`
+	Error    string `json:"error,omitempty"`
+}
+
+// healthz handles GET requests probing NanoStatus itself: 200 when the database answers a ping, 503 otherwise
+// Only pings and counts monitors so it can be polled every few seconds; logged at debug level for the same reason
+func healthz(w http.ResponseWriter, r *http.Request) {
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := HealthResponse{Status: "ok", DB: "ok"}
+	status := http.StatusOK
+
+	sqlDB, err := db.DB()
+	if err == nil {
+		ctx, cancel := context.WithTimeout(r.Context(), healthPingTimeout)
+		err = sqlDB.PingContext(ctx)
+		cancel()
+	}
+	if err == nil {
+		err = db.Model(&Monitor{}).Count(&response.Monitors).Error
+	}
+	if err != nil {
+		log.Warn().Err(err).Msg("[Health] Database unreachable")
+		response = HealthResponse{Status: "error", DB: "error", Error: err.Error()}
+		status = http.StatusServiceUnavailable
+	}
+
+	log.Debug().Int("status", status).Int64("monitors", response.Monitors).Msg("[Health] GET /healthz")
+	if err := encodeJSONWithStatus(w, r, status, response); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding health")
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// historyExportFlushRows is how many CSV rows are written between flushes to the client
+const historyExportFlushRows = 500
+
+// apiExportMonitorHistory handles GET requests streaming a monitor's raw check history as CSV
+func apiExportMonitorHistory(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	timeRange := r.URL.Query().Get("range")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", id).Str("range", timeRange).Msg("[API] Request")
+
+	setCORSHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	monitorID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		log.Warn().Str("id", id).Msg("[API] ERROR GET /api/monitor/history/export: Invalid monitor ID")
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	var monitor Monitor
+	if err := db.Select("id").First(&monitor, monitorID).Error; err != nil {
+		log.Warn().Str("id", id).Err(err).Msg("[API] ERROR GET /api/monitor/history/export: Monitor not found")
+		http.Error(w, "Monitor not found", http.StatusNotFound)
+		return
+	}
+
+	// Same ranges as /api/response-time, but every raw check rather than a limited number of points
+	rows, err := db.Model(&CheckHistory{}).Select("created_at", "status", "response_time").
+		Where("monitor_id = ? AND created_at > ?", monitor.ID, responseTimeCutoff(timeRange, time.Now())).
+		Order("created_at ASC").Rows()
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("[API] ERROR GET /api/monitor/history/export: Failed to query history")
+		http.Error(w, "Failed to export check history", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=history-%d.csv", monitor.ID))
+	flusher, _ := w.(http.Flusher)
+
+	// Rows are streamed as they are read, so large ranges are never held in memory
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"timestamp", "status", "response_time"})
+	count := 0
+	for rows.Next() {
+		var createdAt time.Time
+		var status string
+		var responseTime int
+		if err := rows.Scan(&createdAt, &status, &responseTime); err != nil {
+			log.Error().Err(err).Str("id", id).Msg("[API] ERROR GET /api/monitor/history/export: Failed to read row")
+			break
+		}
+		writer.Write([]string{createdAt.UTC().Format(time.RFC3339), status, strconv.Itoa(responseTime)})
+		count++
+		if count%historyExportFlushRows == 0 {
+			writer.Flush()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("[API] ERROR GET /api/monitor/history/export: Failed to write CSV")
+		return
+	}
+
+	log.Info().Str("id", id).Str("range", timeRange).Int("rows", count).Msg("[API] GET /api/monitor/history/export")
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+)
+
+// validateHTTP3 checks that an HTTP/3 monitor only makes requests QUIC can carry: HTTP/3 always
+// runs over TLS, so the URL, the additional endpoints and the pre-check URL must be https
+func validateHTTP3(enabled bool, monitorType, rawURL string, endpoints []string, preCheckURL string) error {
+	if !enabled {
+		return nil
+	}
+	if monitorType == MonitorTypePush {
+		return fmt.Errorf("http3 can't be used with push monitors")
+	}
+	for _, target := range append([]string{rawURL, preCheckURL}, endpoints...) {
+		if target != "" && urlScheme(target) != "https" {
+			return fmt.Errorf("http3 requires https URLs (got %s)", target)
+		}
+	}
+	return nil
+}
+
+// http3Clients caches HTTP/3 clients, keyed like sourceClients
+var (
+	http3Clients   = make(map[string]*http.Client)
+	http3ClientsMu sync.Mutex
+)
+
+// http3ClientForMonitor returns the cached HTTP/3 client for a monitor's checks
+// Requests go over QUIC only: an endpoint that doesn't speak HTTP/3 fails the check with a connection
+// error rather than silently falling back to TCP
+func http3ClientForMonitor(monitor *Monitor, stopAtRedirects bool) *http.Client {
+	key := fmt.Sprintf("%s|%s|%v", monitor.SourceIP, monitor.DNSServer, stopAtRedirects)
+	http3ClientsMu.Lock()
+	defer http3ClientsMu.Unlock()
+	if client, exists := http3Clients[key]; exists {
+		return client
+	}
+
+	transport := &http3.Transport{Dial: quicDialer(monitor.SourceIP, resolverForMonitor(monitor))}
+	base := httpClient
+	if stopAtRedirects {
+		base = noRedirectClient
+	}
+	client := &http.Client{
+		Timeout:       base.Timeout,
+		Transport:     transport,
+		CheckRedirect: base.CheckRedirect,
+	}
+	http3Clients[key] = client
+	return client
+}
+
+// quicDialer returns the dial function of an HTTP/3 transport, resolving through resolver (nil is the
+// system resolver) and sending from sourceIP when it's set
+func quicDialer(sourceIP string, resolver *net.Resolver) func(context.Context, string, *tls.Config, *quic.Config) (*quic.Conn, error) {
+	return func(ctx context.Context, addr string, tlsConf *tls.Config, conf *quic.Config) (*quic.Conn, error) {
+		host, portName, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		port, err := strconv.Atoi(portName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", portName)
+		}
+
+		local := &net.UDPAddr{}
+		if sourceIP != "" {
+			local.IP = net.ParseIP(sourceIP)
+		}
+		addrs, err := resolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return nil, err
+		}
+		var remote *net.UDPAddr
+		for _, ip := range addrs {
+			// The source address fixes the address family
+			if local.IP == nil || (local.IP.To4() != nil) == ip.Unmap().Is4() {
+				remote = &net.UDPAddr{IP: ip.Unmap().AsSlice(), Port: port}
+				break
+			}
+		}
+		if remote == nil {
+			return nil, fmt.Errorf("%s has no address reachable from sourceIp %s", host, sourceIP)
+		}
+
+		udpConn, err := net.ListenUDP("udp", local)
+		if err != nil {
+			return nil, err
+		}
+		// Each connection owns its socket, which is closed with the connection
+		quicTransport := &quic.Transport{Conn: udpConn}
+		conn, err := quicTransport.DialEarly(ctx, remote, tlsConf, conf)
+		if err != nil {
+			quicTransport.Close()
+			return nil, fmt.Errorf("HTTP/3 (QUIC) connection to %s failed: %w", addr, err)
+		}
+		go func() {
+			<-conn.Context().Done()
+			quicTransport.Close()
+		}()
+		return conn, nil
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/quic-go/quic-go/http3"
+)
+
+func TestValidateHTTP3(t *testing.T) {
+	if err := validateHTTP3(true, MonitorTypePoll, "https://example.com", []string{"https://example.com/health"}, ""); err != nil {
+		t.Errorf("https monitor rejected: %v", err)
+	}
+	if err := validateHTTP3(false, MonitorTypePoll, "http://example.com", nil, ""); err != nil {
+		t.Errorf("http3 disabled but validated: %v", err)
+	}
+	for _, tc := range []struct {
+		name      string
+		url       string
+		endpoints []string
+		preCheck  string
+	}{
+		{"http URL", "http://example.com", nil, ""},
+		{"http endpoint", "https://example.com", []string{"http://example.com/health"}, ""},
+		{"http pre-check", "https://example.com", nil, "http://example.com/login"},
+		{"wss URL", "wss://example.com", nil, ""},
+	} {
+		if err := validateHTTP3(true, MonitorTypePoll, tc.url, tc.endpoints, tc.preCheck); err == nil {
+			t.Errorf("%s: accepted", tc.name)
+		}
+	}
+	if err := validateHTTP3(true, MonitorTypePush, "", nil, ""); err == nil {
+		t.Error("push monitor accepted")
+	}
+}
+
+func TestQUICDialerRoundTrip(t *testing.T) {
+	// Borrow httptest's certificate for 127.0.0.1
+	certServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer certServer.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(certServer.Certificate())
+
+	udpConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Skipf("UDP unavailable: %v", err)
+	}
+	server := &http3.Server{
+		TLSConfig: http3.ConfigureTLSConfig(&tls.Config{Certificates: certServer.TLS.Certificates}),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "ok")
+		}),
+	}
+	go server.Serve(udpConn)
+	defer server.Close()
+
+	transport := &http3.Transport{
+		Dial:            quicDialer("127.0.0.1", nil),
+		TLSClientConfig: &tls.Config{RootCAs: roots},
+	}
+	defer transport.Close()
+	resp, err := (&http.Client{Transport: transport}).Get("https://" + udpConn.LocalAddr().String() + "/")
+	if err != nil {
+		t.Fatalf("HTTP/3 request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.Proto != "HTTP/3.0" || resp.StatusCode != http.StatusOK {
+		t.Errorf("got %s %d, want HTTP/3.0 200", resp.Proto, resp.StatusCode)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// idempotencyTTL is how long an Idempotency-Key is remembered after the first request
+const idempotencyTTL = 10 * time.Minute
+
+// maxIdempotencyKeyLength bounds the keys accepted from clients
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyStore remembers recent Idempotency-Key values and the monitor each one created
+// so retried create requests return the original monitor instead of a duplicate
+type IdempotencyStore struct {
+	entries map[string]idempotencyEntry
+	mu      sync.Mutex
+}
+
+type idempotencyEntry struct {
+	monitorID uint // 0 while the original request is still in progress
+	expires   time.Time
+}
+
+var idempotencyStore = &IdempotencyStore{entries: make(map[string]idempotencyEntry)}
+
+// begin reserves key for a new request
+// Returns the monitor ID created by an earlier request with the same key, or inProgress
+// if that request hasn't finished yet; otherwise the key is reserved and (0, false) is returned
+func (s *IdempotencyStore) begin(key string) (monitorID uint, inProgress bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range s.entries {
+		if now.After(entry.expires) {
+			delete(s.entries, k)
+		}
+	}
+
+	if entry, exists := s.entries[key]; exists {
+		return entry.monitorID, entry.monitorID == 0
+	}
+	s.entries[key] = idempotencyEntry{expires: now.Add(idempotencyTTL)}
+	return 0, false
+}
+
+// complete records the monitor created for a reserved key
+func (s *IdempotencyStore) complete(key string, monitorID uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = idempotencyEntry{monitorID: monitorID, expires: time.Now().Add(idempotencyTTL)}
+}
+
+// abort releases a reserved key so the request can be retried
+func (s *IdempotencyStore) abort(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// MonitorImportResponse reports the outcome of a monitor import
+type MonitorImportResponse struct {
+	ConfigSyncResult
+	Invalid int `json:"invalid"` // Entries that failed validation (see the [Config] warnings in the log)
+}
+
+// decodeImportedConfigs decodes an imported monitor list: JSON for application/json, otherwise the YAML
+// of monitors.yaml and /api/monitors/export
+func decodeImportedConfigs(contentType string, data []byte) ([]MonitorConfig, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/json" {
+		return decodeMonitorConfigsJSON(data)
+	}
+	var config ConfigFile
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	return config.Monitors, nil
+}
+
+// apiImportMonitors handles POST requests creating and updating monitors from a YAML or JSON monitor list
+// Monitors are matched and updated like monitors.yaml on startup, but nothing is removed, so importing
+// a partial list is safe. Imported monitors are managed by the "import" config source
+func apiImportMonitors(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("content_type", r.Header.Get("Content-Type")).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodPost {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxRemoteConfigBytes+1))
+	if err != nil {
+		log.Error().Err(err).Msg("[API] ERROR POST /api/monitors/import: Failed to read request body")
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+	if len(data) > maxRemoteConfigBytes {
+		log.Warn().Int("bytes", len(data)).Msg("[API] ERROR POST /api/monitors/import: Request body too large")
+		http.Error(w, fmt.Sprintf("monitor list exceeds %d bytes", maxRemoteConfigBytes), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	configs, err := decodeImportedConfigs(r.Header.Get("Content-Type"), data)
+	if err != nil {
+		log.Warn().Err(err).Msg("[API] ERROR POST /api/monitors/import: Invalid monitor list")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	monitors, hashes := monitorsFromConfigs(configs, ConfigSourceImport)
+	response := MonitorImportResponse{
+		ConfigSyncResult: syncConfigMonitors(ConfigSourceImport, monitors, hashes, false),
+		Invalid:          len(configs) - len(monitors),
+	}
+
+	// Start tickers for created monitors
+	if response.Created > 0 {
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			monitorScheduler.refreshScheduler()
+		}()
+	}
+
+	log.Info().Int("created", response.Created).Int("updated", response.Updated).Int("unchanged", response.Unchanged).
+		Int("skipped", response.Skipped).Int("invalid", response.Invalid).Msg("[API] POST /api/monitors/import: Imported monitors")
+	if err := encodeJSONWithCompression(w, r, response); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding import response")
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// maxMonitorIncidents caps how many recent incidents GET /api/monitor/incidents returns
+const maxMonitorIncidents = 100
+
+// openIncident records that a monitor went down
+// A monitor has at most one open incident: the status survives restarts, but if an open incident is
+// already there (e.g. a check was interrupted between the two writes), it is kept rather than duplicated
+func openIncident(monitorID uint, at time.Time) {
+	var open int64
+	db.Model(&Incident{}).Where("monitor_id = ? AND ended_at IS NULL", monitorID).Count(&open)
+	if open > 0 {
+		log.Debug().Uint("monitor_id", monitorID).Msg("[Incident] Monitor already has an open incident")
+		return
+	}
+
+	incident := Incident{MonitorID: monitorID, StartedAt: at}
+	if err := db.Create(&incident).Error; err != nil {
+		log.Error().Err(err).Uint("monitor_id", monitorID).Msg("[Incident] Failed to open incident")
+		return
+	}
+	log.Info().Uint("monitor_id", monitorID).Uint("incident_id", incident.ID).Msg("[Incident] Opened")
+}
+
+// closeIncident ends a monitor's open incident, storing its duration
+func closeIncident(monitorID uint, at time.Time) {
+	var incidents []Incident
+	db.Where("monitor_id = ? AND ended_at IS NULL", monitorID).Find(&incidents)
+	for _, incident := range incidents {
+		duration := int64(at.Sub(incident.StartedAt).Seconds())
+		if err := db.Model(&incident).Updates(map[string]interface{}{"ended_at": at, "duration_seconds": duration}).Error; err != nil {
+			log.Error().Err(err).Uint("monitor_id", monitorID).Uint("incident_id", incident.ID).Msg("[Incident] Failed to close incident")
+			continue
+		}
+		log.Info().Uint("monitor_id", monitorID).Uint("incident_id", incident.ID).Str("duration", formatDowntime(duration)).Msg("[Incident] Closed")
+	}
+}
+
+// withOngoingDurations sets the duration so far of incidents that haven't ended
+func withOngoingDurations(incidents []Incident, now time.Time) []Incident {
+	for i := range incidents {
+		if incidents[i].EndedAt == nil {
+			incidents[i].DurationSeconds = int64(now.Sub(incidents[i].StartedAt).Seconds())
+		}
+	}
+	return incidents
+}
+
+// apiMonitorIncidents handles GET requests for a monitor's recent incidents, newest first
+func apiMonitorIncidents(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", id).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id == "" {
+		log.Warn().Msg("[API] ERROR GET /api/monitor/incidents: Missing monitor ID")
+		http.Error(w, "Monitor ID is required", http.StatusBadRequest)
+		return
+	}
+
+	incidents := []Incident{}
+	if err := db.Where("monitor_id = ?", id).Order("started_at DESC").Limit(maxMonitorIncidents).Find(&incidents).Error; err != nil {
+		log.Error().Err(err).Str("id", id).Msg("[API] ERROR GET /api/monitor/incidents: Failed to query incidents")
+		http.Error(w, "Failed to load incidents", http.StatusInternalServerError)
+		return
+	}
+	incidents = withOngoingDurations(incidents, time.Now())
+
+	log.Info().Str("id", id).Int("incidents", len(incidents)).Msg("[API] GET /api/monitor/incidents")
+	if err := encodeJSONWithCompression(w, r, incidents); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding incidents")
+	}
+}
+
+// apiIncidents handles GET requests for all open incidents, longest running first
+func apiIncidents(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	incidents := []Incident{}
+	if err := db.Preload("Monitor", func(tx *gorm.DB) *gorm.DB { return tx.Select("id", "name") }).
+		Where("ended_at IS NULL").Order("started_at ASC").Find(&incidents).Error; err != nil {
+		log.Error().Err(err).Msg("[API] ERROR GET /api/incidents: Failed to query open incidents")
+		http.Error(w, "Failed to load incidents", http.StatusInternalServerError)
+		return
+	}
+	for i := range incidents {
+		if incidents[i].Monitor != nil {
+			incidents[i].MonitorName = incidents[i].Monitor.Name
+		}
+	}
+	incidents = withOngoingDurations(incidents, time.Now())
+
+	log.Info().Int("open", len(incidents)).Msg("[API] GET /api/incidents")
+	if err := encodeJSONWithCompression(w, r, incidents); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding open incidents")
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// expectedStaticHash is the digest of the embedded dist directory, baked in at build time:
+// go build -ldflags "-X main.expectedStaticHash=<hash>"
+var expectedStaticHash string
+
+// hashStaticFiles computes a digest of every file in the static filesystem
+// The digest is the SHA256 of sha256sum-style lines ("<hash>  <path>\n") sorted by path,
+// so it can be reproduced at build time with:
+// cd dist && find . -type f | sed 's|^\./||' | LC_ALL=C sort | xargs sha256sum | sha256sum
+func hashStaticFiles(staticFS fs.FS) (string, error) {
+	var paths []string
+	err := fs.WalkDir(staticFS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(paths)
+
+	digest := sha256.New()
+	for _, path := range paths {
+		data, err := fs.ReadFile(staticFS, path)
+		if err != nil {
+			return "", err
+		}
+		fileHash := sha256.Sum256(data)
+		fmt.Fprintf(digest, "%s  %s\n", hex.EncodeToString(fileHash[:]), path)
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// verifyStaticFiles checks the embedded static files against expectedStaticHash
+// Controlled by STATIC_INTEGRITY_CHECK: "warn" logs a mismatch, "enforce" refuses to start
+func verifyStaticFiles(staticFS fs.FS) {
+	mode := strings.ToLower(os.Getenv("STATIC_INTEGRITY_CHECK"))
+	if mode == "" || mode == "off" {
+		return
+	}
+	if mode != "warn" && mode != "enforce" {
+		log.Warn().Str("mode", mode).Msg("[Integrity] Unknown STATIC_INTEGRITY_CHECK mode, expected warn or enforce")
+		return
+	}
+
+	actual, err := hashStaticFiles(staticFS)
+	if err != nil {
+		if mode == "enforce" {
+			log.Fatal().Err(err).Msg("[Integrity] Failed to hash embedded static files")
+		}
+		log.Warn().Err(err).Msg("[Integrity] Failed to hash embedded static files")
+		return
+	}
+
+	if expectedStaticHash == "" {
+		if mode == "enforce" {
+			log.Fatal().Str("actual", actual).Msg("[Integrity] No expected static hash was set at build time")
+		}
+		log.Warn().Str("actual", actual).Msg("[Integrity] No expected static hash was set at build time, skipping verification")
+		return
+	}
+
+	if !strings.EqualFold(actual, expectedStaticHash) {
+		if mode == "enforce" {
+			log.Fatal().Str("expected", expectedStaticHash).Str("actual", actual).Msg("[Integrity] Embedded static files do not match expected hash")
+		}
+		log.Warn().Str("expected", expectedStaticHash).Str("actual", actual).Msg("[Integrity] Embedded static files do not match expected hash")
+		return
+	}
+
+	log.Info().Str("hash", actual).Msg("[Integrity] ✅ Embedded static files verified")
+}
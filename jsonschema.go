@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// maxCachedSchemas bounds the compiled schema cache; it is emptied when full
+const maxCachedSchemas = 64
+
+// maxSchemaError caps the validation error stored on a monitor
+const maxSchemaError = 500
+
+// schemaDir is the directory (JSON_SCHEMA_DIR) schema files may be loaded from; when empty, only
+// inline schemas are accepted
+var schemaDir string
+
+// schemaCache holds compiled schemas keyed by the SHA-256 of the schema document, so an edited
+// schema file is recompiled on the next check
+var (
+	schemaCache   = make(map[[sha256.Size]byte]*jsonschema.Schema)
+	schemaCacheMu sync.Mutex
+)
+
+// initSchemaDir reads JSON_SCHEMA_DIR
+func initSchemaDir() {
+	schemaDir = strings.TrimSpace(os.Getenv("JSON_SCHEMA_DIR"))
+	if schemaDir != "" {
+		log.Info().Str("dir", schemaDir).Msg("[Config] Loading jsonSchema files from directory")
+	}
+}
+
+// readSchemaSource returns a monitor's schema document: the source itself when it's inline JSON, or
+// the named file inside JSON_SCHEMA_DIR. Names must be relative and stay inside the directory
+func readSchemaSource(source string) ([]byte, error) {
+	trimmed := strings.TrimSpace(source)
+	if strings.HasPrefix(trimmed, "{") || trimmed == "true" || trimmed == "false" {
+		return []byte(trimmed), nil
+	}
+	if schemaDir == "" {
+		return nil, fmt.Errorf("schema must be inline JSON (set JSON_SCHEMA_DIR to load schema files)")
+	}
+	if !filepath.IsLocal(trimmed) {
+		return nil, fmt.Errorf("schema file must be a relative path inside JSON_SCHEMA_DIR")
+	}
+
+	// os.Root also refuses symlinks that lead out of the directory
+	root, err := os.OpenRoot(schemaDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open JSON_SCHEMA_DIR: %w", err)
+	}
+	defer root.Close()
+	file, err := root.Open(trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file %q", trimmed)
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// compileJSONSchema compiles a schema document; references outside the document are not loaded
+func compileJSONSchema(data []byte) (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema JSON: %w", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft7)
+	compiler.UseLoader(jsonschema.SchemeURLLoader{}) // No file:// or remote $refs
+	if err := compiler.AddResource("schema.json", doc); err != nil {
+		return nil, err
+	}
+	return compiler.Compile("schema.json")
+}
+
+// loadJSONSchema compiles a monitor's schema, which is either inline JSON or the name of a file in
+// JSON_SCHEMA_DIR. Compiled schemas are cached by content
+func loadJSONSchema(source string) (*jsonschema.Schema, error) {
+	data, err := readSchemaSource(source)
+	if err != nil {
+		return nil, err
+	}
+	key := sha256.Sum256(data)
+
+	schemaCacheMu.Lock()
+	schema, ok := schemaCache[key]
+	schemaCacheMu.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	schema, err = compileJSONSchema(data)
+	if err != nil {
+		return nil, err
+	}
+	schemaCacheMu.Lock()
+	if len(schemaCache) >= maxCachedSchemas {
+		clear(schemaCache)
+	}
+	schemaCache[key] = schema
+	schemaCacheMu.Unlock()
+	return schema, nil
+}
+
+// validateJSONSchema checks that a monitor's schema (if any) loads and compiles
+func validateJSONSchema(source string) error {
+	if strings.TrimSpace(source) == "" {
+		return nil
+	}
+	if _, err := loadJSONSchema(source); err != nil {
+		return fmt.Errorf("invalid jsonSchema: %w", err)
+	}
+	return nil
+}
+
+// validateResponseSchema validates a response body (read up to the monitor's body limit) against the schema
+func validateResponseSchema(source string, data []byte) error {
+	schema, err := loadJSONSchema(source)
+	if err != nil {
+		return err
+	}
+	body, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("response is not valid JSON: %w", err)
+	}
+	if err := schema.Validate(body); err != nil {
+		var validationErr *jsonschema.ValidationError
+		if errors.As(err, &validationErr) {
+			return errors.New(schemaErrorMessage(validationErr))
+		}
+		return err
+	}
+	return nil
+}
+
+// schemaErrorMessage flattens a validation error into one line of its leaf causes, e.g.
+// "at '/status': value must be 'ok'; at '/': missing property 'version'"
+func schemaErrorMessage(err *jsonschema.ValidationError) string {
+	printer := message.NewPrinter(language.English)
+	var causes []string
+	var collect func(e *jsonschema.ValidationError)
+	collect = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			causes = append(causes, fmt.Sprintf("at '/%s': %s", strings.Join(e.InstanceLocation, "/"), e.ErrorKind.LocalizedString(printer)))
+			return
+		}
+		for _, cause := range e.Causes {
+			collect(cause)
+		}
+	}
+	collect(err)
+
+	text := strings.Join(causes, "; ")
+	if len(text) > maxSchemaError {
+		text = strings.ToValidUTF8(text[:maxSchemaError], "") + "..."
+	}
+	return text
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadSchemaSourceRejectsPathsOutsideSchemaDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "health.json"), []byte(`{"type":"object"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(previous string) { schemaDir = previous }(schemaDir)
+
+	schemaDir = ""
+	if _, err := readSchemaSource("health.json"); err == nil {
+		t.Error("schema file accepted without JSON_SCHEMA_DIR")
+	}
+
+	schemaDir = dir
+	if _, err := readSchemaSource("health.json"); err != nil {
+		t.Errorf("schema file inside JSON_SCHEMA_DIR rejected: %v", err)
+	}
+	for _, source := range []string{"/etc/passwd", "../health.json", "sub/../../health.json"} {
+		if _, err := readSchemaSource(source); err == nil {
+			t.Errorf("readSchemaSource(%q) succeeded, want an error", source)
+		}
+	}
+}
+
+func TestValidateResponseSchema(t *testing.T) {
+	schema := `{"type":"object","required":["status"],"properties":{"status":{"const":"ok"}}}`
+
+	if err := validateResponseSchema(schema, []byte(`{"status":"ok"}`)); err != nil {
+		t.Errorf("matching body failed validation: %v", err)
+	}
+	err := validateResponseSchema(schema, []byte(`{"status":"down"}`))
+	if err == nil || !strings.Contains(err.Error(), "/status") {
+		t.Errorf("validation error = %v, want one pointing at /status", err)
+	}
+	if err := validateResponseSchema(schema, []byte(`not json`)); err == nil {
+		t.Error("non-JSON body passed validation")
+	}
+}
+
+func TestValidateJSONSchemaRejectsExternalRefs(t *testing.T) {
+	if err := validateJSONSchema(`{"$ref":"file:///etc/passwd"}`); err == nil {
+		t.Error("schema with a file:// $ref compiled")
+	}
+	if err := validateJSONSchema(`{"definitions":{"id":{"type":"integer"}},"$ref":"#/definitions/id"}`); err != nil {
+		t.Errorf("schema with a local $ref rejected: %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrorCategoryKeyword marks checks whose response body failed the expected keyword assertion
+const ErrorCategoryKeyword = "keyword"
+
+// validateExpectKeyword checks a monitor's keyword assertion settings
+func validateExpectKeyword(keyword string, absent bool, method string) error {
+	if keyword == "" {
+		if absent {
+			return fmt.Errorf("expectKeywordAbsent requires expectKeyword")
+		}
+		return nil
+	}
+	if strings.EqualFold(strings.TrimSpace(method), http.MethodHead) {
+		return fmt.Errorf("expectKeyword can't be used with method HEAD, which returns no body")
+	}
+	return nil
+}
+
+// checkKeyword asserts the expected keyword appears in (or, with ExpectKeywordAbsent, is missing from)
+// the part of a response body read within the monitor's body limit. Matching is case-sensitive
+func checkKeyword(monitor *Monitor, body []byte) error {
+	found := bytes.Contains(body, []byte(monitor.ExpectKeyword))
+	if monitor.ExpectKeywordAbsent && found {
+		return fmt.Errorf("response body contains %q", monitor.ExpectKeyword)
+	}
+	if !monitor.ExpectKeywordAbsent && !found {
+		return fmt.Errorf("response body does not contain %q", monitor.ExpectKeyword)
+	}
+	return nil
+}
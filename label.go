@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxLabelLength caps the extracted label stored on a monitor and its check history
+const maxLabelLength = 100
+
+// validateExtractLabel checks a monitor's label extraction spec: header:<name> or json:<path>
+func validateExtractLabel(spec string) error {
+	if spec == "" {
+		return nil
+	}
+	source, path, _ := strings.Cut(spec, ":")
+	if (source != "header" && source != "json") || strings.TrimSpace(path) == "" {
+		return fmt.Errorf("extractLabel must be header:<name> or json:<path>")
+	}
+	return nil
+}
+
+// labelFromBody reports whether a monitor's label is read from the response body
+func (m *Monitor) labelFromBody() bool {
+	return strings.HasPrefix(m.ExtractLabel, "json:")
+}
+
+// extractLabel reads a monitor's label from a response: a header value, or a JSON scalar at a dot-separated
+// path (the same paths preCheckExtract uses). Returns "" when the header or field is missing
+func extractLabel(monitor *Monitor, header http.Header, body []byte) string {
+	source, path, _ := strings.Cut(monitor.ExtractLabel, ":")
+	path = strings.TrimSpace(path)
+
+	var label string
+	switch source {
+	case "header":
+		label = strings.TrimSpace(header.Get(path))
+	case "json":
+		var document interface{}
+		if err := json.Unmarshal(body, &document); err != nil {
+			return ""
+		}
+		value, ok := lookupJSONPath(document, path)
+		if !ok {
+			return ""
+		}
+		label = jsonScalarString(value)
+	}
+	if len(label) > maxLabelLength {
+		label = label[:maxLabelLength]
+	}
+	return label
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+)
+
+// maxLatencyWindow caps how many recent checks a rolling latency window may span
+const maxLatencyWindow = 100
+
+// validateLatencyWindow checks a monitor's rolling latency window settings (window 0 disables)
+func validateLatencyWindow(window, threshold int) error {
+	if window == 0 {
+		if threshold != 0 {
+			return fmt.Errorf("latencyThreshold requires latencyWindow")
+		}
+		return nil
+	}
+	if window < 2 || window > maxLatencyWindow {
+		return fmt.Errorf("latencyWindow must be between 2 and %d checks", maxLatencyWindow)
+	}
+	if threshold <= 0 {
+		return fmt.Errorf("latencyThreshold must be a positive number of milliseconds")
+	}
+	return nil
+}
+
+// validateDegradedThreshold checks a monitor's per-check degraded threshold (0 disables)
+func validateDegradedThreshold(thresholdMs int) error {
+	if thresholdMs < 0 || thresholdMs > MaxTimeoutSeconds*1000 {
+		return fmt.Errorf("degradedThresholdMs must be between 0 and %d milliseconds", MaxTimeoutSeconds*1000)
+	}
+	return nil
+}
+
+// slowCheck reports whether a passing check took longer than the monitor's degraded threshold
+func slowCheck(monitor *Monitor, responseTime int) bool {
+	return monitor.DegradedThresholdMs > 0 && responseTime > monitor.DegradedThresholdMs
+}
+
+// rollingResponseTime averages the response times of the monitor's last window checks that got a response
+// Returns the average in milliseconds and how many checks it covers
+func rollingResponseTime(monitorID uint, window int) (int, int) {
+	var times []int
+	db.Model(&CheckHistory{}).
+		Where("monitor_id = ? AND response_time > 0", monitorID).
+		Order("created_at DESC").Limit(window).
+		Pluck("response_time", &times)
+	if len(times) == 0 {
+		return 0, 0
+	}
+	total := 0
+	for _, responseTime := range times {
+		total += responseTime
+	}
+	return total / len(times), len(times)
+}
+
+// latencyElevated reports whether a passing monitor's rolling average exceeds its threshold
+// The window has to be full, so a single slow check after startup doesn't flag it
+func latencyElevated(monitor *Monitor, average, samples int) bool {
+	return monitor.LatencyWindow > 0 && samples >= monitor.LatencyWindow && average > monitor.LatencyThreshold
+}
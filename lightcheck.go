@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// validateLightCheck checks that a light (HEAD-first) monitor doesn't need the response body
+// Light checks replace GET, so they can't be combined with another method, a request body, or the
+// body assertions and label extraction
+func validateLightCheck(light bool, method, body, keyword, schema, extractLabel string) error {
+	if !light {
+		return nil
+	}
+	if method = strings.ToUpper(strings.TrimSpace(method)); method != "" && method != http.MethodGet {
+		return fmt.Errorf("lightCheck requires method GET")
+	}
+	if body != "" {
+		return fmt.Errorf("lightCheck can't be used with requestBody")
+	}
+	if keyword != "" || schema != "" || strings.HasPrefix(strings.TrimSpace(extractLabel), "json:") {
+		return fmt.Errorf("lightCheck can't be used with expectKeyword, jsonSchema or a json: extractLabel, which need the response body")
+	}
+	return nil
+}
+
+// headRejected reports whether a server refused a light check's HEAD request, so it should be retried with GET
+func headRejected(statusCode int) bool {
+	return statusCode == http.StatusMethodNotAllowed || statusCode == http.StatusNotImplemented
+}
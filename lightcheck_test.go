@@ -0,0 +1,40 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLightCheckFallsBackToGet(t *testing.T) {
+	cases := []struct {
+		headStatus int
+		getStatus  int
+		want       string
+	}{
+		{http.StatusMethodNotAllowed, http.StatusOK, "up"},
+		{http.StatusNotImplemented, http.StatusOK, "up"},
+		{http.StatusMethodNotAllowed, http.StatusInternalServerError, "down"},
+	}
+	for _, tc := range cases {
+		var methods []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.Method)
+			if r.Method == http.MethodHead {
+				w.WriteHeader(tc.headStatus)
+				return
+			}
+			w.WriteHeader(tc.getStatus)
+		}))
+
+		result := checkEndpoint(&Monitor{URL: server.URL, LightCheck: true}, server.URL, nil)
+		server.Close()
+		if result.Status != tc.want || result.StatusCode != tc.getStatus {
+			t.Errorf("HEAD %d, GET %d: status %q with code %d, want %q with %d",
+				tc.headStatus, tc.getStatus, result.Status, result.StatusCode, tc.want, tc.getStatus)
+		}
+		if len(methods) != 2 || methods[0] != http.MethodHead || methods[1] != http.MethodGet {
+			t.Errorf("HEAD %d, GET %d: requests %v, want HEAD then GET", tc.headStatus, tc.getStatus, methods)
+		}
+	}
+}
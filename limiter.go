@@ -0,0 +1,141 @@
+package main
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CheckLimiter bounds the number of health checks running at once and defers
+// non-critical checks while the host is under heavy load
+type CheckLimiter struct {
+	slots         chan struct{} // nil when MAX_CONCURRENT_CHECKS is unset (unlimited)
+	maxGoroutines int           // Goroutine ceiling (0 disables)
+	maxLoad       float64       // 1-minute load average ceiling (0 disables)
+	pollInterval  time.Duration
+	throttled     bool
+	deferred      int64
+	running       atomic.Int64 // Checks holding a slot (counted even when unlimited)
+	waiting       atomic.Int64 // Goroutines parked in waitForCapacity, excluded from the goroutine ceiling
+	mu            sync.Mutex
+}
+
+var checkLimiter *CheckLimiter
+
+// initCheckLimiter configures the check limiter from the environment
+func initCheckLimiter() {
+	maxConcurrent := getEnvInt("MAX_CONCURRENT_CHECKS", 0)
+	if maxConcurrent < 0 {
+		maxConcurrent = 0
+	}
+
+	checkLimiter = &CheckLimiter{
+		maxGoroutines: getEnvInt("LOAD_MAX_GOROUTINES", 0),
+		maxLoad:       getEnvFloat("LOAD_MAX_LOAD1", 0),
+		pollInterval:  time.Second,
+	}
+	if maxConcurrent > 0 {
+		checkLimiter.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	if checkLimiter.maxGoroutines > 0 || checkLimiter.maxLoad > 0 {
+		log.Info().Int("max_concurrent", maxConcurrent).
+			Int("max_goroutines", checkLimiter.maxGoroutines).
+			Float64("max_load1", checkLimiter.maxLoad).
+			Msg("[Limiter] Load safety valve enabled")
+	}
+}
+
+// acquire blocks until a check slot is free and returns a function that releases it
+// Non-critical checks additionally wait while the host is over its load ceiling,
+// so they are deferred rather than dropped
+func (l *CheckLimiter) acquire(monitorID uint, critical bool) func() {
+	if !critical {
+		l.waitForCapacity(monitorID)
+	}
+
+	if l.slots != nil {
+		l.slots <- struct{}{}
+	}
+	l.running.Add(1)
+	return func() {
+		l.running.Add(-1)
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+}
+
+// waitForCapacity blocks until the current load is below the configured ceilings
+func (l *CheckLimiter) waitForCapacity(monitorID uint) {
+	waited := false
+	for {
+		overloaded, reason := l.overloaded()
+		if !overloaded {
+			break
+		}
+
+		if !waited {
+			waited = true
+			l.waiting.Add(1)
+			defer l.waiting.Add(-1)
+			l.mu.Lock()
+			l.deferred++
+			if !l.throttled {
+				l.throttled = true
+				log.Warn().Str("reason", reason).Msg("[Limiter] High system load - deferring non-critical checks")
+			}
+			l.mu.Unlock()
+			log.Debug().Uint("monitor_id", monitorID).Str("reason", reason).Msg("[Limiter] Deferring check")
+		}
+		time.Sleep(l.pollInterval)
+	}
+
+	l.mu.Lock()
+	if l.throttled {
+		l.throttled = false
+		log.Info().Int64("deferred", l.deferred).Msg("[Limiter] Load subsided - resuming deferred checks")
+		l.deferred = 0
+	}
+	l.mu.Unlock()
+}
+
+// overloaded reports whether either load ceiling is currently exceeded
+func (l *CheckLimiter) overloaded() (bool, string) {
+	if l.maxGoroutines > 0 {
+		// Deferred checks are themselves goroutines; counting them would keep the ceiling exceeded
+		// for as long as they wait
+		if count := runtime.NumGoroutine() - int(l.waiting.Load()); count > l.maxGoroutines {
+			return true, "goroutines=" + strconv.Itoa(count)
+		}
+	}
+	if l.maxLoad > 0 {
+		if load, ok := readLoadAverage(); ok && load > l.maxLoad {
+			return true, "load1=" + strconv.FormatFloat(load, 'f', 2, 64)
+		}
+	}
+	return false, ""
+}
+
+// readLoadAverage returns the 1-minute load average from /proc/loadavg (Linux only)
+func readLoadAverage() (float64, bool) {
+	data, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0, false
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, false
+	}
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, false
+	}
+	return load, true
+}
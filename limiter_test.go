@@ -0,0 +1,51 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestOverloadedExcludesDeferredChecks(t *testing.T) {
+	l := &CheckLimiter{maxGoroutines: runtime.NumGoroutine() + 2}
+
+	// Stand-ins for checks parked in waitForCapacity
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-release
+		}()
+	}
+	defer func() {
+		close(release)
+		wg.Wait()
+	}()
+
+	if overloaded, _ := l.overloaded(); !overloaded {
+		t.Fatal("limiter not overloaded above the goroutine ceiling")
+	}
+	l.waiting.Add(5)
+	if overloaded, reason := l.overloaded(); overloaded {
+		t.Errorf("deferred checks kept the limiter overloaded (%s)", reason)
+	}
+}
+
+func TestAcquireUnlimited(t *testing.T) {
+	l := &CheckLimiter{}
+	releases := make([]func(), 0, 20)
+	for i := range 20 {
+		releases = append(releases, l.acquire(uint(i), true))
+	}
+	if got := l.running.Load(); got != 20 {
+		t.Errorf("running = %d, want 20", got)
+	}
+	for _, release := range releases {
+		release()
+	}
+	if got := l.running.Load(); got != 0 {
+		t.Errorf("running = %d after release, want 0", got)
+	}
+}
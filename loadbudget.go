@@ -0,0 +1,146 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"runtime"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// loadBudgetHysteresis is how much the demanded rate must move before the interval scale is
+// recomputed, so small changes don't reschedule every job
+const loadBudgetHysteresis = 0.05
+
+// LoadBudget relaxes check intervals proportionally when the monitors together would exceed a
+// global checks-per-second budget (CHECK_RATE_BUDGET, opt-in)
+type LoadBudget struct {
+	budget       float64 // Checks per second (0 disables)
+	demandedRate float64 // Checks per second the monitors' own intervals ask for
+	scale        float64 // Factor applied to every interval (1 = unchanged)
+	mu           sync.RWMutex
+}
+
+var loadBudget = &LoadBudget{scale: 1}
+
+// initLoadBudget configures the global check rate budget from CHECK_RATE_BUDGET
+func initLoadBudget() {
+	budget := getEnvFloat("CHECK_RATE_BUDGET", 0)
+	if budget < 0 || math.IsNaN(budget) {
+		log.Warn().Float64("budget", budget).Msg("[Config] Invalid CHECK_RATE_BUDGET, disabling the check rate budget")
+		budget = 0
+	}
+	loadBudget.budget = budget
+	if budget > 0 {
+		log.Info().Float64("checks_per_second", budget).Msg("[Scheduler] Check rate budget enabled")
+	}
+}
+
+// update recomputes the interval scale from the unpaused monitors' own intervals
+func (b *LoadBudget) update(monitors []Monitor) {
+	if b.budget <= 0 {
+		return
+	}
+
+	demanded := 0.0
+	for i := range monitors {
+		if !monitors[i].Paused {
+			demanded += 1 / float64(monitors[i].scheduledInterval())
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.demandedRate > 0 && math.Abs(demanded-b.demandedRate)/b.demandedRate < loadBudgetHysteresis {
+		return
+	}
+	b.demandedRate = demanded
+	scale := max(demanded/b.budget, 1)
+	if scale != b.scale {
+		log.Info().Float64("demanded_rate", demanded).Float64("budget", b.budget).Float64("scale", scale).
+			Msg("[Scheduler] Adjusting intervals to the check rate budget")
+	}
+	b.scale = scale
+}
+
+// apply stretches a monitor's interval by the current scale; intervals are only ever lengthened,
+// and stop at the largest supported interval
+func (b *LoadBudget) apply(interval int) int {
+	b.mu.RLock()
+	scale := b.scale
+	b.mu.RUnlock()
+	if scale <= 1 {
+		return interval
+	}
+	return max(interval, min(int(math.Ceil(float64(interval)*scale)), MaxCheckInterval))
+}
+
+// effectiveRate returns the checks per second the scheduled jobs add up to
+func (ms *MonitorScheduler) effectiveRate() (float64, int) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	rate := 0.0
+	for _, interval := range ms.intervals {
+		if interval > 0 {
+			rate += 1 / float64(interval)
+		}
+	}
+	return rate, len(ms.intervals)
+}
+
+// AdminMetricsResponse reports scheduler and check load
+type AdminMetricsResponse struct {
+	ScheduledJobs       int     `json:"scheduledJobs"`
+	CheckRateBudget     float64 `json:"checkRateBudget"`     // Checks per second (0 = disabled)
+	DemandedCheckRate   float64 `json:"demandedCheckRate"`   // Checks per second the monitors' own intervals ask for (while the budget is enabled)
+	EffectiveCheckRate  float64 `json:"effectiveCheckRate"`  // Checks per second the scheduled jobs add up to
+	IntervalScale       float64 `json:"intervalScale"`       // Factor the budget stretches intervals by (1 = unchanged)
+	MaxConcurrentChecks int     `json:"maxConcurrentChecks"` // 0 when unlimited
+	RunningChecks       int     `json:"runningChecks"`
+	Throttled           bool    `json:"throttled"`      // The load safety valve is deferring non-critical checks
+	DeferredChecks      int64   `json:"deferredChecks"` // Checks deferred while throttled
+	Goroutines          int     `json:"goroutines"`
+}
+
+// apiAdminMetrics handles GET requests for scheduler and check load metrics
+func apiAdminMetrics(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !requireAPIKey(w, r) {
+		return
+	}
+
+	effectiveRate, jobs := monitorScheduler.effectiveRate()
+	checkLimiter.mu.Lock()
+	throttled, deferred := checkLimiter.throttled, checkLimiter.deferred
+	checkLimiter.mu.Unlock()
+
+	loadBudget.mu.RLock()
+	metrics := AdminMetricsResponse{
+		ScheduledJobs:       jobs,
+		CheckRateBudget:     loadBudget.budget,
+		DemandedCheckRate:   loadBudget.demandedRate,
+		EffectiveCheckRate:  effectiveRate,
+		IntervalScale:       loadBudget.scale,
+		MaxConcurrentChecks: cap(checkLimiter.slots),
+		RunningChecks:       int(checkLimiter.running.Load()),
+		Throttled:           throttled,
+		DeferredChecks:      deferred,
+		Goroutines:          runtime.NumGoroutine(),
+	}
+	loadBudget.mu.RUnlock()
+
+	log.Info().Float64("effective_rate", effectiveRate).Int("jobs", jobs).Msg("[API] GET /api/admin/metrics")
+	if err := encodeJSONWithCompression(w, r, metrics); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding admin metrics")
+	}
+}
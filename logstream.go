@@ -0,0 +1,93 @@
+package main
+
+import (
+	"sync"
+)
+
+// LogStream keeps the most recent log lines in memory and fans new lines out to
+// admin log subscribers; it is installed as an extra zerolog writer
+// It must never log itself - that would feed its own output back into the stream
+type LogStream struct {
+	buffer      [][]byte // Ring buffer of recent JSON log lines
+	next        int
+	full        bool
+	subscribers map[chan []byte]struct{}
+	maxSubs     int
+	mu          sync.Mutex
+}
+
+var logStream = newLogStream(200, 5)
+
+func newLogStream(bufferSize, maxSubscribers int) *LogStream {
+	return &LogStream{
+		buffer:      make([][]byte, bufferSize),
+		subscribers: make(map[chan []byte]struct{}),
+		maxSubs:     maxSubscribers,
+	}
+}
+
+// configure applies LOG_STREAM_BUFFER and LOG_STREAM_MAX_CLIENTS, discarding buffered lines
+func (s *LogStream) configure(bufferSize, maxSubscribers int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if bufferSize > 0 {
+		s.buffer = make([][]byte, bufferSize)
+		s.next = 0
+		s.full = false
+	}
+	if maxSubscribers > 0 {
+		s.maxSubs = maxSubscribers
+	}
+}
+
+// Write receives one JSON-encoded log event from zerolog
+func (s *LogStream) Write(p []byte) (int, error) {
+	// zerolog reuses its buffers, so keep a copy
+	line := make([]byte, len(p))
+	copy(line, p)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buffer[s.next] = line
+	s.next = (s.next + 1) % len(s.buffer)
+	if s.next == 0 {
+		s.full = true
+	}
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- line:
+		default:
+			// Slow subscriber - drop the line rather than blocking logging
+		}
+	}
+	return len(p), nil
+}
+
+// subscribe registers a subscriber, returning the buffered backlog and a channel of new lines
+// Returns ok=false when the subscriber limit is reached
+func (s *LogStream) subscribe() (backlog [][]byte, ch chan []byte, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.subscribers) >= s.maxSubs {
+		return nil, nil, false
+	}
+
+	if s.full {
+		backlog = append(backlog, s.buffer[s.next:]...)
+	}
+	backlog = append(backlog, s.buffer[:s.next]...)
+
+	ch = make(chan []byte, 256)
+	s.subscribers[ch] = struct{}{}
+	return backlog, ch, true
+}
+
+// unsubscribe removes a subscriber
+func (s *LogStream) unsubscribe(ch chan []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscribers, ch)
+}
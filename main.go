@@ -2,6 +2,7 @@ package main
 
 import (
 	"embed"
+	"io"
 	"io/fs"
 	"net/http"
 	"os"
@@ -19,15 +20,23 @@ var staticFiles embed.FS
 var db *gorm.DB
 
 func init() {
+	// Load .env before anything reads configuration from the environment
+	envFile, envErr := loadDotEnv()
+
 	// Configure zerolog for console output with colors
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	
 	// Use console writer for pretty output in development
 	// In production, you can set ZEROLOG_LOG_LEVEL env var to control log level
+	var output io.Writer = os.Stderr
 	if os.Getenv("ZEROLOG_LOG_LEVEL") == "" {
-		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339})
+		output = zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
 	}
+
+	// Also keep recent log lines in memory for the admin log stream
+	logStream.configure(getEnvInt("LOG_STREAM_BUFFER", 200), getEnvInt("LOG_STREAM_MAX_CLIENTS", 5))
+	log.Logger = log.Output(zerolog.MultiLevelWriter(output, logStream))
 	
 	// Set log level from environment variable if provided
 	if level := os.Getenv("ZEROLOG_LOG_LEVEL"); level != "" {
@@ -42,26 +51,87 @@ func init() {
 			zerolog.SetGlobalLevel(zerolog.ErrorLevel)
 		}
 	}
+
+	if envErr != nil {
+		log.Warn().Err(envErr).Msg("[Config] Failed to load .env file")
+	} else if envFile != "" {
+		log.Info().Str("path", envFile).Msg("[Config] Loaded environment from file")
+	}
 }
 
 func main() {
+	// Configure limits, the scheduler and policies from the environment (before any checks or requests can run)
+	initCheckLimiter()
+	initHeaderCapture()
+	initMonitorScheduler()
+	initLoadBudget()
+	initUptimePolicy()
+	initTrustedProxies()
+	initSchemaDir()
+	initRateLimiter()
+	initNamePolicy()
+	initCertificatePolicy()
+	initDisplayTimezone()
+	initNotifiers()
+
 	// Initialize database
 	initDB()
+	loadNotificationSilence()
 
 	// Start background checker
 	startChecker()
 	
 	// Start cleanup scheduler (runs daily at midnight)
-	go startCleanupScheduler()
+	go startCleanupScheduler(loadRetentionSettings())
+
+	// Start syncing monitors from a remote source (if MONITORS_SOURCE_URL is set)
+	startRemoteConfigSync()
 
 	// API routes
 	http.HandleFunc("/api/monitors", apiMonitors)
 	http.HandleFunc("/api/monitors/create", apiCreateMonitor)
+	http.HandleFunc("/api/monitors/search", apiSearchMonitors)
+	http.HandleFunc("/api/monitors/bulk", apiBulkCreateMonitors)
+	http.HandleFunc("/api/monitors/upsert", apiUpsertMonitor)
+	http.HandleFunc("/api/monitors/bulk-tag", apiBulkTagMonitors)
 	http.HandleFunc("/api/monitors/export", apiExportMonitors)
+	http.HandleFunc("/api/monitors/import", apiImportMonitors)
 	http.HandleFunc("/api/stats", apiStats)
+	http.HandleFunc("/api/stats/history", apiStatsHistory)
+	http.HandleFunc("/api/stats/sla", apiFleetSLA)
+	http.HandleFunc("/api/stats/percentiles", apiFleetPercentiles)
 	http.HandleFunc("/api/response-time", apiResponseTime)
 	http.HandleFunc("/api/monitor", apiMonitor)
+	http.HandleFunc("/api/monitor/downtime", apiMonitorDowntime)
+	http.HandleFunc("/api/monitor/failures", apiMonitorFailures)
+	http.HandleFunc("/api/monitor/notification-preview", apiNotificationPreview)
+	http.HandleFunc("/api/monitor/versions", apiMonitorVersions)
+	http.HandleFunc("/api/monitor/streak", apiMonitorStreak)
+	http.HandleFunc("/api/monitor/uptime", apiMonitorUptime)
+	http.HandleFunc("/api/monitor/incidents", apiMonitorIncidents)
+	http.HandleFunc("/api/heartbeat/", apiHeartbeat)
+	http.HandleFunc("/api/monitor/heartbeat-token", apiMonitorHeartbeatToken)
+	http.HandleFunc("/api/monitor/history/export", apiExportMonitorHistory)
+	http.HandleFunc("/api/monitor/maintenance", apiMonitorMaintenance)
+	http.HandleFunc("/api/monitor/validator-changes", apiMonitorValidatorChanges)
+	http.HandleFunc("/api/incidents", apiIncidents)
+	http.HandleFunc("/api/incidents/annotations", apiIncidentAnnotations)
+	http.HandleFunc("/api/notifications/silence", apiNotificationSilence)
 	http.HandleFunc("/api/events", apiSSE)
+	http.HandleFunc("/api/config/defaults", apiConfigDefaults)
+	http.HandleFunc("/api/admin/config/raw", apiAdminConfigRaw)
+	http.HandleFunc("/api/admin/logs", apiAdminLogs)
+	http.HandleFunc("/api/admin/metrics", apiAdminMetrics)
+	http.HandleFunc("/api/admin/due", apiAdminDue)
+	http.HandleFunc("/api/admin/explain", apiAdminExplain)
+	http.HandleFunc("/api/admin/export/full", apiAdminExportFull)
+	http.HandleFunc("/api/admin/import/full", apiAdminImportFull)
+	http.HandleFunc("/api/admin/optimize", apiAdminOptimize)
+	http.HandleFunc("/api/admin/db", apiAdminDB)
+	http.HandleFunc("/api/admin/monitor/precheck", apiAdminMonitorPreCheck)
+
+	// Liveness/readiness probe for NanoStatus itself, outside /api and the SPA
+	http.HandleFunc("/healthz", healthz)
 
 	// Serve static files
 	staticFS, err := fs.Sub(staticFiles, "dist")
@@ -69,6 +139,9 @@ func main() {
 		log.Fatal().Err(err).Msg("Failed to create sub filesystem")
 	}
 
+	// Optionally verify the embedded assets haven't been tampered with
+	verifyStaticFiles(staticFS)
+
 	fileServer := http.FileServer(http.FS(staticFS))
 
 	// Handle SPA routing - serve index.html for all non-API routes
@@ -107,14 +180,52 @@ func main() {
 
 	log.Info().Str("port", port).Msg("🚀 Server starting")
 	log.Info().Msg("📊 API endpoints:")
-	log.Info().Msg("   GET /api/monitors - List all monitors")
+	log.Info().Msg("   GET /api/monitors?group=<group>&tag=<tag>&limit=<n>&offset=<n>&sort=<fields> - List all monitors, optionally filtered, paginated and sorted")
 	log.Info().Msg("   POST /api/monitors/create - Create a new monitor")
+	log.Info().Msg("   GET /api/monitors/search?q=<text> - Find monitors by name or URL")
+	log.Info().Msg("   POST /api/monitors/bulk - Create many monitors from a JSON array")
+	log.Info().Msg("   PUT /api/monitors/upsert - Create or update a monitor by name and URL")
+	log.Info().Msg("   POST /api/monitors/bulk-tag - Add or remove tags on many monitors")
 	log.Info().Msg("   GET /api/monitors/export - Export monitors as YAML")
-	log.Info().Msg("   GET /api/stats - Get overall statistics")
+	log.Info().Msg("   POST /api/monitors/import - Import monitors from YAML or JSON")
+	log.Info().Msg("   GET /api/stats?group=<group> - Get overall statistics, optionally for one group")
+	log.Info().Msg("   GET /api/stats/history?range=<days>d - Get periodic snapshots of the overall statistics")
+	log.Info().Msg("   GET /api/stats/sla?range=<days>d - Get fleet-wide availability and SLA target compliance")
+	log.Info().Msg("   GET /api/stats/percentiles?range=<range> - Get fleet-wide p50/p95/p99 response times")
 	log.Info().Msg("   GET /api/response-time?id=<id>&range=<range> - Get response time data")
 	log.Info().Msg("   GET /api/monitor?id=<id> - Get specific monitor")
 	log.Info().Msg("   PUT /api/monitor?id=<id> - Update monitor")
 	log.Info().Msg("   DELETE /api/monitor?id=<id> - Delete a monitor")
-	log.Info().Msg("   GET /api/events - Server-Sent Events stream")
-	log.Fatal().Err(http.ListenAndServe(port, nil)).Msg("Server failed")
+	log.Info().Msg("   GET /api/monitor/downtime?id=<id>&range=<days>d - Get per-day downtime minutes")
+	log.Info().Msg("   GET /api/monitor/failures?id=<id>&limit=<n> - Get recent failed checks")
+	log.Info().Msg("   GET /api/monitor/notification-preview?id=<id>&event=down - Preview the alert a monitor event would send")
+	log.Info().Msg("   GET /api/monitor/versions?id=<id> - Get version change history")
+	log.Info().Msg("   GET /api/monitor/streak?id=<id> - Get how long a monitor has had its current status")
+	log.Info().Msg("   GET /api/monitor/uptime?id=<id> - Get a monitor's uptime over 24h, 7d, 30d and 90d")
+	log.Info().Msg("   GET /api/monitor/incidents?id=<id> - Get a monitor's recent incidents")
+	log.Info().Msg("   POST /api/heartbeat/<token> - Report a heartbeat for a push monitor")
+	log.Info().Msg("   POST /api/monitor/heartbeat-token?id=<id>&rotate=true - Get or rotate a push monitor's heartbeat token")
+	log.Info().Msg("   GET /api/monitor/history/export?id=<id>&range=<range> - Download a monitor's check history as CSV")
+	log.Info().Msg("   GET/POST/PUT/DELETE /api/monitor/maintenance?id=<id>&window=<window> - Manage a monitor's maintenance windows")
+	log.Info().Msg("   GET /api/monitor/validator-changes?id=<id> - Get ETag/Last-Modified change history")
+	log.Info().Msg("   GET /api/incidents - Get all open incidents")
+	log.Info().Msg("   GET /api/incidents/annotations?range=<range> - Get outages as Grafana annotations")
+	log.Info().Msg("   GET/POST/DELETE /api/notifications/silence?minutes=<n> - Inspect, set or clear the global notification silence")
+	log.Info().Msg("   GET /api/events?monitorId=<id> - Server-Sent Events stream, optionally for one monitor")
+	log.Info().Msg("   GET /api/config/defaults - Get monitor defaults and validation bounds")
+	log.Info().Msg("   GET /api/admin/config/raw - Get the on-disk monitors.yaml (requires API key)")
+	log.Info().Msg("   GET /api/admin/logs - Stream server logs over SSE (requires API key)")
+	log.Info().Msg("   GET /api/admin/metrics - Get scheduler and check load metrics (requires API key)")
+	log.Info().Msg("   GET /api/admin/due?limit=<n> - List scheduled monitors by next check time (requires API key)")
+	log.Info().Msg("   GET /api/admin/explain - Get the query plans of the key queries (requires API key)")
+	log.Info().Msg("   GET /api/admin/export/full - Download a full JSON backup of the database (requires API key)")
+	log.Info().Msg("   POST /api/admin/import/full?mode=merge|replace - Restore a full JSON backup (requires API key)")
+	log.Info().Msg("   POST /api/admin/optimize?vacuum=true - Optimize and optionally vacuum the database (requires API key)")
+	log.Info().Msg("   GET /api/admin/db - Get database connectivity, pool statistics and row counts (requires API key)")
+	log.Info().Msg("   GET /api/admin/monitor/precheck?id=<id> - Get a monitor's pre-check body and inject header (requires API key)")
+	log.Info().Msg("   GET /healthz - Liveness/readiness probe for NanoStatus itself")
+	if os.Getenv("API_KEY") != "" {
+		log.Info().Msg("[API] API_KEY is set: non-GET /api requests require the key")
+	}
+	log.Fatal().Err(http.ListenAndServe(port, rateLimitWrites(requireAPIKeyForWrites(http.DefaultServeMux)))).Msg("Server failed")
 }
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Maintenance window recurrences
+const (
+	RecurrenceNone   = ""       // The window happens once
+	RecurrenceDaily  = "daily"  // Repeats every 24 hours from StartsAt
+	RecurrenceWeekly = "weekly" // Repeats every 7 days from StartsAt
+)
+
+// MaintenanceWindowRequest is the request body for creating or updating a maintenance window
+type MaintenanceWindowRequest struct {
+	StartsAt    time.Time `json:"startsAt"` // ISO 8601
+	EndsAt      time.Time `json:"endsAt"`   // ISO 8601
+	Recurrence  string    `json:"recurrence,omitempty"`
+	Description string    `json:"description,omitempty"`
+}
+
+// MaintenanceResponse lists a monitor's maintenance windows and whether one is active
+type MaintenanceResponse struct {
+	MonitorID     uint                `json:"monitorId"`
+	InMaintenance bool                `json:"inMaintenance"`
+	Until         *string             `json:"until,omitempty"` // ISO 8601 end of the active window
+	Windows       []MaintenanceWindow `json:"windows"`
+}
+
+// recurrencePeriod returns how often the window repeats (0 if it happens once)
+func (w *MaintenanceWindow) recurrencePeriod() time.Duration {
+	switch w.Recurrence {
+	case RecurrenceDaily:
+		return 24 * time.Hour
+	case RecurrenceWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// activeUntil reports whether the window covers now, and when the current occurrence ends
+func (w *MaintenanceWindow) activeUntil(now time.Time) (time.Time, bool) {
+	if now.Before(w.StartsAt) {
+		return time.Time{}, false
+	}
+	period := w.recurrencePeriod()
+	if period == 0 {
+		return w.EndsAt, now.Before(w.EndsAt)
+	}
+	duration := w.EndsAt.Sub(w.StartsAt)
+	offset := now.Sub(w.StartsAt) % period
+	if offset >= duration {
+		return time.Time{}, false
+	}
+	return now.Add(duration - offset), true
+}
+
+// validateMaintenanceWindow checks a window's times and recurrence; a recurring window must be shorter than its period
+func validateMaintenanceWindow(req MaintenanceWindowRequest) error {
+	if req.StartsAt.IsZero() || req.EndsAt.IsZero() {
+		return fmt.Errorf("startsAt and endsAt are required")
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		return fmt.Errorf("endsAt must be after startsAt")
+	}
+	window := MaintenanceWindow{StartsAt: req.StartsAt, EndsAt: req.EndsAt, Recurrence: req.Recurrence}
+	switch req.Recurrence {
+	case RecurrenceNone:
+	case RecurrenceDaily, RecurrenceWeekly:
+		if window.EndsAt.Sub(window.StartsAt) >= window.recurrencePeriod() {
+			return fmt.Errorf("a %s window must be shorter than its period", req.Recurrence)
+		}
+	default:
+		return fmt.Errorf("recurrence must be daily, weekly, or empty")
+	}
+	return nil
+}
+
+// maintenanceUntil reports whether a monitor is in a maintenance window, and when the last active one ends
+func maintenanceUntil(monitorID uint, now time.Time) (time.Time, bool) {
+	var windows []MaintenanceWindow
+	if err := db.Where("monitor_id = ? AND starts_at <= ?", monitorID, now).Find(&windows).Error; err != nil {
+		log.Error().Err(err).Uint("monitor_id", monitorID).Msg("[Maintenance] Failed to load maintenance windows")
+		return time.Time{}, false
+	}
+
+	var until time.Time
+	active := false
+	for i := range windows {
+		if end, ok := windows[i].activeUntil(now); ok {
+			active = true
+			if end.After(until) {
+				until = end
+			}
+		}
+	}
+	return until, active
+}
+
+// isInMaintenance reports whether a monitor is in one of its maintenance windows
+func isInMaintenance(monitorID uint, now time.Time) bool {
+	_, active := maintenanceUntil(monitorID, now)
+	return active
+}
+
+// apiMonitorMaintenance handles GET, POST, PUT, and DELETE requests for a monitor's maintenance windows
+func apiMonitorMaintenance(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	windowID := r.URL.Query().Get("window")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", id).Str("window", windowID).Msg("[API] Request")
+
+	setCORSHeaders(w)
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-API-Key, Authorization")
+
+	if r.Method == http.MethodOptions {
+		log.Debug().Msg("[API] OPTIONS /api/monitor/maintenance: CORS preflight")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	setJSONHeaders(w)
+
+	switch r.Method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete:
+	default:
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id == "" {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR /api/monitor/maintenance: Missing monitor ID")
+		http.Error(w, "Monitor ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var monitor Monitor
+	if err := db.Select("id", "name").First(&monitor, id).Error; err != nil {
+		log.Warn().Str("id", id).Err(err).Str("method", r.Method).Msg("[API] ERROR /api/monitor/maintenance: Monitor not found")
+		http.Error(w, "Monitor not found", http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		windows := []MaintenanceWindow{}
+		if err := db.Where("monitor_id = ?", monitor.ID).Order("starts_at ASC").Find(&windows).Error; err != nil {
+			log.Error().Err(err).Str("id", id).Msg("[API] ERROR GET /api/monitor/maintenance: Failed to load windows")
+			http.Error(w, "Failed to load maintenance windows", http.StatusInternalServerError)
+			return
+		}
+		response := MaintenanceResponse{MonitorID: monitor.ID, Windows: windows}
+		if until, active := maintenanceUntil(monitor.ID, time.Now()); active {
+			formatted := until.UTC().Format(time.RFC3339)
+			response.InMaintenance = true
+			response.Until = &formatted
+		}
+		log.Info().Str("id", id).Int("windows", len(windows)).Bool("in_maintenance", response.InMaintenance).Msg("[API] GET /api/monitor/maintenance")
+		if err := encodeJSONWithCompression(w, r, response); err != nil {
+			log.Error().Err(err).Msg("[API] ERROR encoding maintenance windows")
+		}
+		return
+	}
+
+	var window MaintenanceWindow
+	if r.Method != http.MethodPost {
+		if _, err := strconv.ParseUint(windowID, 10, 32); err != nil {
+			log.Warn().Str("window", windowID).Str("method", r.Method).Msg("[API] ERROR /api/monitor/maintenance: Invalid window ID")
+			http.Error(w, "window must be a maintenance window ID", http.StatusBadRequest)
+			return
+		}
+		if err := db.Where("monitor_id = ?", monitor.ID).First(&window, windowID).Error; err != nil {
+			log.Warn().Str("id", id).Str("window", windowID).Str("method", r.Method).Msg("[API] ERROR /api/monitor/maintenance: Window not found")
+			http.Error(w, "Maintenance window not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	if r.Method == http.MethodDelete {
+		if err := db.Delete(&window).Error; err != nil {
+			log.Error().Err(err).Str("window", windowID).Msg("[API] ERROR DELETE /api/monitor/maintenance: Failed to delete")
+			http.Error(w, "Failed to delete maintenance window", http.StatusInternalServerError)
+			return
+		}
+		log.Info().Str("id", id).Str("window", windowID).Msg("[API] DELETE /api/monitor/maintenance: Deleted maintenance window")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var req MaintenanceWindowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn().Err(err).Str("method", r.Method).Msg("[API] ERROR /api/monitor/maintenance: Invalid request body")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := validateMaintenanceWindow(req); err != nil {
+		log.Warn().Err(err).Str("method", r.Method).Msg("[API] ERROR /api/monitor/maintenance: Invalid maintenance window")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	window.MonitorID = monitor.ID
+	window.StartsAt = req.StartsAt.UTC()
+	window.EndsAt = req.EndsAt.UTC()
+	window.Recurrence = req.Recurrence
+	window.Description = req.Description
+	if err := db.Save(&window).Error; err != nil {
+		log.Error().Err(err).Str("id", id).Str("method", r.Method).Msg("[API] ERROR /api/monitor/maintenance: Failed to save window")
+		http.Error(w, "Failed to save maintenance window", http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	if r.Method == http.MethodPost {
+		status = http.StatusCreated
+	}
+	log.Info().Str("id", id).Uint("window", window.ID).Time("starts_at", window.StartsAt).Time("ends_at", window.EndsAt).
+		Str("recurrence", window.Recurrence).Msgf("[API] %s /api/monitor/maintenance: Saved maintenance window", r.Method)
+	if err := encodeJSONWithStatus(w, r, status, window); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding maintenance window")
+	}
+}
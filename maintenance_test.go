@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMaintenanceWindowActiveUntil(t *testing.T) {
+	start := time.Date(2026, 1, 5, 2, 0, 0, 0, time.UTC)
+	once := MaintenanceWindow{StartsAt: start, EndsAt: start.Add(time.Hour)}
+	daily := MaintenanceWindow{StartsAt: start, EndsAt: start.Add(time.Hour), Recurrence: RecurrenceDaily}
+
+	for _, tc := range []struct {
+		name   string
+		window MaintenanceWindow
+		now    time.Time
+		active bool
+	}{
+		{"before the window", once, start.Add(-time.Minute), false},
+		{"inside the window", once, start.Add(30 * time.Minute), true},
+		{"at the end", once, start.Add(time.Hour), false},
+		{"a day later, once", once, start.Add(24*time.Hour + 30*time.Minute), false},
+		{"a day later, daily", daily, start.Add(24*time.Hour + 30*time.Minute), true},
+		{"between daily occurrences", daily, start.Add(12 * time.Hour), false},
+	} {
+		if _, active := tc.window.activeUntil(tc.now); active != tc.active {
+			t.Errorf("%s: active = %v, want %v", tc.name, active, tc.active)
+		}
+	}
+}
+
+func TestUptimeExcludesChecksInMaintenance(t *testing.T) {
+	db := newTestDB(t)
+
+	monitor := Monitor{Name: "maintained", URL: "https://example.com", Status: "up"}
+	if err := db.Create(&monitor).Error; err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC()
+	window := MaintenanceWindow{MonitorID: monitor.ID, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}
+	if err := db.Create(&window).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if !isInMaintenance(monitor.ID, now) {
+		t.Error("monitor not in maintenance inside its window")
+	}
+	if isInMaintenance(monitor.ID, now.Add(2*time.Hour)) {
+		t.Error("monitor in maintenance after its window ended")
+	}
+
+	// Three passing checks before the window, two failing ones inside it
+	history := []CheckHistory{
+		{MonitorID: monitor.ID, Status: "up", CreatedAt: now.Add(-3 * time.Hour)},
+		{MonitorID: monitor.ID, Status: "up", CreatedAt: now.Add(-150 * time.Minute)},
+		{MonitorID: monitor.ID, Status: "up", CreatedAt: now.Add(-2 * time.Hour)},
+		{MonitorID: monitor.ID, Status: "down", CreatedAt: now.Add(-30 * time.Minute), InMaintenance: true},
+		{MonitorID: monitor.ID, Status: "down", CreatedAt: now.Add(-10 * time.Minute), InMaintenance: true},
+	}
+	if err := db.Create(&history).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var stats struct {
+		TotalChecks   int64
+		UptimePercent float64
+	}
+	if err := db.Raw("SELECT total_checks, uptime_percent FROM monitor_stats_24h WHERE monitor_id = ?", monitor.ID).Scan(&stats).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalChecks != 3 || stats.UptimePercent != 100 {
+		t.Errorf("monitor_stats_24h = %d checks at %.1f%%, want 3 at 100%%", stats.TotalChecks, stats.UptimePercent)
+	}
+}
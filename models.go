@@ -1,6 +1,23 @@
 package main
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Monitor defaults and validation bounds shared by the API, YAML loader, and checker
+const (
+	DefaultCheckInterval  = 60   // Seconds between checks when none is configured
+	MinCheckInterval      = 10   // Smallest interval accepted for a monitor (seconds)
+	MaxCheckInterval      = 3600 // Largest interval accepted for a monitor (seconds)
+	DefaultTimeoutSeconds = 10   // Check timeout when a monitor sets none
+	MaxTimeoutSeconds     = 300  // Largest per-monitor check timeout (seconds)
+)
+
+// AllowedSchemes lists the URL schemes the checker understands (bare hosts default to https)
+var AllowedSchemes = []string{"http", "https", "ping", "udp", "ws", "wss", "dns"}
 
 // Monitor represents a service being monitored
 type Monitor struct {
@@ -14,9 +31,101 @@ type Monitor struct {
 	IsThirdParty bool      `gorm:"default:false" json:"isThirdParty,omitempty"`
 	Icon         string    `json:"icon,omitempty"`
 	CheckInterval int      `gorm:"default:60" json:"checkInterval"` // Interval in seconds
+	TimeoutSeconds int     `gorm:"default:0" json:"timeoutSeconds,omitempty"` // Check timeout in seconds (0 = DefaultTimeoutSeconds)
 	Paused       bool      `gorm:"default:false;index:idx_paused_status" json:"paused"` // Whether monitoring is paused
-	// Note: Partial index idx_monitors_active on (Status, Uptime) WHERE paused = 0 will be created via raw SQL
+	StatusSince  *time.Time `json:"statusSince,omitempty"` // When Status last changed (start of the current streak)
+	// Note: Partial index idx_monitors_active on (Status, Uptime) WHERE paused = 0 is created via raw SQL in migrateDB
 	ConfigHash   string    `gorm:"index" json:"configHash,omitempty"` // Hash of YAML config (empty if created via UI/API)
+	ConfigSource string    `json:"configSource,omitempty"` // Where a config-managed monitor comes from: yaml, remote or import (empty means yaml)
+	RedirectPolicy string  `json:"redirectPolicy,omitempty"` // How 3xx responses are treated: follow (default), redirect, or down
+	MaxRedirects int       `gorm:"default:0" json:"maxRedirects,omitempty"` // Redirects followed before the check fails (0 = 10)
+	FollowRedirects *bool  `json:"followRedirects,omitempty"` // Follow redirects (nil = true); false judges the first response, so a 301 itself is up
+	StatusOutcomes string  `json:"statusOutcomes,omitempty"` // Overrides for 1xx, 206 and 304 responses, e.g. "206=degraded,304=down"
+	ExpectedStatusCodes string `json:"expectedStatusCodes,omitempty"` // Status codes counted as up, e.g. "200-299,301" (empty = 200-399)
+	LastRedirect string    `json:"lastRedirect,omitempty"` // Location of the last unfollowed redirect
+	MinCacheAge  int       `json:"minCacheAge,omitempty"` // Minimum acceptable Age header in seconds (0 disables)
+	MaxCacheAge  int       `json:"maxCacheAge,omitempty"` // Maximum acceptable Age header in seconds (0 disables)
+	LastCacheAge *int      `json:"lastCacheAge,omitempty"` // Age header from the last check (nil if absent)
+	LastServerDate *time.Time `json:"lastServerDate,omitempty"` // Date header from the last check
+	Tags         StringList `json:"tags,omitempty"` // Labels for organizing monitors, e.g. payments or env:prod
+	Group        string    `gorm:"column:monitor_group;index" json:"group,omitempty"` // Group the monitor is listed under, e.g. staging (the column avoids the SQL keyword)
+	Endpoints    StringList `json:"endpoints,omitempty"` // Additional URLs that must also pass for the monitor to be up
+	ParallelEndpoints bool  `gorm:"default:false" json:"parallelEndpoints,omitempty"` // Check all endpoints concurrently
+	EndpointResults EndpointResultList `json:"endpointResults,omitempty"` // Per-endpoint results from the last check (multi-endpoint monitors only)
+	GracePeriod  int       `gorm:"default:0" json:"gracePeriod,omitempty"` // Seconds after creation/warming during which failures show as pending
+	EscalateAfter int      `gorm:"default:0" json:"escalateAfter,omitempty"` // Seconds a monitor must stay down before alerting (0 = immediately)
+	FailureThreshold int   `gorm:"default:0" json:"failureThreshold,omitempty"` // Consecutive failed checks before the monitor is down (0 or 1 = the first)
+	RetryCount   int       `gorm:"default:0" json:"retryCount,omitempty"` // Immediate retries of a failed check within the same cycle
+	RetryDelayMs int       `gorm:"default:0" json:"retryDelayMs,omitempty"` // Milliseconds before the first retry, doubled for each further one (0 = 500)
+	Type         string    `gorm:"column:monitor_type" json:"type,omitempty"` // Empty polls the URL; push waits for heartbeats on /api/heartbeat/<token>
+	HeartbeatGrace int     `gorm:"default:0" json:"heartbeatGrace,omitempty"` // Seconds a push monitor's heartbeat may be late (0 = 30)
+	LastHeartbeatAt *time.Time `json:"lastHeartbeatAt,omitempty"` // When the last heartbeat of a push monitor arrived
+	HeartbeatToken string  `gorm:"index" json:"-"` // Secret in a push monitor's heartbeat URL; never serialized, since monitor reads are public
+	ConsecutiveFailures int `gorm:"default:0" json:"consecutiveFailures,omitempty"` // Failed checks in a row, reset by any other result
+	GraceStartedAt *time.Time `json:"graceStartedAt,omitempty"` // Start of the current grace period (nil means CreatedAt)
+	StoreHeaders bool      `gorm:"default:false" json:"storeHeaders,omitempty"` // Keep response headers on check history for debugging
+	LightCheck   bool      `gorm:"default:false" json:"lightCheck,omitempty"` // Check with HEAD, falling back to GET when the server rejects HEAD
+	HTTP3        bool      `gorm:"default:false" json:"http3,omitempty"` // Check over HTTP/3 (QUIC) instead of TCP
+	UDPProbe     string    `json:"udpProbe,omitempty"`  // Datagram sent by udp:// checks (text, or hex: prefixed bytes)
+	UDPExpect    string    `json:"udpExpect,omitempty"` // Bytes the UDP reply must contain (empty accepts any reply)
+	WebSocketPing bool     `json:"websocketPing,omitempty"` // ws:// and wss:// checks also send a ping and wait for the pong
+	AdaptiveInterval bool  `gorm:"default:false" json:"adaptiveInterval,omitempty"` // Lengthen the interval while stable, shorten it after failures
+	MinAdaptiveInterval int `json:"minAdaptiveInterval,omitempty"` // Lower bound in seconds (default: a quarter of CheckInterval)
+	MaxAdaptiveInterval int `json:"maxAdaptiveInterval,omitempty"` // Upper bound in seconds (default: four times CheckInterval)
+	EffectiveInterval int  `gorm:"default:0" json:"effectiveInterval,omitempty"` // Current adaptive interval in seconds (0 = CheckInterval)
+	EffectiveIntervalSince *time.Time `json:"effectiveIntervalSince,omitempty"` // When the adaptive interval last changed
+	VersionHeader string   `json:"versionHeader,omitempty"` // Response header carrying the service version (e.g. X-App-Version)
+	CurrentVersion string  `json:"currentVersion,omitempty"` // Last observed value of VersionHeader
+	ExtractLabel string    `json:"extractLabel,omitempty"` // Value shown with the monitor: header:<name> or json:<dot.path> of the response
+	LastLabel    string    `json:"lastLabel,omitempty"` // Value extracted by ExtractLabel in the last check
+	VersionChangedAt *time.Time `json:"versionChangedAt,omitempty"` // When CurrentVersion last changed
+	WatchValidators bool   `json:"watchValidators,omitempty"` // Alert when the ETag or Last-Modified of the response changes
+	LastETag     string    `json:"lastEtag,omitempty"` // Last observed ETag (with WatchValidators)
+	LastModified string    `json:"lastModified,omitempty"` // Last observed Last-Modified (with WatchValidators)
+	ValidatorChangedAt *time.Time `json:"validatorChangedAt,omitempty"` // When LastETag or LastModified last changed
+	WatchCertificate bool  `json:"watchCertificate,omitempty"` // Alert when the leaf TLS certificate's fingerprint changes
+	CertFingerprint string `json:"certFingerprint,omitempty"` // SHA-256 of the last seen leaf certificate (with WatchCertificate)
+	PreviousCertFingerprint string `json:"previousCertFingerprint,omitempty"` // Fingerprint before the last change
+	CertFingerprintChangedAt *time.Time `json:"certFingerprintChangedAt,omitempty"` // When CertFingerprint last changed
+	DependsOn    string    `json:"dependsOn,omitempty"` // Name of a monitor whose outage skips this monitor's checks (reported as skipped)
+	Method       string    `json:"method,omitempty"` // HTTP method checks use: GET, HEAD, POST or PUT (empty = GET, or POST with a RequestBody)
+	RequestBody  string    `gorm:"type:text" json:"requestBody,omitempty"` // Body sent by POST and PUT checks; may use {{.Timestamp}}, {{.Nonce}} and similar placeholders
+	ContentType  string    `json:"contentType,omitempty"` // Content-Type of the request body (empty guesses JSON or form)
+	ExpectKeyword string   `json:"expectKeyword,omitempty"` // Text the response body must contain (first 1MB, case-sensitive)
+	ExpectKeywordAbsent bool `json:"expectKeywordAbsent,omitempty"` // Invert ExpectKeyword: the body must not contain it
+	MaxBodyBytes int64     `gorm:"default:0" json:"maxBodyBytes,omitempty"` // Response body bytes the keyword and schema assertions read (0 = 1MB)
+	JSONSchema   string    `gorm:"type:text" json:"jsonSchema,omitempty"` // JSON Schema (inline JSON or file path) the response body must satisfy
+	SchemaError  string    `json:"schemaError,omitempty"` // Validation error from the last check (empty when the body matched)
+	SLATarget    float64   `gorm:"default:0" json:"slaTarget,omitempty"` // Availability target percentage, e.g. 99.9 (0 = none)
+	SourceIP     string    `json:"sourceIp,omitempty"` // Local address checks originate from (multi-homed hosts; empty uses the default route)
+	DNSServer    string    `json:"dnsServer,omitempty"` // DNS server (IP, optional port) checks resolve names through (empty uses the system resolver)
+	ExpectedIP   string    `json:"expectedIp,omitempty"` // Address a dns:// monitor's hostname must resolve to (empty accepts any)
+	AutoRetireAfter int    `gorm:"default:0" json:"autoRetireAfter,omitempty"` // Seconds of continuous DNS failures or 404/410 before retiring (0 disables)
+	AutoRetireAction string `json:"autoRetireAction,omitempty"` // pause (default) or delete
+	RetireCandidateSince *time.Time `json:"retireCandidateSince,omitempty"` // Start of the current streak of DNS failures or 404/410
+	ResponseTimeMode string `json:"responseTimeMode,omitempty"` // Which measurement drives responseTime: ttfb (default) or total
+	LastTTFB     int       `gorm:"default:0" json:"lastTtfb,omitempty"` // Milliseconds to the first response byte in the last check
+	LastTotalTime int      `gorm:"default:0" json:"lastTotalTime,omitempty"` // Milliseconds until the body was fully read in the last check
+	LatencyWindow int      `gorm:"default:0" json:"latencyWindow,omitempty"` // Number of recent checks averaged for sustained latency (0 disables)
+	LatencyThreshold int   `gorm:"default:0" json:"latencyThreshold,omitempty"` // Milliseconds the rolling average may reach before the monitor is degraded
+	RollingResponseTime int `gorm:"default:0" json:"rollingResponseTime,omitempty"` // Average response time over the latency window (milliseconds)
+	DegradedThresholdMs int `gorm:"default:0" json:"degradedThresholdMs,omitempty"` // Milliseconds a passing check may take before it counts as degraded (0 disables)
+	P50ResponseTime int    `gorm:"-" json:"p50ResponseTime,omitempty"` // Median response time of up checks in the last 24h, only set by GET /api/monitor
+	P90ResponseTime int    `gorm:"-" json:"p90ResponseTime,omitempty"`
+	P99ResponseTime int    `gorm:"-" json:"p99ResponseTime,omitempty"`
+	SampleRate   int       `gorm:"default:0" json:"sampleRate,omitempty"` // Store only 1 in N checks in history (0 or 1 stores every check)
+	StoreHistory *bool     `json:"storeHistory,omitempty"` // Store checks in history at all (nil = true); a pointer since GORM skips false for defaulted columns
+	LastContentEncoding string `json:"lastContentEncoding,omitempty"` // Compression of the last response body (e.g. gzip; empty if uncompressed)
+	LastProtocol string `json:"lastProtocol,omitempty"` // HTTP version negotiated by the last check (HTTP/1.1, HTTP/2.0 or HTTP/3.0)
+	PreCheckURL  string    `json:"preCheckUrl,omitempty"` // Request made before the check to obtain a token (e.g. a login endpoint)
+	PreCheckMethod string  `json:"preCheckMethod,omitempty"` // GET (default, POST with a body), POST, or PUT
+	PreCheckBody string    `gorm:"type:text" json:"-"` // Pre-check request body (JSON bodies are sent as application/json); holds credentials, so never serialized
+	PreCheckExtract string `json:"preCheckExtract,omitempty"` // Where the token comes from: header:<name> or json:<dot.path>
+	PreCheckInject string  `json:"-"` // Header carrying the token on the check (default "Authorization: Bearer {token}"); never serialized
+	PreCheckTTL  int       `gorm:"default:0" json:"preCheckTtl,omitempty"` // Seconds to reuse the token (default 300, shortened by a JSON expires_in)
+	Certificate  *CertificateInfo `json:"certificate,omitempty"` // Leaf certificate from the last HTTPS check, with trust, hostname and expiry warnings
+	LastError    string    `json:"lastError,omitempty"` // Request error of the last check, prefixed with its category (cleared on success)
+	LastErrorCategory string `json:"lastErrorCategory,omitempty"` // Category of LastError: timeout, dns, connection_refused, connection_reset, eof, tls, too_many_redirects, status_code, websocket, keyword, precheck, config or error
 	CreatedAt    time.Time `json:"createdAt"`
 	UpdatedAt    time.Time `json:"updatedAt"`
 }
@@ -28,14 +137,76 @@ type CreateMonitorRequest struct {
 	IsThirdParty bool   `json:"isThirdParty,omitempty"`
 	Icon         string `json:"icon,omitempty"`
 	CheckInterval int   `json:"checkInterval,omitempty"` // Interval in seconds (default: 60)
+	TimeoutSeconds int  `json:"timeoutSeconds,omitempty"` // Check timeout in seconds (default: 10)
+	RedirectPolicy string `json:"redirectPolicy,omitempty"` // follow (default), redirect, or down
+	MaxRedirects int     `json:"maxRedirects,omitempty"`   // Redirects followed before the check fails (0 = 10)
+	FollowRedirects *bool `json:"followRedirects,omitempty"` // Follow redirects (default true)
+	StatusOutcomes string `json:"statusOutcomes,omitempty"` // Outcomes (up, down, degraded) for 1xx, 206 and 304 responses
+	ExpectedStatusCodes string `json:"expectedStatusCodes,omitempty"` // Status codes and ranges counted as up, e.g. "200-299,301"
+	MinCacheAge  int    `json:"minCacheAge,omitempty"`  // Minimum acceptable Age header in seconds
+	MaxCacheAge  int    `json:"maxCacheAge,omitempty"`  // Maximum acceptable Age header in seconds
+	Tags         []string `json:"tags,omitempty"`     // Labels for organizing monitors
+	Group        string `json:"group,omitempty"`      // Group the monitor is listed under
+	Endpoints    []string `json:"endpoints,omitempty"` // Additional URLs that must also pass
+	ParallelEndpoints bool `json:"parallelEndpoints,omitempty"` // Check all endpoints concurrently
+	GracePeriod  int    `json:"gracePeriod,omitempty"` // Seconds after creation during which failures show as pending
+	EscalateAfter int   `json:"escalateAfter,omitempty"` // Seconds a monitor must stay down before alerting
+	FailureThreshold int `json:"failureThreshold,omitempty"` // Consecutive failed checks before the monitor is down
+	RetryCount   int    `json:"retryCount,omitempty"`   // Immediate retries of a failed check within the same cycle
+	RetryDelayMs int    `json:"retryDelayMs,omitempty"` // Milliseconds before the first retry (default 500)
+	Type         string `json:"type,omitempty"`           // Empty (poll the URL) or push
+	HeartbeatGrace int  `json:"heartbeatGrace,omitempty"` // Seconds a push monitor's heartbeat may be late (default 30)
+	StoreHeaders bool   `json:"storeHeaders,omitempty"` // Keep response headers on check history for debugging
+	LightCheck   bool   `json:"lightCheck,omitempty"`   // Check with HEAD, falling back to GET on 405/501
+	HTTP3        bool   `json:"http3,omitempty"`        // Check over HTTP/3 (QUIC)
+	UDPProbe     string `json:"udpProbe,omitempty"`     // Datagram sent by udp:// checks
+	UDPExpect    string `json:"udpExpect,omitempty"`    // Bytes the UDP reply must contain
+	WebSocketPing bool  `json:"websocketPing,omitempty"` // WebSocket checks also wait for a pong
+	AdaptiveInterval bool `json:"adaptiveInterval,omitempty"` // Adjust the interval based on stability
+	MinAdaptiveInterval int `json:"minAdaptiveInterval,omitempty"` // Lower bound in seconds
+	MaxAdaptiveInterval int `json:"maxAdaptiveInterval,omitempty"` // Upper bound in seconds
+	VersionHeader string `json:"versionHeader,omitempty"` // Response header carrying the service version
+	ExtractLabel string `json:"extractLabel,omitempty"` // header:<name> or json:<dot.path>
+	WatchValidators bool `json:"watchValidators,omitempty"` // Alert when the ETag or Last-Modified changes
+	WatchCertificate bool `json:"watchCertificate,omitempty"` // Alert when the leaf TLS certificate changes
+	DependsOn    string `json:"dependsOn,omitempty"`    // Name of the monitor this one depends on
+	Method       string `json:"method,omitempty"`       // GET (default), HEAD, POST or PUT
+	RequestBody  string `json:"requestBody,omitempty"`  // Body sent by POST and PUT checks (a text/template rendered per request)
+	ContentType  string `json:"contentType,omitempty"`  // Content-Type of the request body
+	ExpectKeyword string `json:"expectKeyword,omitempty"` // Text the response body must contain
+	ExpectKeywordAbsent bool `json:"expectKeywordAbsent,omitempty"` // The body must not contain ExpectKeyword instead
+	MaxBodyBytes int64  `json:"maxBodyBytes,omitempty"` // Response body bytes the body assertions read (0 = 1MB)
+	JSONSchema   string `json:"jsonSchema,omitempty"`   // JSON Schema (inline JSON or file path) the response body must satisfy
+	SLATarget    float64 `json:"slaTarget,omitempty"`   // Availability target percentage
+	SourceIP     string `json:"sourceIp,omitempty"`     // Local address checks originate from
+	DNSServer    string `json:"dnsServer,omitempty"`    // DNS server checks resolve names through
+	ExpectedIP   string `json:"expectedIp,omitempty"`   // Address a dns:// monitor must resolve to
+	AutoRetireAfter int  `json:"autoRetireAfter,omitempty"` // Seconds of continuous DNS failures or 404/410 before retiring
+	AutoRetireAction string `json:"autoRetireAction,omitempty"` // pause (default) or delete
+	ResponseTimeMode string `json:"responseTimeMode,omitempty"` // ttfb (default) or total
+	LatencyWindow int    `json:"latencyWindow,omitempty"`    // Number of recent checks averaged for sustained latency
+	LatencyThreshold int `json:"latencyThreshold,omitempty"` // Milliseconds the rolling average may reach
+	DegradedThresholdMs int `json:"degradedThresholdMs,omitempty"` // Milliseconds a passing check may take before it's degraded
+	SampleRate   int    `json:"sampleRate,omitempty"`   // Store only 1 in N checks in history
+	StoreHistory *bool  `json:"storeHistory,omitempty"` // Store checks in history at all (default true)
+	PreCheckURL  string `json:"preCheckUrl,omitempty"`  // Request made before the check to obtain a token
+	PreCheckMethod string `json:"preCheckMethod,omitempty"` // GET, POST, or PUT
+	PreCheckBody string `json:"preCheckBody,omitempty"` // Pre-check request body (write-only: empty keeps the stored one)
+	PreCheckExtract string `json:"preCheckExtract,omitempty"` // header:<name> or json:<dot.path>
+	PreCheckInject string `json:"preCheckInject,omitempty"` // "Header-Name: value with {token}" (write-only: empty keeps the stored one)
+	PreCheckTTL  int    `json:"preCheckTtl,omitempty"`  // Seconds to reuse the token
 }
 
 // StatsResponse represents overall statistics
 type StatsResponse struct {
-	OverallUptime   float64 `json:"overallUptime"`
-	ServicesUp      int     `json:"servicesUp"`
-	ServicesDown    int     `json:"servicesDown"`
-	AvgResponseTime int     `json:"avgResponseTime"`
+	OverallUptime    float64 `json:"overallUptime"`
+	ServicesUp       int     `json:"servicesUp"`
+	ServicesDown     int     `json:"servicesDown"`
+	ServicesDegraded int     `json:"servicesDegraded"` // Also counted in ServicesUp unless DEGRADED_COUNTS_AS_UP=false
+	AvgResponseTime  int     `json:"avgResponseTime"`
+	P50ResponseTime  int     `json:"p50ResponseTime"` // Percentiles of up checks in the last 24h (milliseconds)
+	P90ResponseTime  int     `json:"p90ResponseTime"`
+	P99ResponseTime  int     `json:"p99ResponseTime"`
 }
 
 // CheckHistory stores historical check data
@@ -45,6 +216,64 @@ type CheckHistory struct {
 	Status       string    `gorm:"not null;index:idx_monitor_created_status;index:idx_monitor_created_status_response"`
 	ResponseTime int       `gorm:"default:0;index:idx_response_time_status;index:idx_monitor_created_status_response"`
 	CreatedAt    time.Time `gorm:"index:idx_monitor_created;index:idx_monitor_created_status;index:idx_monitor_created_status_response"`
+	CacheAge     *int       // Age header in seconds (nil if absent)
+	ServerDate   *time.Time // Date header reported by the server
+	Headers      *string   `gorm:"type:text"` // Redacted response headers as JSON (StoreHeaders monitors only, short retention)
+	ErrorCategory string   // Category of the request error for failed checks (empty if the request completed)
+	TTFB         int       `gorm:"default:0"` // Milliseconds to the first response byte (0 if no response)
+	TotalTime    int       `gorm:"default:0"` // Milliseconds until the body was fully read (0 if no response)
+	Label        string    // Value extracted by the monitor's ExtractLabel (empty without one)
+	InMaintenance bool     `gorm:"default:false"` // Checked during a maintenance window, so excluded from uptime
+}
+
+// MaintenanceWindow is a scheduled period during which a monitor is still checked, but its checks don't
+// count toward uptime and nothing is alerted
+type MaintenanceWindow struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	MonitorID   uint      `gorm:"not null;index" json:"monitorId"`
+	Monitor     *Monitor  `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	StartsAt    time.Time `gorm:"not null" json:"startsAt"` // Start of the first occurrence
+	EndsAt      time.Time `gorm:"not null" json:"endsAt"`   // End of the first occurrence
+	Recurrence  string    `json:"recurrence,omitempty"`     // daily or weekly (empty = once)
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Incident is a period during which a monitor was down, from the transition to down until it recovered
+type Incident struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	MonitorID       uint       `gorm:"not null;index:idx_incident_monitor_started" json:"monitorId"`
+	Monitor         *Monitor   `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	MonitorName     string     `gorm:"-" json:"monitorName,omitempty"` // Only set by GET /api/incidents
+	StartedAt       time.Time  `gorm:"not null;index:idx_incident_monitor_started" json:"startedAt"`
+	EndedAt         *time.Time `gorm:"index" json:"endedAt"` // nil while the incident is ongoing
+	DurationSeconds int64      `json:"durationSeconds"`      // Stored once closed; for ongoing incidents the time so far
+}
+
+// VersionChange records a change of a monitor's reported version
+type VersionChange struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	MonitorID  uint      `gorm:"not null;index" json:"monitorId"`
+	OldVersion string    `json:"oldVersion"`
+	NewVersion string    `json:"newVersion"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ValidatorChange records a change of a watched monitor's ETag or Last-Modified header
+type ValidatorChange struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	MonitorID uint      `gorm:"not null;index" json:"monitorId"`
+	Header    string    `json:"header"` // ETag or Last-Modified
+	OldValue  string    `json:"oldValue"`
+	NewValue  string    `json:"newValue"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Setting is a persisted server-wide key/value setting
+type Setting struct {
+	Key       string `gorm:"primaryKey"`
+	Value     string
+	UpdatedAt time.Time
 }
 
 // CheckHistoryBucket stores aggregated hourly buckets of check history for older data
@@ -60,10 +289,284 @@ type CheckHistoryBucket struct {
 	CreatedAt      time.Time
 }
 
+// StatsSnapshot is a periodic copy of the overall StatsResponse, kept for trend charts
+type StatsSnapshot struct {
+	ID              uint      `gorm:"primaryKey" json:"-"`
+	OverallUptime   float64   `json:"overallUptime"`
+	ServicesUp      int       `json:"servicesUp"`
+	ServicesDown    int       `json:"servicesDown"`
+	AvgResponseTime int       `json:"avgResponseTime"`
+	CreatedAt       time.Time `gorm:"index" json:"timestamp"`
+}
+
+// StatsHistoryResponse is the snapshot series returned by the stats history endpoint
+type StatsHistoryResponse struct {
+	Range     string          `json:"range"`
+	Snapshots []StatsSnapshot `json:"snapshots"`
+	Change    *StatsChange    `json:"change,omitempty"` // Difference between the first and last snapshot (nil with fewer than two)
+}
+
+// SLAResponse is the fleet-wide availability over a range
+type SLAResponse struct {
+	Range                     string       `json:"range"`
+	Availability              float64      `json:"availability"`              // Average of the monitors' availability percentages
+	CheckWeightedAvailability float64      `json:"checkWeightedAvailability"` // Up checks / all checks across the fleet
+	MonitorCount              int          `json:"monitorCount"`              // Unpaused monitors with history in the range
+	MeetingTarget             int          `json:"meetingTarget"`
+	MissingTarget             int          `json:"missingTarget"`
+	NoTarget                  int          `json:"noTarget"` // Monitors without an slaTarget
+	NoData                    int          `json:"noData"`   // Monitors with a target but no history in the range
+	Monitors                  []MonitorSLA `json:"monitors"`
+}
+
+// MonitorSLA is a monitor's availability against its target
+type MonitorSLA struct {
+	ID           uint     `json:"id"`
+	Name         string   `json:"name"`
+	Availability *float64 `json:"availability,omitempty"` // nil without history in the range
+	Target       float64  `json:"target,omitempty"`
+	Met          *bool    `json:"met,omitempty"` // nil without a target or history
+	Checks       int64    `json:"checks"`
+}
+
+// PercentilesResponse is the fleet-wide response time distribution over a range, in milliseconds
+type PercentilesResponse struct {
+	Range          string  `json:"range"`
+	P50            float64 `json:"p50"`
+	P95            float64 `json:"p95"`
+	P99            float64 `json:"p99"`
+	MonitorCount   int     `json:"monitorCount"`   // Unpaused monitors with response times in the range
+	RawChecks      int64   `json:"rawChecks"`      // Checks with a response read from raw history
+	BucketedChecks int64   `json:"bucketedChecks"` // Checks only known from hourly bucket averages
+	Approximate    bool    `json:"approximate"`    // True when buckets contributed, since they only keep an average per hour
+}
+
+// StatsChange is the difference between two stats snapshots
+type StatsChange struct {
+	OverallUptime   float64 `json:"overallUptime"`   // Percentage points
+	AvgResponseTime int     `json:"avgResponseTime"` // Milliseconds
+}
+
 // ResponseTimeData represents formatted response time data for charts
 type ResponseTimeData struct {
 	Time         string  `json:"time"`         // Formatted time string (for display)
 	Timestamp    string  `json:"timestamp"`    // ISO 8601 timestamp (for client-side formatting)
 	ResponseTime float64 `json:"responseTime"`
+	Min          float64 `json:"min,omitempty"`  // Fastest response in the point, only for aggregated (hourly or daily) points
+	Max          float64 `json:"max,omitempty"`  // Slowest response in the point, only for aggregated points
+	Unit         string  `json:"unit,omitempty"` // Set when requested with ?unit= (ms or s)
+}
+
+
+// ConfigDefaultsResponse describes the server's monitor defaults and validation bounds
+// so clients can render forms consistent with the backend
+type ConfigDefaultsResponse struct {
+	CheckInterval    int      `json:"checkInterval"`
+	MinCheckInterval int      `json:"minCheckInterval"`
+	MaxCheckInterval int      `json:"maxCheckInterval"`
+	TimeoutSeconds   int      `json:"timeoutSeconds"`
+	MaxTimeoutSeconds int     `json:"maxTimeoutSeconds"`
+	AllowedSchemes   []string `json:"allowedSchemes"`
+	RedirectPolicies []string `json:"redirectPolicies"`
+	DefaultScheme    string   `json:"defaultScheme"`
+}
+
+// DowntimeDay is the downtime recorded for a monitor on one UTC day
+type DowntimeDay struct {
+	Date            string  `json:"date"`                // YYYY-MM-DD (UTC)
+	DowntimeMinutes float64 `json:"downtimeMinutes"`
+	Partial         bool    `json:"partial,omitempty"`   // Day is still in progress
+	Estimated       bool    `json:"estimated,omitempty"` // Derived from hourly buckets rather than raw checks
+}
+
+// DowntimeResponse is the per-day downtime breakdown for a monitor
+type DowntimeResponse struct {
+	MonitorID    uint          `json:"monitorId"`
+	Range        string        `json:"range"`
+	TotalMinutes float64       `json:"totalMinutes"`
+	Ongoing      bool          `json:"ongoing"` // Monitor is currently down
+	Days         []DowntimeDay `json:"days"`
+}
+
+// CheckFailure is a failed check as returned by the failures endpoint
+type CheckFailure struct {
+	Timestamp    string              `json:"timestamp"` // ISO 8601
+	Status       string              `json:"status"`
+	ResponseTime int                 `json:"responseTime"`
+	ErrorCategory string             `json:"errorCategory,omitempty"` // Request error category (empty if the request completed)
+	Headers      map[string][]string `json:"headers,omitempty"` // Only present while within the header retention window
+}
+
+// NotificationSilenceResponse describes the global notification silence
+type NotificationSilenceResponse struct {
+	Silenced         bool    `json:"silenced"`
+	Until            *string `json:"until,omitempty"` // ISO 8601
+	RemainingSeconds int     `json:"remainingSeconds,omitempty"`
+}
+
+// RawConfigResponse is the on-disk monitors.yaml as returned by the admin endpoint
+type RawConfigResponse struct {
+	Path         string `json:"path"`
+	Exists       bool   `json:"exists"`
+	LastModified string `json:"lastModified,omitempty"` // ISO 8601
+	Size         int64  `json:"size"`
+	Content      string `json:"content"`
 }
 
+// GrafanaAnnotation is an outage in the Grafana annotation JSON format (times in epoch milliseconds)
+type GrafanaAnnotation struct {
+	Time    int64    `json:"time"`
+	TimeEnd int64    `json:"timeEnd"`
+	Title   string   `json:"title"`
+	Text    string   `json:"text"`
+	Tags    []string `json:"tags"`
+}
+
+// FieldErrorResponse is an error response tied to a specific request field
+type FieldErrorResponse struct {
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
+
+// UpsertMonitorResponse reports what an upsert did and the resulting monitor
+type UpsertMonitorResponse struct {
+	Action  string  `json:"action"` // created, updated, or unchanged
+	Monitor Monitor `json:"monitor"`
+}
+
+// MonitorAlert is a down/recovery alert, sent to SSE clients as a monitor_alert event
+type MonitorAlert struct {
+	MonitorID   uint   `json:"monitorId"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Kind        string `json:"kind"` // down, recovered or retired
+	Status      string `json:"status"`
+	Message     string `json:"message"`               // Human-readable alert text
+	Time        string `json:"time"`                  // ISO 8601
+	DownSeconds int64  `json:"downSeconds,omitempty"` // How long the monitor was down, on recovery alerts
+}
+
+// MonitorStreak is how long a monitor has had its current status
+type MonitorStreak struct {
+	MonitorID       uint    `json:"monitorId"`
+	Status          string  `json:"status"`
+	Paused          bool    `json:"paused"`
+	Since           *string `json:"since,omitempty"`   // ISO 8601, unset until the monitor has been checked
+	DurationSeconds int64   `json:"durationSeconds"`
+}
+
+// MonitorUptime is a monitor's availability over several windows, unset for windows without checks
+type MonitorUptime struct {
+	MonitorID uint     `json:"monitorId"`
+	Uptime24h *float64 `json:"uptime24h"`
+	Uptime7d  *float64 `json:"uptime7d"`
+	Uptime30d *float64 `json:"uptime30d"`
+	Uptime90d *float64 `json:"uptime90d"`
+}
+
+// NotificationPreview is the alert a monitor event would send, rendered without sending it
+type NotificationPreview struct {
+	Event           string       `json:"event"`
+	Alert           MonitorAlert `json:"alert"`
+	Channels        []string     `json:"channels"`                  // Where the alert would be delivered
+	EscalateAfter   int          `json:"escalateAfter,omitempty"`   // Seconds the monitor must stay down before a down alert is sent
+	Suppressed      bool         `json:"suppressed"`                // Notifications are currently silenced or the monitor is in maintenance
+	SuppressedUntil *string      `json:"suppressedUntil,omitempty"` // ISO 8601
+}
+
+// EndpointResult records the outcome of checking one URL of a multi-endpoint monitor
+type EndpointResult struct {
+	URL          string `json:"url"`
+	Status       string `json:"status"`
+	ResponseTime int    `json:"responseTime"`
+}
+
+// StringList is a list of strings stored as a JSON text column
+type StringList []string
+
+// GormDataType stores the list as text
+func (StringList) GormDataType() string {
+	return "text"
+}
+
+// Value implements driver.Valuer
+func (l StringList) Value() (driver.Value, error) {
+	return marshalJSONColumn(l, len(l) == 0)
+}
+
+// Scan implements sql.Scanner
+func (l *StringList) Scan(value interface{}) error {
+	*l = nil
+	return unmarshalJSONColumn(value, l)
+}
+
+// CertificateInfo describes the leaf certificate seen by a monitor's last HTTPS check
+// It is captured even when verification fails, so the check's TLS error can be explained
+type CertificateInfo struct {
+	Subject            string    `json:"subject"`
+	Issuer             string    `json:"issuer"`
+	SANs               []string  `json:"sans,omitempty"` // DNS names and IP addresses the certificate is valid for
+	SerialNumber       string    `json:"serialNumber"`
+	Fingerprint        string    `json:"fingerprint"` // SHA-256 of the leaf certificate, colon-separated hex
+	NotBefore          time.Time `json:"notBefore"`
+	NotAfter           time.Time `json:"notAfter"`
+	KeyAlgorithm       string    `json:"keyAlgorithm"` // RSA, ECDSA, Ed25519
+	KeyBits            int       `json:"keyBits,omitempty"`
+	SignatureAlgorithm string    `json:"signatureAlgorithm"`
+	ChainLength        int       `json:"chainLength"`   // Certificates presented by the server
+	Trusted            bool      `json:"trusted"`       // Chains to a root trusted by the host
+	HostnameMatch      bool      `json:"hostnameMatch"` // Valid for the host that was checked
+	VerifyError        string    `json:"verifyError,omitempty"`
+	Warnings           []string  `json:"warnings,omitempty"`
+	CheckedAt          time.Time `json:"checkedAt"`
+}
+
+// EndpointResultList is a list of endpoint results stored as a JSON text column
+type EndpointResultList []EndpointResult
+
+// GormDataType stores the list as text
+func (EndpointResultList) GormDataType() string {
+	return "text"
+}
+
+// Value implements driver.Valuer
+func (l EndpointResultList) Value() (driver.Value, error) {
+	return marshalJSONColumn(l, len(l) == 0)
+}
+
+// Scan implements sql.Scanner
+func (l *EndpointResultList) Scan(value interface{}) error {
+	*l = nil
+	return unmarshalJSONColumn(value, l)
+}
+
+// marshalJSONColumn encodes a value for a JSON text column, storing NULL when empty
+func marshalJSONColumn(value interface{}, empty bool) (driver.Value, error) {
+	if empty {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// unmarshalJSONColumn decodes a JSON text column into dest, leaving it empty for NULL
+func unmarshalJSONColumn(value interface{}, dest interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("unsupported JSON column type %T", value)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, dest)
+}
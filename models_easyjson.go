@@ -7,6 +7,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -49,12 +50,36 @@ func easyjsonD2b7633eDecodeNanostatusNanostat(in *jlexer.Lexer, out *StatsRespon
 			} else {
 				out.ServicesDown = int(in.Int())
 			}
+		case "servicesDegraded":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ServicesDegraded = int(in.Int())
+			}
 		case "avgResponseTime":
 			if in.IsNull() {
 				in.Skip()
 			} else {
 				out.AvgResponseTime = int(in.Int())
 			}
+		case "p50ResponseTime":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.P50ResponseTime = int(in.Int())
+			}
+		case "p90ResponseTime":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.P90ResponseTime = int(in.Int())
+			}
+		case "p99ResponseTime":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.P99ResponseTime = int(in.Int())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -84,11 +109,31 @@ func easyjsonD2b7633eEncodeNanostatusNanostat(out *jwriter.Writer, in StatsRespo
 		out.RawString(prefix)
 		out.Int(int(in.ServicesDown))
 	}
+	{
+		const prefix string = ",\"servicesDegraded\":"
+		out.RawString(prefix)
+		out.Int(int(in.ServicesDegraded))
+	}
 	{
 		const prefix string = ",\"avgResponseTime\":"
 		out.RawString(prefix)
 		out.Int(int(in.AvgResponseTime))
 	}
+	{
+		const prefix string = ",\"p50ResponseTime\":"
+		out.RawString(prefix)
+		out.Int(int(in.P50ResponseTime))
+	}
+	{
+		const prefix string = ",\"p90ResponseTime\":"
+		out.RawString(prefix)
+		out.Int(int(in.P90ResponseTime))
+	}
+	{
+		const prefix string = ",\"p99ResponseTime\":"
+		out.RawString(prefix)
+		out.Int(int(in.P99ResponseTime))
+	}
 	out.RawByte('}')
 }
 
@@ -133,6 +178,24 @@ func easyjsonD2b7633eDecodeNanostatusNanostat1(in *jlexer.Lexer, out *ResponseTi
 			} else {
 				out.ResponseTime = float64(in.Float64())
 			}
+		case "min":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Min = float64(in.Float64())
+			}
+		case "max":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Max = float64(in.Float64())
+			}
+		case "unit":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Unit = string(in.String())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -162,6 +225,21 @@ func easyjsonD2b7633eEncodeNanostatusNanostat1(out *jwriter.Writer, in ResponseT
 		out.RawString(prefix)
 		out.Float64(float64(in.ResponseTime))
 	}
+	if in.Min != 0 {
+		const prefix string = ",\"min\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Min))
+	}
+	if in.Max != 0 {
+		const prefix string = ",\"max\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Max))
+	}
+	if in.Unit != "" {
+		const prefix string = ",\"unit\":"
+		out.RawString(prefix)
+		out.String(string(in.Unit))
+	}
 	out.RawByte('}')
 }
 
@@ -248,264 +326,2089 @@ func easyjsonD2b7633eDecodeNanostatusNanostat2(in *jlexer.Lexer, out *Monitor) {
 			} else {
 				out.CheckInterval = int(in.Int())
 			}
-		case "paused":
+		case "timeoutSeconds":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.Paused = bool(in.Bool())
+				out.TimeoutSeconds = int(in.Int())
 			}
-		case "configHash":
+		case "paused":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.ConfigHash = string(in.String())
+				out.Paused = bool(in.Bool())
 			}
-		case "createdAt":
+		case "statusSince":
 			if in.IsNull() {
 				in.Skip()
+				out.StatusSince = nil
 			} else {
+				if out.StatusSince == nil {
+					out.StatusSince = new(time.Time)
+				}
 				if data := in.Raw(); in.Ok() {
-					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+					in.AddError((*out.StatusSince).UnmarshalJSON(data))
 				}
 			}
-		case "updatedAt":
+		case "configHash":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				if data := in.Raw(); in.Ok() {
-					in.AddError((out.UpdatedAt).UnmarshalJSON(data))
-				}
+				out.ConfigHash = string(in.String())
 			}
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjsonD2b7633eEncodeNanostatusNanostat2(out *jwriter.Writer, in Monitor) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"id\":"
-		out.RawString(prefix[1:])
-		out.Uint(uint(in.ID))
-	}
-	{
-		const prefix string = ",\"name\":"
-		out.RawString(prefix)
-		out.String(string(in.Name))
-	}
-	{
-		const prefix string = ",\"url\":"
-		out.RawString(prefix)
-		out.String(string(in.URL))
-	}
-	{
-		const prefix string = ",\"uptime\":"
-		out.RawString(prefix)
-		out.Float64(float64(in.Uptime))
-	}
-	{
-		const prefix string = ",\"status\":"
-		out.RawString(prefix)
-		out.String(string(in.Status))
-	}
-	{
-		const prefix string = ",\"responseTime\":"
-		out.RawString(prefix)
-		out.Int(int(in.ResponseTime))
-	}
-	{
-		const prefix string = ",\"lastCheck\":"
-		out.RawString(prefix)
-		out.String(string(in.LastCheck))
-	}
-	if in.IsThirdParty {
-		const prefix string = ",\"isThirdParty\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.IsThirdParty))
-	}
-	if in.Icon != "" {
-		const prefix string = ",\"icon\":"
-		out.RawString(prefix)
-		out.String(string(in.Icon))
-	}
-	{
-		const prefix string = ",\"checkInterval\":"
-		out.RawString(prefix)
-		out.Int(int(in.CheckInterval))
-	}
-	{
-		const prefix string = ",\"paused\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.Paused))
-	}
-	if in.ConfigHash != "" {
-		const prefix string = ",\"configHash\":"
-		out.RawString(prefix)
-		out.String(string(in.ConfigHash))
-	}
-	{
-		const prefix string = ",\"createdAt\":"
-		out.RawString(prefix)
-		out.Raw((in.CreatedAt).MarshalJSON())
-	}
-	{
-		const prefix string = ",\"updatedAt\":"
-		out.RawString(prefix)
-		out.Raw((in.UpdatedAt).MarshalJSON())
-	}
-	out.RawByte('}')
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Monitor) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeNanostatusNanostat2(w, v)
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Monitor) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeNanostatusNanostat2(l, v)
-}
-func easyjsonD2b7633eDecodeNanostatusNanostat3(in *jlexer.Lexer, out *CreateMonitorRequest) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		switch key {
-		case "name":
+		case "configSource":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.Name = string(in.String())
+				out.ConfigSource = string(in.String())
 			}
-		case "url":
+		case "redirectPolicy":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.URL = string(in.String())
+				out.RedirectPolicy = string(in.String())
 			}
-		case "isThirdParty":
+		case "maxRedirects":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.IsThirdParty = bool(in.Bool())
+				out.MaxRedirects = int(in.Int())
 			}
-		case "icon":
+		case "followRedirects":
 			if in.IsNull() {
 				in.Skip()
+				out.FollowRedirects = nil
 			} else {
-				out.Icon = string(in.String())
+				if out.FollowRedirects == nil {
+					out.FollowRedirects = new(bool)
+				}
+				*out.FollowRedirects = bool(in.Bool())
 			}
-		case "checkInterval":
+		case "statusOutcomes":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.CheckInterval = int(in.Int())
+				out.StatusOutcomes = string(in.String())
 			}
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjsonD2b7633eEncodeNanostatusNanostat3(out *jwriter.Writer, in CreateMonitorRequest) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"name\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.Name))
-	}
-	{
-		const prefix string = ",\"url\":"
-		out.RawString(prefix)
-		out.String(string(in.URL))
-	}
-	if in.IsThirdParty {
-		const prefix string = ",\"isThirdParty\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.IsThirdParty))
-	}
-	if in.Icon != "" {
-		const prefix string = ",\"icon\":"
-		out.RawString(prefix)
-		out.String(string(in.Icon))
-	}
-	if in.CheckInterval != 0 {
-		const prefix string = ",\"checkInterval\":"
-		out.RawString(prefix)
-		out.Int(int(in.CheckInterval))
-	}
-	out.RawByte('}')
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CreateMonitorRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonD2b7633eEncodeNanostatusNanostat3(w, v)
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CreateMonitorRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonD2b7633eDecodeNanostatusNanostat3(l, v)
-}
-func easyjsonD2b7633eDecodeNanostatusNanostat4(in *jlexer.Lexer, out *CheckHistory) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		switch key {
-		case "ID":
+		case "expectedStatusCodes":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.ID = uint(in.Uint())
+				out.ExpectedStatusCodes = string(in.String())
 			}
-		case "MonitorID":
+		case "lastRedirect":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.MonitorID = uint(in.Uint())
+				out.LastRedirect = string(in.String())
 			}
-		case "Status":
+		case "minCacheAge":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.Status = string(in.String())
+				out.MinCacheAge = int(in.Int())
 			}
-		case "ResponseTime":
+		case "maxCacheAge":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.ResponseTime = int(in.Int())
+				out.MaxCacheAge = int(in.Int())
 			}
-		case "CreatedAt":
+		case "lastCacheAge":
+			if in.IsNull() {
+				in.Skip()
+				out.LastCacheAge = nil
+			} else {
+				if out.LastCacheAge == nil {
+					out.LastCacheAge = new(int)
+				}
+				*out.LastCacheAge = int(in.Int())
+			}
+		case "lastServerDate":
+			if in.IsNull() {
+				in.Skip()
+				out.LastServerDate = nil
+			} else {
+				if out.LastServerDate == nil {
+					out.LastServerDate = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.LastServerDate).UnmarshalJSON(data))
+				}
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make(StringList, 0, 4)
+					} else {
+						out.Tags = StringList{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Tags = append(out.Tags, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "group":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Group = string(in.String())
+			}
+		case "endpoints":
+			if in.IsNull() {
+				in.Skip()
+				out.Endpoints = nil
+			} else {
+				in.Delim('[')
+				if out.Endpoints == nil {
+					if !in.IsDelim(']') {
+						out.Endpoints = make(StringList, 0, 4)
+					} else {
+						out.Endpoints = StringList{}
+					}
+				} else {
+					out.Endpoints = (out.Endpoints)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 string
+					v2 = string(in.String())
+					out.Endpoints = append(out.Endpoints, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "parallelEndpoints":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ParallelEndpoints = bool(in.Bool())
+			}
+		case "endpointResults":
+			if in.IsNull() {
+				in.Skip()
+				out.EndpointResults = nil
+			} else {
+				in.Delim('[')
+				if out.EndpointResults == nil {
+					if !in.IsDelim(']') {
+						out.EndpointResults = make(EndpointResultList, 0, 1)
+					} else {
+						out.EndpointResults = EndpointResultList{}
+					}
+				} else {
+					out.EndpointResults = (out.EndpointResults)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 EndpointResult
+					easyjsonD2b7633eDecodeNanostatusNanostat5(in, &v3)
+					out.EndpointResults = append(out.EndpointResults, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "gracePeriod":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.GracePeriod = int(in.Int())
+			}
+		case "escalateAfter":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.EscalateAfter = int(in.Int())
+			}
+		case "failureThreshold":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.FailureThreshold = int(in.Int())
+			}
+		case "retryCount":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RetryCount = int(in.Int())
+			}
+		case "retryDelayMs":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RetryDelayMs = int(in.Int())
+			}
+		case "type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Type = string(in.String())
+			}
+		case "heartbeatGrace":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.HeartbeatGrace = int(in.Int())
+			}
+		case "lastHeartbeatAt":
+			if in.IsNull() {
+				in.Skip()
+				out.LastHeartbeatAt = nil
+			} else {
+				if out.LastHeartbeatAt == nil {
+					out.LastHeartbeatAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.LastHeartbeatAt).UnmarshalJSON(data))
+				}
+			}
+		case "consecutiveFailures":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ConsecutiveFailures = int(in.Int())
+			}
+		case "graceStartedAt":
+			if in.IsNull() {
+				in.Skip()
+				out.GraceStartedAt = nil
+			} else {
+				if out.GraceStartedAt == nil {
+					out.GraceStartedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.GraceStartedAt).UnmarshalJSON(data))
+				}
+			}
+		case "storeHeaders":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.StoreHeaders = bool(in.Bool())
+			}
+		case "lightCheck":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LightCheck = bool(in.Bool())
+			}
+		case "http3":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.HTTP3 = bool(in.Bool())
+			}
+		case "udpProbe":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UDPProbe = string(in.String())
+			}
+		case "udpExpect":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UDPExpect = string(in.String())
+			}
+		case "websocketPing":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.WebSocketPing = bool(in.Bool())
+			}
+		case "adaptiveInterval":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.AdaptiveInterval = bool(in.Bool())
+			}
+		case "minAdaptiveInterval":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MinAdaptiveInterval = int(in.Int())
+			}
+		case "maxAdaptiveInterval":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxAdaptiveInterval = int(in.Int())
+			}
+		case "effectiveInterval":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.EffectiveInterval = int(in.Int())
+			}
+		case "effectiveIntervalSince":
+			if in.IsNull() {
+				in.Skip()
+				out.EffectiveIntervalSince = nil
+			} else {
+				if out.EffectiveIntervalSince == nil {
+					out.EffectiveIntervalSince = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.EffectiveIntervalSince).UnmarshalJSON(data))
+				}
+			}
+		case "versionHeader":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.VersionHeader = string(in.String())
+			}
+		case "currentVersion":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.CurrentVersion = string(in.String())
+			}
+		case "extractLabel":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExtractLabel = string(in.String())
+			}
+		case "lastLabel":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LastLabel = string(in.String())
+			}
+		case "versionChangedAt":
+			if in.IsNull() {
+				in.Skip()
+				out.VersionChangedAt = nil
+			} else {
+				if out.VersionChangedAt == nil {
+					out.VersionChangedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.VersionChangedAt).UnmarshalJSON(data))
+				}
+			}
+		case "watchValidators":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.WatchValidators = bool(in.Bool())
+			}
+		case "lastEtag":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LastETag = string(in.String())
+			}
+		case "lastModified":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LastModified = string(in.String())
+			}
+		case "validatorChangedAt":
+			if in.IsNull() {
+				in.Skip()
+				out.ValidatorChangedAt = nil
+			} else {
+				if out.ValidatorChangedAt == nil {
+					out.ValidatorChangedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ValidatorChangedAt).UnmarshalJSON(data))
+				}
+			}
+		case "watchCertificate":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.WatchCertificate = bool(in.Bool())
+			}
+		case "certFingerprint":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.CertFingerprint = string(in.String())
+			}
+		case "previousCertFingerprint":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PreviousCertFingerprint = string(in.String())
+			}
+		case "certFingerprintChangedAt":
+			if in.IsNull() {
+				in.Skip()
+				out.CertFingerprintChangedAt = nil
+			} else {
+				if out.CertFingerprintChangedAt == nil {
+					out.CertFingerprintChangedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.CertFingerprintChangedAt).UnmarshalJSON(data))
+				}
+			}
+		case "dependsOn":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.DependsOn = string(in.String())
+			}
+		case "method":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Method = string(in.String())
+			}
+		case "requestBody":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RequestBody = string(in.String())
+			}
+		case "contentType":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ContentType = string(in.String())
+			}
+		case "expectKeyword":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExpectKeyword = string(in.String())
+			}
+		case "expectKeywordAbsent":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExpectKeywordAbsent = bool(in.Bool())
+			}
+		case "maxBodyBytes":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxBodyBytes = int64(in.Int64())
+			}
+		case "jsonSchema":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.JSONSchema = string(in.String())
+			}
+		case "schemaError":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.SchemaError = string(in.String())
+			}
+		case "slaTarget":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.SLATarget = float64(in.Float64())
+			}
+		case "sourceIp":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.SourceIP = string(in.String())
+			}
+		case "dnsServer":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.DNSServer = string(in.String())
+			}
+		case "expectedIp":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExpectedIP = string(in.String())
+			}
+		case "autoRetireAfter":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.AutoRetireAfter = int(in.Int())
+			}
+		case "autoRetireAction":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.AutoRetireAction = string(in.String())
+			}
+		case "retireCandidateSince":
+			if in.IsNull() {
+				in.Skip()
+				out.RetireCandidateSince = nil
+			} else {
+				if out.RetireCandidateSince == nil {
+					out.RetireCandidateSince = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.RetireCandidateSince).UnmarshalJSON(data))
+				}
+			}
+		case "responseTimeMode":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ResponseTimeMode = string(in.String())
+			}
+		case "lastTtfb":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LastTTFB = int(in.Int())
+			}
+		case "lastTotalTime":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LastTotalTime = int(in.Int())
+			}
+		case "latencyWindow":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LatencyWindow = int(in.Int())
+			}
+		case "latencyThreshold":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LatencyThreshold = int(in.Int())
+			}
+		case "rollingResponseTime":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RollingResponseTime = int(in.Int())
+			}
+		case "degradedThresholdMs":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.DegradedThresholdMs = int(in.Int())
+			}
+		case "p50ResponseTime":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.P50ResponseTime = int(in.Int())
+			}
+		case "p90ResponseTime":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.P90ResponseTime = int(in.Int())
+			}
+		case "p99ResponseTime":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.P99ResponseTime = int(in.Int())
+			}
+		case "sampleRate":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.SampleRate = int(in.Int())
+			}
+		case "storeHistory":
+			if in.IsNull() {
+				in.Skip()
+				out.StoreHistory = nil
+			} else {
+				if out.StoreHistory == nil {
+					out.StoreHistory = new(bool)
+				}
+				*out.StoreHistory = bool(in.Bool())
+			}
+		case "lastContentEncoding":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LastContentEncoding = string(in.String())
+			}
+		case "lastProtocol":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LastProtocol = string(in.String())
+			}
+		case "preCheckUrl":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PreCheckURL = string(in.String())
+			}
+		case "preCheckMethod":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PreCheckMethod = string(in.String())
+			}
+		case "preCheckExtract":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PreCheckExtract = string(in.String())
+			}
+		case "preCheckTtl":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PreCheckTTL = int(in.Int())
+			}
+		case "certificate":
+			if in.IsNull() {
+				in.Skip()
+				out.Certificate = nil
+			} else {
+				if out.Certificate == nil {
+					out.Certificate = new(CertificateInfo)
+				}
+				(*out.Certificate).UnmarshalEasyJSON(in)
+			}
+		case "lastError":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LastError = string(in.String())
+			}
+		case "lastErrorCategory":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LastErrorCategory = string(in.String())
+			}
+		case "createdAt":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		case "updatedAt":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.UpdatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeNanostatusNanostat2(out *jwriter.Writer, in Monitor) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint(uint(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	{
+		const prefix string = ",\"uptime\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Uptime))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"responseTime\":"
+		out.RawString(prefix)
+		out.Int(int(in.ResponseTime))
+	}
+	{
+		const prefix string = ",\"lastCheck\":"
+		out.RawString(prefix)
+		out.String(string(in.LastCheck))
+	}
+	if in.IsThirdParty {
+		const prefix string = ",\"isThirdParty\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsThirdParty))
+	}
+	if in.Icon != "" {
+		const prefix string = ",\"icon\":"
+		out.RawString(prefix)
+		out.String(string(in.Icon))
+	}
+	{
+		const prefix string = ",\"checkInterval\":"
+		out.RawString(prefix)
+		out.Int(int(in.CheckInterval))
+	}
+	if in.TimeoutSeconds != 0 {
+		const prefix string = ",\"timeoutSeconds\":"
+		out.RawString(prefix)
+		out.Int(int(in.TimeoutSeconds))
+	}
+	{
+		const prefix string = ",\"paused\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Paused))
+	}
+	if in.StatusSince != nil {
+		const prefix string = ",\"statusSince\":"
+		out.RawString(prefix)
+		out.Raw((*in.StatusSince).MarshalJSON())
+	}
+	if in.ConfigHash != "" {
+		const prefix string = ",\"configHash\":"
+		out.RawString(prefix)
+		out.String(string(in.ConfigHash))
+	}
+	if in.ConfigSource != "" {
+		const prefix string = ",\"configSource\":"
+		out.RawString(prefix)
+		out.String(string(in.ConfigSource))
+	}
+	if in.RedirectPolicy != "" {
+		const prefix string = ",\"redirectPolicy\":"
+		out.RawString(prefix)
+		out.String(string(in.RedirectPolicy))
+	}
+	if in.MaxRedirects != 0 {
+		const prefix string = ",\"maxRedirects\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxRedirects))
+	}
+	if in.FollowRedirects != nil {
+		const prefix string = ",\"followRedirects\":"
+		out.RawString(prefix)
+		out.Bool(bool(*in.FollowRedirects))
+	}
+	if in.StatusOutcomes != "" {
+		const prefix string = ",\"statusOutcomes\":"
+		out.RawString(prefix)
+		out.String(string(in.StatusOutcomes))
+	}
+	if in.ExpectedStatusCodes != "" {
+		const prefix string = ",\"expectedStatusCodes\":"
+		out.RawString(prefix)
+		out.String(string(in.ExpectedStatusCodes))
+	}
+	if in.LastRedirect != "" {
+		const prefix string = ",\"lastRedirect\":"
+		out.RawString(prefix)
+		out.String(string(in.LastRedirect))
+	}
+	if in.MinCacheAge != 0 {
+		const prefix string = ",\"minCacheAge\":"
+		out.RawString(prefix)
+		out.Int(int(in.MinCacheAge))
+	}
+	if in.MaxCacheAge != 0 {
+		const prefix string = ",\"maxCacheAge\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxCacheAge))
+	}
+	if in.LastCacheAge != nil {
+		const prefix string = ",\"lastCacheAge\":"
+		out.RawString(prefix)
+		out.Int(int(*in.LastCacheAge))
+	}
+	if in.LastServerDate != nil {
+		const prefix string = ",\"lastServerDate\":"
+		out.RawString(prefix)
+		out.Raw((*in.LastServerDate).MarshalJSON())
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v4, v5 := range in.Tags {
+				if v4 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v5))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Group != "" {
+		const prefix string = ",\"group\":"
+		out.RawString(prefix)
+		out.String(string(in.Group))
+	}
+	if len(in.Endpoints) != 0 {
+		const prefix string = ",\"endpoints\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v6, v7 := range in.Endpoints {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v7))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.ParallelEndpoints {
+		const prefix string = ",\"parallelEndpoints\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.ParallelEndpoints))
+	}
+	if len(in.EndpointResults) != 0 {
+		const prefix string = ",\"endpointResults\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.EndpointResults {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD2b7633eEncodeNanostatusNanostat5(out, v9)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.GracePeriod != 0 {
+		const prefix string = ",\"gracePeriod\":"
+		out.RawString(prefix)
+		out.Int(int(in.GracePeriod))
+	}
+	if in.EscalateAfter != 0 {
+		const prefix string = ",\"escalateAfter\":"
+		out.RawString(prefix)
+		out.Int(int(in.EscalateAfter))
+	}
+	if in.FailureThreshold != 0 {
+		const prefix string = ",\"failureThreshold\":"
+		out.RawString(prefix)
+		out.Int(int(in.FailureThreshold))
+	}
+	if in.RetryCount != 0 {
+		const prefix string = ",\"retryCount\":"
+		out.RawString(prefix)
+		out.Int(int(in.RetryCount))
+	}
+	if in.RetryDelayMs != 0 {
+		const prefix string = ",\"retryDelayMs\":"
+		out.RawString(prefix)
+		out.Int(int(in.RetryDelayMs))
+	}
+	if in.Type != "" {
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	if in.HeartbeatGrace != 0 {
+		const prefix string = ",\"heartbeatGrace\":"
+		out.RawString(prefix)
+		out.Int(int(in.HeartbeatGrace))
+	}
+	if in.LastHeartbeatAt != nil {
+		const prefix string = ",\"lastHeartbeatAt\":"
+		out.RawString(prefix)
+		out.Raw((*in.LastHeartbeatAt).MarshalJSON())
+	}
+	if in.ConsecutiveFailures != 0 {
+		const prefix string = ",\"consecutiveFailures\":"
+		out.RawString(prefix)
+		out.Int(int(in.ConsecutiveFailures))
+	}
+	if in.GraceStartedAt != nil {
+		const prefix string = ",\"graceStartedAt\":"
+		out.RawString(prefix)
+		out.Raw((*in.GraceStartedAt).MarshalJSON())
+	}
+	if in.StoreHeaders {
+		const prefix string = ",\"storeHeaders\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.StoreHeaders))
+	}
+	if in.LightCheck {
+		const prefix string = ",\"lightCheck\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.LightCheck))
+	}
+	if in.HTTP3 {
+		const prefix string = ",\"http3\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.HTTP3))
+	}
+	if in.UDPProbe != "" {
+		const prefix string = ",\"udpProbe\":"
+		out.RawString(prefix)
+		out.String(string(in.UDPProbe))
+	}
+	if in.UDPExpect != "" {
+		const prefix string = ",\"udpExpect\":"
+		out.RawString(prefix)
+		out.String(string(in.UDPExpect))
+	}
+	if in.WebSocketPing {
+		const prefix string = ",\"websocketPing\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.WebSocketPing))
+	}
+	if in.AdaptiveInterval {
+		const prefix string = ",\"adaptiveInterval\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.AdaptiveInterval))
+	}
+	if in.MinAdaptiveInterval != 0 {
+		const prefix string = ",\"minAdaptiveInterval\":"
+		out.RawString(prefix)
+		out.Int(int(in.MinAdaptiveInterval))
+	}
+	if in.MaxAdaptiveInterval != 0 {
+		const prefix string = ",\"maxAdaptiveInterval\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxAdaptiveInterval))
+	}
+	if in.EffectiveInterval != 0 {
+		const prefix string = ",\"effectiveInterval\":"
+		out.RawString(prefix)
+		out.Int(int(in.EffectiveInterval))
+	}
+	if in.EffectiveIntervalSince != nil {
+		const prefix string = ",\"effectiveIntervalSince\":"
+		out.RawString(prefix)
+		out.Raw((*in.EffectiveIntervalSince).MarshalJSON())
+	}
+	if in.VersionHeader != "" {
+		const prefix string = ",\"versionHeader\":"
+		out.RawString(prefix)
+		out.String(string(in.VersionHeader))
+	}
+	if in.CurrentVersion != "" {
+		const prefix string = ",\"currentVersion\":"
+		out.RawString(prefix)
+		out.String(string(in.CurrentVersion))
+	}
+	if in.ExtractLabel != "" {
+		const prefix string = ",\"extractLabel\":"
+		out.RawString(prefix)
+		out.String(string(in.ExtractLabel))
+	}
+	if in.LastLabel != "" {
+		const prefix string = ",\"lastLabel\":"
+		out.RawString(prefix)
+		out.String(string(in.LastLabel))
+	}
+	if in.VersionChangedAt != nil {
+		const prefix string = ",\"versionChangedAt\":"
+		out.RawString(prefix)
+		out.Raw((*in.VersionChangedAt).MarshalJSON())
+	}
+	if in.WatchValidators {
+		const prefix string = ",\"watchValidators\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.WatchValidators))
+	}
+	if in.LastETag != "" {
+		const prefix string = ",\"lastEtag\":"
+		out.RawString(prefix)
+		out.String(string(in.LastETag))
+	}
+	if in.LastModified != "" {
+		const prefix string = ",\"lastModified\":"
+		out.RawString(prefix)
+		out.String(string(in.LastModified))
+	}
+	if in.ValidatorChangedAt != nil {
+		const prefix string = ",\"validatorChangedAt\":"
+		out.RawString(prefix)
+		out.Raw((*in.ValidatorChangedAt).MarshalJSON())
+	}
+	if in.WatchCertificate {
+		const prefix string = ",\"watchCertificate\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.WatchCertificate))
+	}
+	if in.CertFingerprint != "" {
+		const prefix string = ",\"certFingerprint\":"
+		out.RawString(prefix)
+		out.String(string(in.CertFingerprint))
+	}
+	if in.PreviousCertFingerprint != "" {
+		const prefix string = ",\"previousCertFingerprint\":"
+		out.RawString(prefix)
+		out.String(string(in.PreviousCertFingerprint))
+	}
+	if in.CertFingerprintChangedAt != nil {
+		const prefix string = ",\"certFingerprintChangedAt\":"
+		out.RawString(prefix)
+		out.Raw((*in.CertFingerprintChangedAt).MarshalJSON())
+	}
+	if in.DependsOn != "" {
+		const prefix string = ",\"dependsOn\":"
+		out.RawString(prefix)
+		out.String(string(in.DependsOn))
+	}
+	if in.Method != "" {
+		const prefix string = ",\"method\":"
+		out.RawString(prefix)
+		out.String(string(in.Method))
+	}
+	if in.RequestBody != "" {
+		const prefix string = ",\"requestBody\":"
+		out.RawString(prefix)
+		out.String(string(in.RequestBody))
+	}
+	if in.ContentType != "" {
+		const prefix string = ",\"contentType\":"
+		out.RawString(prefix)
+		out.String(string(in.ContentType))
+	}
+	if in.ExpectKeyword != "" {
+		const prefix string = ",\"expectKeyword\":"
+		out.RawString(prefix)
+		out.String(string(in.ExpectKeyword))
+	}
+	if in.ExpectKeywordAbsent {
+		const prefix string = ",\"expectKeywordAbsent\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.ExpectKeywordAbsent))
+	}
+	if in.MaxBodyBytes != 0 {
+		const prefix string = ",\"maxBodyBytes\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MaxBodyBytes))
+	}
+	if in.JSONSchema != "" {
+		const prefix string = ",\"jsonSchema\":"
+		out.RawString(prefix)
+		out.String(string(in.JSONSchema))
+	}
+	if in.SchemaError != "" {
+		const prefix string = ",\"schemaError\":"
+		out.RawString(prefix)
+		out.String(string(in.SchemaError))
+	}
+	if in.SLATarget != 0 {
+		const prefix string = ",\"slaTarget\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.SLATarget))
+	}
+	if in.SourceIP != "" {
+		const prefix string = ",\"sourceIp\":"
+		out.RawString(prefix)
+		out.String(string(in.SourceIP))
+	}
+	if in.DNSServer != "" {
+		const prefix string = ",\"dnsServer\":"
+		out.RawString(prefix)
+		out.String(string(in.DNSServer))
+	}
+	if in.ExpectedIP != "" {
+		const prefix string = ",\"expectedIp\":"
+		out.RawString(prefix)
+		out.String(string(in.ExpectedIP))
+	}
+	if in.AutoRetireAfter != 0 {
+		const prefix string = ",\"autoRetireAfter\":"
+		out.RawString(prefix)
+		out.Int(int(in.AutoRetireAfter))
+	}
+	if in.AutoRetireAction != "" {
+		const prefix string = ",\"autoRetireAction\":"
+		out.RawString(prefix)
+		out.String(string(in.AutoRetireAction))
+	}
+	if in.RetireCandidateSince != nil {
+		const prefix string = ",\"retireCandidateSince\":"
+		out.RawString(prefix)
+		out.Raw((*in.RetireCandidateSince).MarshalJSON())
+	}
+	if in.ResponseTimeMode != "" {
+		const prefix string = ",\"responseTimeMode\":"
+		out.RawString(prefix)
+		out.String(string(in.ResponseTimeMode))
+	}
+	if in.LastTTFB != 0 {
+		const prefix string = ",\"lastTtfb\":"
+		out.RawString(prefix)
+		out.Int(int(in.LastTTFB))
+	}
+	if in.LastTotalTime != 0 {
+		const prefix string = ",\"lastTotalTime\":"
+		out.RawString(prefix)
+		out.Int(int(in.LastTotalTime))
+	}
+	if in.LatencyWindow != 0 {
+		const prefix string = ",\"latencyWindow\":"
+		out.RawString(prefix)
+		out.Int(int(in.LatencyWindow))
+	}
+	if in.LatencyThreshold != 0 {
+		const prefix string = ",\"latencyThreshold\":"
+		out.RawString(prefix)
+		out.Int(int(in.LatencyThreshold))
+	}
+	if in.RollingResponseTime != 0 {
+		const prefix string = ",\"rollingResponseTime\":"
+		out.RawString(prefix)
+		out.Int(int(in.RollingResponseTime))
+	}
+	if in.DegradedThresholdMs != 0 {
+		const prefix string = ",\"degradedThresholdMs\":"
+		out.RawString(prefix)
+		out.Int(int(in.DegradedThresholdMs))
+	}
+	if in.P50ResponseTime != 0 {
+		const prefix string = ",\"p50ResponseTime\":"
+		out.RawString(prefix)
+		out.Int(int(in.P50ResponseTime))
+	}
+	if in.P90ResponseTime != 0 {
+		const prefix string = ",\"p90ResponseTime\":"
+		out.RawString(prefix)
+		out.Int(int(in.P90ResponseTime))
+	}
+	if in.P99ResponseTime != 0 {
+		const prefix string = ",\"p99ResponseTime\":"
+		out.RawString(prefix)
+		out.Int(int(in.P99ResponseTime))
+	}
+	if in.SampleRate != 0 {
+		const prefix string = ",\"sampleRate\":"
+		out.RawString(prefix)
+		out.Int(int(in.SampleRate))
+	}
+	if in.StoreHistory != nil {
+		const prefix string = ",\"storeHistory\":"
+		out.RawString(prefix)
+		out.Bool(bool(*in.StoreHistory))
+	}
+	if in.LastContentEncoding != "" {
+		const prefix string = ",\"lastContentEncoding\":"
+		out.RawString(prefix)
+		out.String(string(in.LastContentEncoding))
+	}
+	if in.LastProtocol != "" {
+		const prefix string = ",\"lastProtocol\":"
+		out.RawString(prefix)
+		out.String(string(in.LastProtocol))
+	}
+	if in.PreCheckURL != "" {
+		const prefix string = ",\"preCheckUrl\":"
+		out.RawString(prefix)
+		out.String(string(in.PreCheckURL))
+	}
+	if in.PreCheckMethod != "" {
+		const prefix string = ",\"preCheckMethod\":"
+		out.RawString(prefix)
+		out.String(string(in.PreCheckMethod))
+	}
+	if in.PreCheckExtract != "" {
+		const prefix string = ",\"preCheckExtract\":"
+		out.RawString(prefix)
+		out.String(string(in.PreCheckExtract))
+	}
+	if in.PreCheckTTL != 0 {
+		const prefix string = ",\"preCheckTtl\":"
+		out.RawString(prefix)
+		out.Int(int(in.PreCheckTTL))
+	}
+	if in.Certificate != nil {
+		const prefix string = ",\"certificate\":"
+		out.RawString(prefix)
+		easyjsonD2b7633eEncodeNanostatusNanostat6(out, *in.Certificate)
+	}
+	if in.LastError != "" {
+		const prefix string = ",\"lastError\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
+	}
+	if in.LastErrorCategory != "" {
+		const prefix string = ",\"lastErrorCategory\":"
+		out.RawString(prefix)
+		out.String(string(in.LastErrorCategory))
+	}
+	{
+		const prefix string = ",\"createdAt\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"updatedAt\":"
+		out.RawString(prefix)
+		out.Raw((in.UpdatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Monitor) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeNanostatusNanostat2(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Monitor) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeNanostatusNanostat2(l, v)
+}
+func easyjsonD2b7633eDecodeNanostatusNanostat3(in *jlexer.Lexer, out *CreateMonitorRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		case "url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.URL = string(in.String())
+			}
+		case "isThirdParty":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.IsThirdParty = bool(in.Bool())
+			}
+		case "icon":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Icon = string(in.String())
+			}
+		case "checkInterval":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.CheckInterval = int(in.Int())
+			}
+		case "timeoutSeconds":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.TimeoutSeconds = int(in.Int())
+			}
+		case "redirectPolicy":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RedirectPolicy = string(in.String())
+			}
+		case "maxRedirects":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxRedirects = int(in.Int())
+			}
+		case "followRedirects":
+			if in.IsNull() {
+				in.Skip()
+				out.FollowRedirects = nil
+			} else {
+				if out.FollowRedirects == nil {
+					out.FollowRedirects = new(bool)
+				}
+				*out.FollowRedirects = bool(in.Bool())
+			}
+		case "statusOutcomes":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.StatusOutcomes = string(in.String())
+			}
+		case "expectedStatusCodes":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExpectedStatusCodes = string(in.String())
+			}
+		case "minCacheAge":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MinCacheAge = int(in.Int())
+			}
+		case "maxCacheAge":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxCacheAge = int(in.Int())
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 string
+					v10 = string(in.String())
+					out.Tags = append(out.Tags, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "group":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Group = string(in.String())
+			}
+		case "endpoints":
+			if in.IsNull() {
+				in.Skip()
+				out.Endpoints = nil
+			} else {
+				in.Delim('[')
+				if out.Endpoints == nil {
+					if !in.IsDelim(']') {
+						out.Endpoints = make([]string, 0, 4)
+					} else {
+						out.Endpoints = []string{}
+					}
+				} else {
+					out.Endpoints = (out.Endpoints)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 string
+					v11 = string(in.String())
+					out.Endpoints = append(out.Endpoints, v11)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "parallelEndpoints":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ParallelEndpoints = bool(in.Bool())
+			}
+		case "gracePeriod":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.GracePeriod = int(in.Int())
+			}
+		case "escalateAfter":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.EscalateAfter = int(in.Int())
+			}
+		case "failureThreshold":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.FailureThreshold = int(in.Int())
+			}
+		case "retryCount":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RetryCount = int(in.Int())
+			}
+		case "retryDelayMs":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RetryDelayMs = int(in.Int())
+			}
+		case "type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Type = string(in.String())
+			}
+		case "heartbeatGrace":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.HeartbeatGrace = int(in.Int())
+			}
+		case "storeHeaders":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.StoreHeaders = bool(in.Bool())
+			}
+		case "lightCheck":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LightCheck = bool(in.Bool())
+			}
+		case "http3":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.HTTP3 = bool(in.Bool())
+			}
+		case "udpProbe":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UDPProbe = string(in.String())
+			}
+		case "udpExpect":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UDPExpect = string(in.String())
+			}
+		case "websocketPing":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.WebSocketPing = bool(in.Bool())
+			}
+		case "adaptiveInterval":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.AdaptiveInterval = bool(in.Bool())
+			}
+		case "minAdaptiveInterval":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MinAdaptiveInterval = int(in.Int())
+			}
+		case "maxAdaptiveInterval":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxAdaptiveInterval = int(in.Int())
+			}
+		case "versionHeader":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.VersionHeader = string(in.String())
+			}
+		case "extractLabel":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExtractLabel = string(in.String())
+			}
+		case "watchValidators":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.WatchValidators = bool(in.Bool())
+			}
+		case "watchCertificate":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.WatchCertificate = bool(in.Bool())
+			}
+		case "dependsOn":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.DependsOn = string(in.String())
+			}
+		case "method":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Method = string(in.String())
+			}
+		case "requestBody":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RequestBody = string(in.String())
+			}
+		case "contentType":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ContentType = string(in.String())
+			}
+		case "expectKeyword":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExpectKeyword = string(in.String())
+			}
+		case "expectKeywordAbsent":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExpectKeywordAbsent = bool(in.Bool())
+			}
+		case "maxBodyBytes":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxBodyBytes = int64(in.Int64())
+			}
+		case "jsonSchema":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.JSONSchema = string(in.String())
+			}
+		case "slaTarget":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.SLATarget = float64(in.Float64())
+			}
+		case "sourceIp":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.SourceIP = string(in.String())
+			}
+		case "dnsServer":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.DNSServer = string(in.String())
+			}
+		case "expectedIp":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExpectedIP = string(in.String())
+			}
+		case "autoRetireAfter":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.AutoRetireAfter = int(in.Int())
+			}
+		case "autoRetireAction":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.AutoRetireAction = string(in.String())
+			}
+		case "responseTimeMode":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ResponseTimeMode = string(in.String())
+			}
+		case "latencyWindow":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LatencyWindow = int(in.Int())
+			}
+		case "latencyThreshold":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.LatencyThreshold = int(in.Int())
+			}
+		case "degradedThresholdMs":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.DegradedThresholdMs = int(in.Int())
+			}
+		case "sampleRate":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.SampleRate = int(in.Int())
+			}
+		case "storeHistory":
+			if in.IsNull() {
+				in.Skip()
+				out.StoreHistory = nil
+			} else {
+				if out.StoreHistory == nil {
+					out.StoreHistory = new(bool)
+				}
+				*out.StoreHistory = bool(in.Bool())
+			}
+		case "preCheckUrl":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PreCheckURL = string(in.String())
+			}
+		case "preCheckMethod":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PreCheckMethod = string(in.String())
+			}
+		case "preCheckBody":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PreCheckBody = string(in.String())
+			}
+		case "preCheckExtract":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PreCheckExtract = string(in.String())
+			}
+		case "preCheckInject":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PreCheckInject = string(in.String())
+			}
+		case "preCheckTtl":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PreCheckTTL = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeNanostatusNanostat3(out *jwriter.Writer, in CreateMonitorRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	if in.IsThirdParty {
+		const prefix string = ",\"isThirdParty\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsThirdParty))
+	}
+	if in.Icon != "" {
+		const prefix string = ",\"icon\":"
+		out.RawString(prefix)
+		out.String(string(in.Icon))
+	}
+	if in.CheckInterval != 0 {
+		const prefix string = ",\"checkInterval\":"
+		out.RawString(prefix)
+		out.Int(int(in.CheckInterval))
+	}
+	if in.TimeoutSeconds != 0 {
+		const prefix string = ",\"timeoutSeconds\":"
+		out.RawString(prefix)
+		out.Int(int(in.TimeoutSeconds))
+	}
+	if in.RedirectPolicy != "" {
+		const prefix string = ",\"redirectPolicy\":"
+		out.RawString(prefix)
+		out.String(string(in.RedirectPolicy))
+	}
+	if in.MaxRedirects != 0 {
+		const prefix string = ",\"maxRedirects\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxRedirects))
+	}
+	if in.FollowRedirects != nil {
+		const prefix string = ",\"followRedirects\":"
+		out.RawString(prefix)
+		out.Bool(bool(*in.FollowRedirects))
+	}
+	if in.StatusOutcomes != "" {
+		const prefix string = ",\"statusOutcomes\":"
+		out.RawString(prefix)
+		out.String(string(in.StatusOutcomes))
+	}
+	if in.ExpectedStatusCodes != "" {
+		const prefix string = ",\"expectedStatusCodes\":"
+		out.RawString(prefix)
+		out.String(string(in.ExpectedStatusCodes))
+	}
+	if in.MinCacheAge != 0 {
+		const prefix string = ",\"minCacheAge\":"
+		out.RawString(prefix)
+		out.Int(int(in.MinCacheAge))
+	}
+	if in.MaxCacheAge != 0 {
+		const prefix string = ",\"maxCacheAge\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxCacheAge))
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v12, v13 := range in.Tags {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v13))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Group != "" {
+		const prefix string = ",\"group\":"
+		out.RawString(prefix)
+		out.String(string(in.Group))
+	}
+	if len(in.Endpoints) != 0 {
+		const prefix string = ",\"endpoints\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v14, v15 := range in.Endpoints {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v15))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.ParallelEndpoints {
+		const prefix string = ",\"parallelEndpoints\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.ParallelEndpoints))
+	}
+	if in.GracePeriod != 0 {
+		const prefix string = ",\"gracePeriod\":"
+		out.RawString(prefix)
+		out.Int(int(in.GracePeriod))
+	}
+	if in.EscalateAfter != 0 {
+		const prefix string = ",\"escalateAfter\":"
+		out.RawString(prefix)
+		out.Int(int(in.EscalateAfter))
+	}
+	if in.FailureThreshold != 0 {
+		const prefix string = ",\"failureThreshold\":"
+		out.RawString(prefix)
+		out.Int(int(in.FailureThreshold))
+	}
+	if in.RetryCount != 0 {
+		const prefix string = ",\"retryCount\":"
+		out.RawString(prefix)
+		out.Int(int(in.RetryCount))
+	}
+	if in.RetryDelayMs != 0 {
+		const prefix string = ",\"retryDelayMs\":"
+		out.RawString(prefix)
+		out.Int(int(in.RetryDelayMs))
+	}
+	if in.Type != "" {
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	if in.HeartbeatGrace != 0 {
+		const prefix string = ",\"heartbeatGrace\":"
+		out.RawString(prefix)
+		out.Int(int(in.HeartbeatGrace))
+	}
+	if in.StoreHeaders {
+		const prefix string = ",\"storeHeaders\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.StoreHeaders))
+	}
+	if in.LightCheck {
+		const prefix string = ",\"lightCheck\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.LightCheck))
+	}
+	if in.HTTP3 {
+		const prefix string = ",\"http3\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.HTTP3))
+	}
+	if in.UDPProbe != "" {
+		const prefix string = ",\"udpProbe\":"
+		out.RawString(prefix)
+		out.String(string(in.UDPProbe))
+	}
+	if in.UDPExpect != "" {
+		const prefix string = ",\"udpExpect\":"
+		out.RawString(prefix)
+		out.String(string(in.UDPExpect))
+	}
+	if in.WebSocketPing {
+		const prefix string = ",\"websocketPing\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.WebSocketPing))
+	}
+	if in.AdaptiveInterval {
+		const prefix string = ",\"adaptiveInterval\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.AdaptiveInterval))
+	}
+	if in.MinAdaptiveInterval != 0 {
+		const prefix string = ",\"minAdaptiveInterval\":"
+		out.RawString(prefix)
+		out.Int(int(in.MinAdaptiveInterval))
+	}
+	if in.MaxAdaptiveInterval != 0 {
+		const prefix string = ",\"maxAdaptiveInterval\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxAdaptiveInterval))
+	}
+	if in.VersionHeader != "" {
+		const prefix string = ",\"versionHeader\":"
+		out.RawString(prefix)
+		out.String(string(in.VersionHeader))
+	}
+	if in.ExtractLabel != "" {
+		const prefix string = ",\"extractLabel\":"
+		out.RawString(prefix)
+		out.String(string(in.ExtractLabel))
+	}
+	if in.WatchValidators {
+		const prefix string = ",\"watchValidators\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.WatchValidators))
+	}
+	if in.WatchCertificate {
+		const prefix string = ",\"watchCertificate\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.WatchCertificate))
+	}
+	if in.DependsOn != "" {
+		const prefix string = ",\"dependsOn\":"
+		out.RawString(prefix)
+		out.String(string(in.DependsOn))
+	}
+	if in.Method != "" {
+		const prefix string = ",\"method\":"
+		out.RawString(prefix)
+		out.String(string(in.Method))
+	}
+	if in.RequestBody != "" {
+		const prefix string = ",\"requestBody\":"
+		out.RawString(prefix)
+		out.String(string(in.RequestBody))
+	}
+	if in.ContentType != "" {
+		const prefix string = ",\"contentType\":"
+		out.RawString(prefix)
+		out.String(string(in.ContentType))
+	}
+	if in.ExpectKeyword != "" {
+		const prefix string = ",\"expectKeyword\":"
+		out.RawString(prefix)
+		out.String(string(in.ExpectKeyword))
+	}
+	if in.ExpectKeywordAbsent {
+		const prefix string = ",\"expectKeywordAbsent\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.ExpectKeywordAbsent))
+	}
+	if in.MaxBodyBytes != 0 {
+		const prefix string = ",\"maxBodyBytes\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MaxBodyBytes))
+	}
+	if in.JSONSchema != "" {
+		const prefix string = ",\"jsonSchema\":"
+		out.RawString(prefix)
+		out.String(string(in.JSONSchema))
+	}
+	if in.SLATarget != 0 {
+		const prefix string = ",\"slaTarget\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.SLATarget))
+	}
+	if in.SourceIP != "" {
+		const prefix string = ",\"sourceIp\":"
+		out.RawString(prefix)
+		out.String(string(in.SourceIP))
+	}
+	if in.DNSServer != "" {
+		const prefix string = ",\"dnsServer\":"
+		out.RawString(prefix)
+		out.String(string(in.DNSServer))
+	}
+	if in.ExpectedIP != "" {
+		const prefix string = ",\"expectedIp\":"
+		out.RawString(prefix)
+		out.String(string(in.ExpectedIP))
+	}
+	if in.AutoRetireAfter != 0 {
+		const prefix string = ",\"autoRetireAfter\":"
+		out.RawString(prefix)
+		out.Int(int(in.AutoRetireAfter))
+	}
+	if in.AutoRetireAction != "" {
+		const prefix string = ",\"autoRetireAction\":"
+		out.RawString(prefix)
+		out.String(string(in.AutoRetireAction))
+	}
+	if in.ResponseTimeMode != "" {
+		const prefix string = ",\"responseTimeMode\":"
+		out.RawString(prefix)
+		out.String(string(in.ResponseTimeMode))
+	}
+	if in.LatencyWindow != 0 {
+		const prefix string = ",\"latencyWindow\":"
+		out.RawString(prefix)
+		out.Int(int(in.LatencyWindow))
+	}
+	if in.LatencyThreshold != 0 {
+		const prefix string = ",\"latencyThreshold\":"
+		out.RawString(prefix)
+		out.Int(int(in.LatencyThreshold))
+	}
+	if in.DegradedThresholdMs != 0 {
+		const prefix string = ",\"degradedThresholdMs\":"
+		out.RawString(prefix)
+		out.Int(int(in.DegradedThresholdMs))
+	}
+	if in.SampleRate != 0 {
+		const prefix string = ",\"sampleRate\":"
+		out.RawString(prefix)
+		out.Int(int(in.SampleRate))
+	}
+	if in.StoreHistory != nil {
+		const prefix string = ",\"storeHistory\":"
+		out.RawString(prefix)
+		out.Bool(bool(*in.StoreHistory))
+	}
+	if in.PreCheckURL != "" {
+		const prefix string = ",\"preCheckUrl\":"
+		out.RawString(prefix)
+		out.String(string(in.PreCheckURL))
+	}
+	if in.PreCheckMethod != "" {
+		const prefix string = ",\"preCheckMethod\":"
+		out.RawString(prefix)
+		out.String(string(in.PreCheckMethod))
+	}
+	if in.PreCheckBody != "" {
+		const prefix string = ",\"preCheckBody\":"
+		out.RawString(prefix)
+		out.String(string(in.PreCheckBody))
+	}
+	if in.PreCheckExtract != "" {
+		const prefix string = ",\"preCheckExtract\":"
+		out.RawString(prefix)
+		out.String(string(in.PreCheckExtract))
+	}
+	if in.PreCheckInject != "" {
+		const prefix string = ",\"preCheckInject\":"
+		out.RawString(prefix)
+		out.String(string(in.PreCheckInject))
+	}
+	if in.PreCheckTTL != 0 {
+		const prefix string = ",\"preCheckTtl\":"
+		out.RawString(prefix)
+		out.Int(int(in.PreCheckTTL))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateMonitorRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeNanostatusNanostat3(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateMonitorRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeNanostatusNanostat3(l, v)
+}
+func easyjsonD2b7633eDecodeNanostatusNanostat4(in *jlexer.Lexer, out *CheckHistory) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "ID":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ID = uint(in.Uint())
+			}
+		case "MonitorID":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MonitorID = uint(in.Uint())
+			}
+		case "Status":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Status = string(in.String())
+			}
+		case "ResponseTime":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ResponseTime = int(in.Int())
+			}
+		case "CreatedAt":
 			if in.IsNull() {
 				in.Skip()
 			} else {
@@ -513,6 +2416,68 @@ func easyjsonD2b7633eDecodeNanostatusNanostat4(in *jlexer.Lexer, out *CheckHisto
 					in.AddError((out.CreatedAt).UnmarshalJSON(data))
 				}
 			}
+		case "CacheAge":
+			if in.IsNull() {
+				in.Skip()
+				out.CacheAge = nil
+			} else {
+				if out.CacheAge == nil {
+					out.CacheAge = new(int)
+				}
+				*out.CacheAge = int(in.Int())
+			}
+		case "ServerDate":
+			if in.IsNull() {
+				in.Skip()
+				out.ServerDate = nil
+			} else {
+				if out.ServerDate == nil {
+					out.ServerDate = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ServerDate).UnmarshalJSON(data))
+				}
+			}
+		case "Headers":
+			if in.IsNull() {
+				in.Skip()
+				out.Headers = nil
+			} else {
+				if out.Headers == nil {
+					out.Headers = new(string)
+				}
+				*out.Headers = string(in.String())
+			}
+		case "ErrorCategory":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ErrorCategory = string(in.String())
+			}
+		case "TTFB":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.TTFB = int(in.Int())
+			}
+		case "TotalTime":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.TotalTime = int(in.Int())
+			}
+		case "Label":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Label = string(in.String())
+			}
+		case "InMaintenance":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.InMaintenance = bool(in.Bool())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -552,6 +2517,58 @@ func easyjsonD2b7633eEncodeNanostatusNanostat4(out *jwriter.Writer, in CheckHist
 		out.RawString(prefix)
 		out.Raw((in.CreatedAt).MarshalJSON())
 	}
+	{
+		const prefix string = ",\"CacheAge\":"
+		out.RawString(prefix)
+		if in.CacheAge == nil {
+			out.RawString("null")
+		} else {
+			out.Int(int(*in.CacheAge))
+		}
+	}
+	{
+		const prefix string = ",\"ServerDate\":"
+		out.RawString(prefix)
+		if in.ServerDate == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.ServerDate).MarshalJSON())
+		}
+	}
+	{
+		const prefix string = ",\"Headers\":"
+		out.RawString(prefix)
+		if in.Headers == nil {
+			out.RawString("null")
+		} else {
+			out.String(string(*in.Headers))
+		}
+	}
+	{
+		const prefix string = ",\"ErrorCategory\":"
+		out.RawString(prefix)
+		out.String(string(in.ErrorCategory))
+	}
+	{
+		const prefix string = ",\"TTFB\":"
+		out.RawString(prefix)
+		out.Int(int(in.TTFB))
+	}
+	{
+		const prefix string = ",\"TotalTime\":"
+		out.RawString(prefix)
+		out.Int(int(in.TotalTime))
+	}
+	{
+		const prefix string = ",\"Label\":"
+		out.RawString(prefix)
+		out.String(string(in.Label))
+	}
+	{
+		const prefix string = ",\"InMaintenance\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.InMaintenance))
+	}
 	out.RawByte('}')
 }
 
@@ -564,3 +2581,350 @@ func (v CheckHistory) MarshalEasyJSON(w *jwriter.Writer) {
 func (v *CheckHistory) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonD2b7633eDecodeNanostatusNanostat4(l, v)
 }
+func easyjsonD2b7633eDecodeNanostatusNanostat5(in *jlexer.Lexer, out *EndpointResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.URL = string(in.String())
+			}
+		case "status":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Status = string(in.String())
+			}
+		case "responseTime":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ResponseTime = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeNanostatusNanostat5(out *jwriter.Writer, in EndpointResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.URL))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"responseTime\":"
+		out.RawString(prefix)
+		out.Int(int(in.ResponseTime))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EndpointResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeNanostatusNanostat5(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EndpointResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeNanostatusNanostat5(l, v)
+}
+func easyjsonD2b7633eDecodeNanostatusNanostat6(in *jlexer.Lexer, out *CertificateInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "subject":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Subject = string(in.String())
+			}
+		case "issuer":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Issuer = string(in.String())
+			}
+		case "sans":
+			if in.IsNull() {
+				in.Skip()
+				out.SANs = nil
+			} else {
+				in.Delim('[')
+				if out.SANs == nil {
+					if !in.IsDelim(']') {
+						out.SANs = make([]string, 0, 4)
+					} else {
+						out.SANs = []string{}
+					}
+				} else {
+					out.SANs = (out.SANs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v16 string
+					v16 = string(in.String())
+					out.SANs = append(out.SANs, v16)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "serialNumber":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.SerialNumber = string(in.String())
+			}
+		case "fingerprint":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Fingerprint = string(in.String())
+			}
+		case "notBefore":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.NotBefore).UnmarshalJSON(data))
+				}
+			}
+		case "notAfter":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.NotAfter).UnmarshalJSON(data))
+				}
+			}
+		case "keyAlgorithm":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.KeyAlgorithm = string(in.String())
+			}
+		case "keyBits":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.KeyBits = int(in.Int())
+			}
+		case "signatureAlgorithm":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.SignatureAlgorithm = string(in.String())
+			}
+		case "chainLength":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ChainLength = int(in.Int())
+			}
+		case "trusted":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Trusted = bool(in.Bool())
+			}
+		case "hostnameMatch":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.HostnameMatch = bool(in.Bool())
+			}
+		case "verifyError":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.VerifyError = string(in.String())
+			}
+		case "warnings":
+			if in.IsNull() {
+				in.Skip()
+				out.Warnings = nil
+			} else {
+				in.Delim('[')
+				if out.Warnings == nil {
+					if !in.IsDelim(']') {
+						out.Warnings = make([]string, 0, 4)
+					} else {
+						out.Warnings = []string{}
+					}
+				} else {
+					out.Warnings = (out.Warnings)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v17 string
+					v17 = string(in.String())
+					out.Warnings = append(out.Warnings, v17)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "checkedAt":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CheckedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD2b7633eEncodeNanostatusNanostat6(out *jwriter.Writer, in CertificateInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"subject\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Subject))
+	}
+	{
+		const prefix string = ",\"issuer\":"
+		out.RawString(prefix)
+		out.String(string(in.Issuer))
+	}
+	if len(in.SANs) != 0 {
+		const prefix string = ",\"sans\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v18, v19 := range in.SANs {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v19))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"serialNumber\":"
+		out.RawString(prefix)
+		out.String(string(in.SerialNumber))
+	}
+	{
+		const prefix string = ",\"fingerprint\":"
+		out.RawString(prefix)
+		out.String(string(in.Fingerprint))
+	}
+	{
+		const prefix string = ",\"notBefore\":"
+		out.RawString(prefix)
+		out.Raw((in.NotBefore).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"notAfter\":"
+		out.RawString(prefix)
+		out.Raw((in.NotAfter).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"keyAlgorithm\":"
+		out.RawString(prefix)
+		out.String(string(in.KeyAlgorithm))
+	}
+	if in.KeyBits != 0 {
+		const prefix string = ",\"keyBits\":"
+		out.RawString(prefix)
+		out.Int(int(in.KeyBits))
+	}
+	{
+		const prefix string = ",\"signatureAlgorithm\":"
+		out.RawString(prefix)
+		out.String(string(in.SignatureAlgorithm))
+	}
+	{
+		const prefix string = ",\"chainLength\":"
+		out.RawString(prefix)
+		out.Int(int(in.ChainLength))
+	}
+	{
+		const prefix string = ",\"trusted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Trusted))
+	}
+	{
+		const prefix string = ",\"hostnameMatch\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.HostnameMatch))
+	}
+	if in.VerifyError != "" {
+		const prefix string = ",\"verifyError\":"
+		out.RawString(prefix)
+		out.String(string(in.VerifyError))
+	}
+	if len(in.Warnings) != 0 {
+		const prefix string = ",\"warnings\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v20, v21 := range in.Warnings {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v21))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"checkedAt\":"
+		out.RawString(prefix)
+		out.Raw((in.CheckedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CertificateInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD2b7633eEncodeNanostatusNanostat6(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CertificateInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD2b7633eDecodeNanostatusNanostat6(l, v)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// enforceUniqueNames rejects monitors whose name collides (case-insensitively) with another monitor
+// Off by default so existing installs with duplicate names keep working
+var enforceUniqueNames = false
+
+// initNamePolicy configures name uniqueness enforcement from ENFORCE_UNIQUE_NAMES
+func initNamePolicy() {
+	enforceUniqueNames = getEnvBool("ENFORCE_UNIQUE_NAMES", false)
+	if enforceUniqueNames {
+		log.Info().Msg("[Config] Enforcing unique monitor names")
+	}
+}
+
+// monitorNameTaken reports whether another monitor (other than excludeID) already uses name,
+// ignoring case; always false unless ENFORCE_UNIQUE_NAMES is set
+func monitorNameTaken(name string, excludeID uint) bool {
+	if !enforceUniqueNames {
+		return false
+	}
+	var count int64
+	db.Model(&Monitor{}).Where("LOWER(name) = ? AND id <> ?", strings.ToLower(name), excludeID).Count(&count)
+	return count > 0
+}
+
+// writeFieldError writes a JSON error response that names the offending request field
+func writeFieldError(w http.ResponseWriter, status int, field, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(FieldErrorResponse{Field: field, Error: message}); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding field error")
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	notifyTimeout    = 5 * time.Second // Per attempt
+	notifyRetryDelay = 2 * time.Second // Before the single retry
+)
+
+// Alert colors: red for down, green for recovery, amber for everything else
+const (
+	notifyColorDown      = 0xD93F3F
+	notifyColorRecovered = 0x2EB67D
+	notifyColorOther     = 0xE8A317
+)
+
+// Notifier delivers alerts to an external chat service
+type Notifier interface {
+	Name() string // Channel name shown in notification previews, e.g. "slack"
+	Notify(alert MonitorAlert, monitor Monitor) error
+}
+
+// notifiers are the configured external channels; alerts always go to SSE clients as well
+var notifiers []Notifier
+
+// notifyClient posts webhook payloads; a hung webhook must not hold on to the alert goroutine
+var notifyClient = &http.Client{Timeout: notifyTimeout}
+
+// initNotifiers configures Slack and Discord notifications from SLACK_WEBHOOK_URL and DISCORD_WEBHOOK_URL
+func initNotifiers() {
+	notifiers = nil
+	if webhookURL := strings.TrimSpace(os.Getenv("SLACK_WEBHOOK_URL")); webhookURL != "" {
+		notifiers = append(notifiers, &SlackNotifier{WebhookURL: webhookURL})
+	}
+	if webhookURL := strings.TrimSpace(os.Getenv("DISCORD_WEBHOOK_URL")); webhookURL != "" {
+		notifiers = append(notifiers, &DiscordNotifier{WebhookURL: webhookURL})
+	}
+	for _, notifier := range notifiers {
+		log.Info().Str("channel", notifier.Name()).Msg("[Notify] Sending alerts to webhook")
+	}
+}
+
+// notificationChannels lists where alerts are delivered
+func notificationChannels() []string {
+	channels := []string{"sse"}
+	for _, notifier := range notifiers {
+		channels = append(channels, notifier.Name())
+	}
+	return channels
+}
+
+// notifyAll sends an alert through every configured notifier, logging failures
+func notifyAll(alert MonitorAlert, monitor Monitor) {
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(alert, monitor); err != nil {
+			log.Error().Err(err).Str("channel", notifier.Name()).Uint("monitor_id", monitor.ID).Str("kind", alert.Kind).
+				Msg("[Notify] Failed to deliver alert")
+		}
+	}
+}
+
+// alertColor returns the color of an alert's attachment or embed
+func alertColor(kind string) int {
+	switch kind {
+	case AlertDown:
+		return notifyColorDown
+	case AlertRecovered:
+		return notifyColorRecovered
+	}
+	return notifyColorOther
+}
+
+// alertTitle returns the headline of an alert
+func alertTitle(alert MonitorAlert) string {
+	switch alert.Kind {
+	case AlertDown:
+		return alert.Name + " is down"
+	case AlertRecovered:
+		return alert.Name + " has recovered"
+	}
+	return alert.Name + ": " + alert.Kind
+}
+
+// alertFields are the details shown below an alert's message
+func alertFields(alert MonitorAlert, monitor Monitor) [][2]string {
+	fields := [][2]string{
+		{"URL", monitor.URL},
+		{"Uptime (24h)", fmt.Sprintf("%.2f%%", monitor.Uptime)},
+	}
+	if alert.DownSeconds > 0 {
+		fields = append(fields, [2]string{"Down for", formatDowntime(alert.DownSeconds)})
+	}
+	return fields
+}
+
+// formatDowntime renders a number of seconds as e.g. "2h 5m" or "45s"
+func formatDowntime(seconds int64) string {
+	duration := time.Duration(seconds) * time.Second
+	if duration < time.Minute {
+		return duration.String()
+	}
+	return strings.TrimSuffix(duration.Round(time.Minute).String(), "0s")
+}
+
+// postNotification POSTs a JSON payload to a webhook, retrying once after network errors, 429 and 5xx
+func postNotification(webhookURL string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < 2; attempt++ {
+		if attempt > 0 {
+			time.Sleep(notifyRetryDelay)
+		}
+		resp, err := notifyClient.Post(webhookURL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			return lastErr // Retrying a rejected payload won't help
+		}
+	}
+	return lastErr
+}
+
+// SlackNotifier posts alerts to a Slack incoming webhook as a colored attachment
+type SlackNotifier struct {
+	WebhookURL string
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+type slackAttachment struct {
+	Fallback  string       `json:"fallback"`
+	Color     string       `json:"color"`
+	Title     string       `json:"title"`
+	TitleLink string       `json:"title_link,omitempty"`
+	Text      string       `json:"text"`
+	Fields    []slackField `json:"fields"`
+	Ts        int64        `json:"ts"`
+}
+
+type slackPayload struct {
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+func (n *SlackNotifier) Name() string {
+	return "slack"
+}
+
+func (n *SlackNotifier) Notify(alert MonitorAlert, monitor Monitor) error {
+	attachment := slackAttachment{
+		Fallback: alert.Message,
+		Color:    fmt.Sprintf("#%06X", alertColor(alert.Kind)),
+		Title:    alertTitle(alert),
+		Text:     alert.Message,
+		Ts:       time.Now().Unix(),
+	}
+	if isHTTPURL(monitor.URL) {
+		attachment.TitleLink = monitor.URL
+	}
+	for _, field := range alertFields(alert, monitor) {
+		attachment.Fields = append(attachment.Fields, slackField{Title: field[0], Value: field[1], Short: field[0] != "URL"})
+	}
+	return postNotification(n.WebhookURL, slackPayload{Attachments: []slackAttachment{attachment}})
+}
+
+// DiscordNotifier posts alerts to a Discord webhook as a colored embed
+type DiscordNotifier struct {
+	WebhookURL string
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	URL         string         `json:"url,omitempty"`
+	Description string         `json:"description"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields"`
+	Timestamp   string         `json:"timestamp"`
+}
+
+type discordPayload struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+func (n *DiscordNotifier) Name() string {
+	return "discord"
+}
+
+func (n *DiscordNotifier) Notify(alert MonitorAlert, monitor Monitor) error {
+	embed := discordEmbed{
+		Title:       alertTitle(alert),
+		Description: alert.Message,
+		Color:       alertColor(alert.Kind),
+		Timestamp:   alert.Time,
+	}
+	if isHTTPURL(monitor.URL) {
+		embed.URL = monitor.URL // Discord rejects embeds whose url isn't http(s)
+	}
+	for _, field := range alertFields(alert, monitor) {
+		embed.Fields = append(embed.Fields, discordField{Name: field[0], Value: field[1], Inline: field[0] != "URL"})
+	}
+	return postNotification(n.WebhookURL, discordPayload{Embeds: []discordEmbed{embed}})
+}
+
+// isHTTPURL reports whether a monitor URL can be linked from a chat message
+func isHTTPURL(rawURL string) bool {
+	scheme := urlScheme(rawURL)
+	return scheme == "http" || scheme == "https"
+}
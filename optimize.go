@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// OptimizeResponse reports what a database optimization did
+type OptimizeResponse struct {
+	Vacuumed       bool  `json:"vacuumed"`
+	SizeBefore     int64 `json:"sizeBefore"` // Bytes, database file plus WAL
+	SizeAfter      int64 `json:"sizeAfter"`
+	ReclaimedBytes int64 `json:"reclaimedBytes"`
+	DurationMs     int64 `json:"durationMs"`
+}
+
+// optimizeMu keeps scheduled and requested optimizations from running at the same time
+var optimizeMu sync.Mutex
+
+// databaseSize returns the size of the database file and its WAL
+func databaseSize() int64 {
+	var size int64
+	dbPath := getDBPath()
+	for _, path := range []string{dbPath, dbPath + "-wal"} {
+		if info, err := os.Stat(path); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+// optimizeDatabase runs PRAGMA optimize and, when vacuum is set, VACUUM to rebuild the file without free pages
+// The pool has a single connection, so every other query waits while VACUUM runs
+func optimizeDatabase(vacuum bool) (OptimizeResponse, error) {
+	optimizeMu.Lock()
+	defer optimizeMu.Unlock()
+
+	start := time.Now()
+	response := OptimizeResponse{SizeBefore: databaseSize()}
+
+	if err := db.Exec("PRAGMA optimize").Error; err != nil {
+		return response, fmt.Errorf("PRAGMA optimize failed: %w", err)
+	}
+	if vacuum {
+		if err := db.Exec("VACUUM").Error; err != nil {
+			return response, fmt.Errorf("VACUUM failed: %w", err)
+		}
+		response.Vacuumed = true
+	}
+	// Fold the WAL back into the database file so the new size is visible on disk
+	if err := db.Exec("PRAGMA wal_checkpoint(TRUNCATE)").Error; err != nil {
+		log.Warn().Err(err).Msg("[Database] WAL checkpoint after optimize failed")
+	}
+
+	response.SizeAfter = databaseSize()
+	response.ReclaimedBytes = response.SizeBefore - response.SizeAfter
+	response.DurationMs = time.Since(start).Milliseconds()
+	log.Info().Bool("vacuum", vacuum).Int64("size_before", response.SizeBefore).Int64("size_after", response.SizeAfter).
+		Int64("duration_ms", response.DurationMs).Msg("[Database] Optimized database")
+	return response, nil
+}
+
+// scheduledDatabaseMaintenance returns what the daily cleanup runs after deleting old data, from
+// DB_MAINTENANCE: "optimize", "vacuum" (which also optimizes) or off (default)
+func scheduledDatabaseMaintenance() (enabled bool, vacuum bool) {
+	switch mode := strings.ToLower(strings.TrimSpace(os.Getenv("DB_MAINTENANCE"))); mode {
+	case "", "off":
+		return false, false
+	case "optimize":
+		return true, false
+	case "vacuum":
+		return true, true
+	default:
+		log.Warn().Str("value", mode).Msg("[Config] Invalid DB_MAINTENANCE, expected optimize or vacuum - scheduled maintenance disabled")
+		return false, false
+	}
+}
+
+// apiAdminOptimize handles POST requests optimizing the database, with ?vacuum=true to also VACUUM it
+func apiAdminOptimize(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodPost {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !requireAPIKey(w, r) {
+		return
+	}
+
+	vacuum := false
+	if value := r.URL.Query().Get("vacuum"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Warn().Str("vacuum", value).Msg("[API] ERROR POST /api/admin/optimize: Invalid vacuum parameter")
+			http.Error(w, "vacuum must be true or false", http.StatusBadRequest)
+			return
+		}
+		vacuum = parsed
+	}
+
+	response, err := optimizeDatabase(vacuum)
+	if err != nil {
+		log.Error().Err(err).Msg("[API] ERROR POST /api/admin/optimize: Failed to optimize database")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Info().Bool("vacuum", vacuum).Int64("reclaimed", response.ReclaimedBytes).Msg("[API] POST /api/admin/optimize")
+	if err := encodeJSONWithCompression(w, r, response); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding optimize response")
+	}
+}
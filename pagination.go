@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// maxMonitorPageSize caps ?limit= on the monitor list
+const maxMonitorPageSize = 1000
+
+// monitorSortColumns maps the fields accepted by ?sort= to their columns; only these reach the ORDER BY
+var monitorSortColumns = map[string]string{
+	"id":           "id",
+	"name":         "name",
+	"url":          "url",
+	"status":       "status",
+	"uptime":       "uptime",
+	"responseTime": "response_time",
+	"group":        "monitor_group",
+	"createdAt":    "created_at",
+	"updatedAt":    "updated_at",
+}
+
+// MonitorPage is the parsed ?limit=, ?offset= and ?sort= of a monitor list request
+type MonitorPage struct {
+	Limit  int      // 0 returns every monitor
+	Offset int
+	Order  []string // ORDER BY clauses built from the allowlist, e.g. "uptime DESC"
+}
+
+// parseMonitorPage reads the pagination and sort parameters of a monitor list request
+// sort is a comma-separated list of fields, each optionally prefixed with - for descending, e.g. "-uptime,name"
+func parseMonitorPage(limit, offset, sort string) (MonitorPage, error) {
+	var page MonitorPage
+	if limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed < 1 || parsed > maxMonitorPageSize {
+			return page, fmt.Errorf("limit must be between 1 and %d", maxMonitorPageSize)
+		}
+		page.Limit = parsed
+	}
+	if offset != "" {
+		parsed, err := strconv.Atoi(offset)
+		if err != nil || parsed < 0 {
+			return page, fmt.Errorf("offset must be a non-negative number")
+		}
+		page.Offset = parsed
+	}
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		direction := "ASC"
+		if strings.HasPrefix(field, "-") {
+			field, direction = field[1:], "DESC"
+		}
+		column, ok := monitorSortColumns[field]
+		if !ok {
+			return page, fmt.Errorf("cannot sort by %q: sort must be id, name, url, status, uptime, responseTime, group, createdAt or updatedAt", field)
+		}
+		page.Order = append(page.Order, column+" "+direction)
+	}
+	return page, nil
+}
+
+// apply adds the page's ordering, offset and limit to a monitor query
+// Sorted pages end with the ID so monitors with equal values keep a stable order across pages
+func (p MonitorPage) apply(query *gorm.DB) *gorm.DB {
+	for _, order := range p.Order {
+		query = query.Order(order)
+	}
+	if len(p.Order) > 0 || p.Limit > 0 || p.Offset > 0 {
+		query = query.Order("id ASC")
+	}
+	if p.Limit > 0 {
+		query = query.Limit(p.Limit)
+	}
+	if p.Offset > 0 {
+		query = query.Offset(p.Offset)
+		if p.Limit == 0 {
+			query = query.Limit(-1) // SQLite requires a LIMIT with OFFSET
+		}
+	}
+	return query
+}
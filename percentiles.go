@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// latencySample is a response time and how many checks it stands for
+// Raw checks weigh 1; an hourly bucket contributes its average weighted by its check count
+type latencySample struct {
+	responseTime float64
+	weight       int64
+}
+
+// parsePercentileRange parses ?range= for fleet percentiles: 1h, 12h, 24h (default), 1w or a number of days such as 30d
+func parsePercentileRange(timeRange string) (time.Duration, string, error) {
+	switch timeRange {
+	case "":
+		return 24 * time.Hour, "24h", nil
+	case "1h":
+		return time.Hour, timeRange, nil
+	case "12h":
+		return 12 * time.Hour, timeRange, nil
+	case "24h":
+		return 24 * time.Hour, timeRange, nil
+	case "1w":
+		return 7 * 24 * time.Hour, timeRange, nil
+	}
+	days, err := parseDayRange(timeRange)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid range %q, expected 1h, 12h, 24h, 1w or a number of days such as 30d", timeRange)
+	}
+	return time.Duration(days) * 24 * time.Hour, fmt.Sprintf("%dd", days), nil
+}
+
+// weightedPercentile returns the nearest-rank percentile p (0-100) of samples sorted by response time
+func weightedPercentile(samples []latencySample, total int64, p float64) float64 {
+	rank := int64(math.Ceil(p / 100 * float64(total)))
+	if rank < 1 {
+		rank = 1
+	}
+	var seen int64
+	for _, sample := range samples {
+		seen += sample.weight
+		if seen >= rank {
+			return sample.responseTime
+		}
+	}
+	return samples[len(samples)-1].responseTime
+}
+
+// upCheckPercentiles computes the nearest-rank p50/p90/p99 response times of up checks since a time
+// SQLite has no percentile function, so each is read with an ordered LIMIT 1 OFFSET rank-1, which stays
+// exact for small samples (a single check is every percentile). scope restricts the monitors counted
+func upCheckPercentiles(since time.Time, scope string, args ...interface{}) (p50, p90, p99 int) {
+	where := "created_at > ? AND response_time > 0 AND status = ? AND " + scope
+	whereArgs := append([]interface{}{since, "up"}, args...)
+
+	var total int64
+	if err := db.Raw("SELECT COUNT(*) FROM check_histories WHERE "+where, whereArgs...).Row().Scan(&total); err != nil || total == 0 {
+		return 0, 0, 0
+	}
+
+	percentile := func(p float64) int {
+		rank := int64(math.Ceil(p / 100 * float64(total)))
+		if rank < 1 {
+			rank = 1
+		}
+		var responseTime int
+		db.Raw("SELECT response_time FROM check_histories WHERE "+where+" ORDER BY response_time ASC LIMIT 1 OFFSET ?",
+			append(whereArgs, rank-1)...).Row().Scan(&responseTime)
+		return responseTime
+	}
+	return percentile(50), percentile(90), percentile(99)
+}
+
+// getFleetPercentiles computes p50/p95/p99 response times across all unpaused monitors since a point in time
+// Raw checks that got a response are used where they exist; hours before a monitor's oldest raw check
+// come from the hourly buckets, which only keep an average, so those percentiles are approximate
+func getFleetPercentiles(since time.Time) PercentilesResponse {
+	var monitors []Monitor
+	db.Select("id").Where("paused = ?", false).Find(&monitors)
+
+	var response PercentilesResponse
+	var samples []latencySample
+	for _, monitor := range monitors {
+		var times []int
+		db.Model(&CheckHistory{}).
+			Where("monitor_id = ? AND created_at >= ? AND response_time > 0", monitor.ID, since).
+			Pluck("response_time", &times)
+		for _, responseTime := range times {
+			samples = append(samples, latencySample{responseTime: float64(responseTime), weight: 1})
+		}
+		response.RawChecks += int64(len(times))
+
+		// Hours before the oldest raw check only survive as buckets
+		rawStart := time.Now()
+		var first CheckHistory
+		if err := db.Select("id", "created_at").Where("monitor_id = ? AND created_at >= ?", monitor.ID, since).
+			Order("created_at ASC").Limit(1).Find(&first).Error; err == nil && first.ID != 0 {
+			rawStart = first.CreatedAt
+		}
+
+		var buckets []CheckHistoryBucket
+		db.Select("total_checks", "avg_response_time").
+			Where("monitor_id = ? AND bucket_hour >= ? AND bucket_hour < ? AND avg_response_time > 0",
+				monitor.ID, since.Unix(), rawStart.Truncate(time.Hour).Unix()).
+			Find(&buckets)
+		for _, bucket := range buckets {
+			samples = append(samples, latencySample{responseTime: bucket.AvgResponseTime, weight: int64(bucket.TotalChecks)})
+			response.BucketedChecks += int64(bucket.TotalChecks)
+		}
+
+		if len(times) > 0 || len(buckets) > 0 {
+			response.MonitorCount++
+		}
+	}
+
+	response.Approximate = response.BucketedChecks > 0
+	total := response.RawChecks + response.BucketedChecks
+	if total == 0 {
+		return response
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].responseTime < samples[j].responseTime })
+	response.P50 = weightedPercentile(samples, total, 50)
+	response.P95 = weightedPercentile(samples, total, 95)
+	response.P99 = weightedPercentile(samples, total, 99)
+	return response
+}
+
+// apiFleetPercentiles handles GET requests for response time percentiles across all unpaused monitors
+func apiFleetPercentiles(w http.ResponseWriter, r *http.Request) {
+	timeRange := r.URL.Query().Get("range")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("range", timeRange).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	window, label, err := parsePercentileRange(timeRange)
+	if err != nil {
+		log.Warn().Err(err).Str("range", timeRange).Msg("[API] ERROR GET /api/stats/percentiles: Invalid range")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	percentiles := getFleetPercentiles(time.Now().Add(-window))
+	percentiles.Range = label
+	log.Info().Float64("p50", percentiles.P50).Float64("p95", percentiles.P95).Float64("p99", percentiles.P99).
+		Int64("raw_checks", percentiles.RawChecks).Int64("bucketed_checks", percentiles.BucketedChecks).
+		Str("range", label).Msg("[API] GET /api/stats/percentiles")
+	if err := encodeJSONWithCompression(w, r, percentiles); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding percentiles")
+	}
+}
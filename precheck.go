@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DefaultPreCheckTTL    = 300                            // Seconds an extracted pre-check token is reused
+	DefaultPreCheckInject = "Authorization: Bearer {token}" // Header the token is sent in
+	maxPreCheckBodyBytes  = 1 << 20
+)
+
+// ErrorCategoryPreCheck marks checks that failed because the pre-check step failed
+const ErrorCategoryPreCheck = "precheck"
+
+// validatePreCheck checks a monitor's pre-check step settings
+func validatePreCheck(preCheckURL, method, extract, inject string, ttl int) error {
+	if preCheckURL == "" {
+		if extract != "" || inject != "" {
+			return fmt.Errorf("preCheckExtract and preCheckInject require preCheckURL")
+		}
+		return nil
+	}
+	if !strings.HasPrefix(preCheckURL, "http://") && !strings.HasPrefix(preCheckURL, "https://") {
+		return fmt.Errorf("preCheckURL must be an http or https URL")
+	}
+	switch strings.ToUpper(method) {
+	case "", http.MethodGet, http.MethodPost, http.MethodPut:
+	default:
+		return fmt.Errorf("preCheckMethod must be GET, POST, or PUT")
+	}
+	source, path, _ := strings.Cut(extract, ":")
+	if (source != "header" && source != "json") || path == "" {
+		return fmt.Errorf("preCheckExtract must be header:<name> or json:<path>")
+	}
+	if inject != "" {
+		name, value, found := strings.Cut(inject, ":")
+		if !found || strings.TrimSpace(name) == "" || !strings.Contains(value, "{token}") {
+			return fmt.Errorf("preCheckInject must look like \"Header-Name: value with {token}\"")
+		}
+	}
+	if ttl < 0 {
+		return fmt.Errorf("preCheckTTL must not be negative")
+	}
+	return nil
+}
+
+// preCheckToken is an extracted token and when it stops being reused
+type preCheckToken struct {
+	value     string
+	expiresAt time.Time
+	configKey string // Fingerprint of the pre-check settings the token was fetched with
+}
+
+// applyPreCheckSecrets sets a monitor's pre-check body and inject header from a request
+// They are never sent back, so an edit that leaves them empty keeps the stored ones; removing the
+// preCheckUrl clears them
+func applyPreCheckSecrets(monitor *Monitor, body, inject string) {
+	if monitor.PreCheckURL == "" {
+		monitor.PreCheckBody, monitor.PreCheckInject = "", ""
+		return
+	}
+	if body != "" {
+		monitor.PreCheckBody = body
+	}
+	if inject != "" {
+		monitor.PreCheckInject = inject
+	}
+}
+
+// PreCheckSecrets is a monitor's pre-check body and inject header, which monitor responses leave out
+// It's served by the admin API and carried in full backups
+type PreCheckSecrets struct {
+	MonitorID      uint   `gorm:"column:id;primaryKey" json:"monitorId"`
+	PreCheckBody   string `json:"preCheckBody,omitempty"`
+	PreCheckInject string `json:"preCheckInject,omitempty"`
+}
+
+// TableName reads the secrets straight from the monitors table
+func (PreCheckSecrets) TableName() string {
+	return "monitors"
+}
+
+// apiAdminMonitorPreCheck handles GET requests for a monitor's pre-check body and inject header
+func apiAdminMonitorPreCheck(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", id).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !requireAPIKey(w, r) {
+		return
+	}
+
+	monitorID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		log.Warn().Str("id", id).Msg("[API] ERROR GET /api/admin/monitor/precheck: Invalid monitor ID")
+		http.Error(w, "Monitor ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var secrets PreCheckSecrets
+	if err := db.First(&secrets, monitorID).Error; err != nil {
+		log.Warn().Str("id", id).Err(err).Msg("[API] ERROR GET /api/admin/monitor/precheck: Monitor not found")
+		http.Error(w, "Monitor not found", http.StatusNotFound)
+		return
+	}
+
+	log.Info().Str("id", id).Msg("[API] GET /api/admin/monitor/precheck")
+	if err := encodeJSONWithCompression(w, r, secrets); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding pre-check response")
+	}
+}
+
+// PreCheckCache caches pre-check tokens per monitor so the pre-step doesn't run on every check
+type PreCheckCache struct {
+	tokens map[uint]preCheckToken
+	mu     sync.Mutex
+}
+
+var preCheckCache = &PreCheckCache{tokens: make(map[uint]preCheckToken)}
+
+// invalidate drops a monitor's cached token, e.g. after the service rejected it
+func (c *PreCheckCache) invalidate(monitorID uint) {
+	c.mu.Lock()
+	delete(c.tokens, monitorID)
+	c.mu.Unlock()
+}
+
+// preCheckConfigKey fingerprints the pre-check settings so edits invalidate cached tokens
+func preCheckConfigKey(monitor *Monitor) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		monitor.PreCheckURL, monitor.PreCheckMethod, monitor.PreCheckBody, monitor.PreCheckExtract,
+	}, "\x00")))
+	return hex.EncodeToString(hash[:8])
+}
+
+// preCheckHeader runs the monitor's pre-check step (or reuses its cached token) and returns the
+// header to inject into the real check; nil when the monitor has no pre-check
+func preCheckHeader(monitor *Monitor) (http.Header, error) {
+	if monitor.PreCheckURL == "" {
+		return nil, nil
+	}
+
+	token, err := preCheckCache.get(monitor)
+	if err != nil {
+		return nil, err
+	}
+
+	inject := monitor.PreCheckInject
+	if inject == "" {
+		inject = DefaultPreCheckInject
+	}
+	name, value, _ := strings.Cut(inject, ":")
+	header := http.Header{}
+	header.Set(strings.TrimSpace(name), strings.ReplaceAll(strings.TrimSpace(value), "{token}", token))
+	return header, nil
+}
+
+// get returns the monitor's cached token, fetching a new one when missing or expired
+func (c *PreCheckCache) get(monitor *Monitor) (string, error) {
+	configKey := preCheckConfigKey(monitor)
+	now := time.Now()
+
+	c.mu.Lock()
+	cached, exists := c.tokens[monitor.ID]
+	c.mu.Unlock()
+	if exists && cached.configKey == configKey && now.Before(cached.expiresAt) {
+		return cached.value, nil
+	}
+
+	value, validity, err := runPreCheck(monitor)
+	if err != nil {
+		c.invalidate(monitor.ID)
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.tokens[monitor.ID] = preCheckToken{value: value, expiresAt: now.Add(validity), configKey: configKey}
+	c.mu.Unlock()
+	log.Debug().Uint("monitor_id", monitor.ID).Dur("valid_for", validity).Msg("[Check] Fetched pre-check token")
+	return value, nil
+}
+
+// runPreCheck performs the pre-check request and extracts the token and how long to reuse it
+// A JSON expires_in field shortens the configured TTL, with a small safety margin
+func runPreCheck(monitor *Monitor) (string, time.Duration, error) {
+	method := strings.ToUpper(monitor.PreCheckMethod)
+	if method == "" {
+		method = http.MethodGet
+		if monitor.PreCheckBody != "" {
+			method = http.MethodPost
+		}
+	}
+
+	var body io.Reader
+	if monitor.PreCheckBody != "" {
+		body = strings.NewReader(monitor.PreCheckBody)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), monitor.checkTimeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, monitor.PreCheckURL, body)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid pre-check request: %w", err)
+	}
+	req.Header.Set("User-Agent", "NanoStatus/1.0")
+	if monitor.PreCheckBody != "" {
+		req.Header.Set("Content-Type", requestBodyContentType(monitor.PreCheckBody))
+	}
+
+	resp, err := clientForMonitor(monitor, false).Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("pre-check request failed: %s", errorMessage(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", 0, fmt.Errorf("pre-check returned HTTP %d", resp.StatusCode)
+	}
+
+	ttl := monitor.PreCheckTTL
+	if ttl <= 0 {
+		ttl = DefaultPreCheckTTL
+	}
+	validity := time.Duration(ttl) * time.Second
+
+	source, path, _ := strings.Cut(monitor.PreCheckExtract, ":")
+	if source == "header" {
+		value := strings.TrimSpace(resp.Header.Get(path))
+		if value == "" {
+			return "", 0, fmt.Errorf("pre-check response has no %s header", path)
+		}
+		return value, validity, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPreCheckBodyBytes))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read pre-check response: %w", err)
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return "", 0, fmt.Errorf("pre-check response is not valid JSON: %w", err)
+	}
+	value, ok := lookupJSONPath(document, path)
+	if !ok {
+		return "", 0, fmt.Errorf("pre-check response has no %q field", path)
+	}
+	token := jsonScalarString(value)
+	if token == "" {
+		return "", 0, fmt.Errorf("pre-check field %q is empty", path)
+	}
+
+	if expiresIn, ok := lookupJSONPath(document, "expires_in"); ok {
+		if seconds, ok := expiresIn.(float64); ok && seconds > 0 {
+			tokenValidity := time.Duration(seconds*0.9) * time.Second
+			if tokenValidity < validity {
+				validity = tokenValidity
+			}
+		}
+	}
+
+	return token, validity, nil
+}
+
+// lookupJSONPath resolves a dot-separated path such as "data.items.0.token" in a decoded JSON document
+func lookupJSONPath(document interface{}, path string) (interface{}, bool) {
+	current := document
+	for _, part := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, exists := node[part]
+			if !exists {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// jsonScalarString formats a decoded JSON scalar as a string (empty for objects, arrays and null)
+func jsonScalarString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
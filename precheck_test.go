@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPreCheckSecretsNotSerialized(t *testing.T) {
+	monitor := Monitor{Name: "api", PreCheckURL: "https://example.com/login",
+		PreCheckBody: `{"password":"hunter2"}`, PreCheckInject: "X-Token: secret {token}"}
+	data, err := json.Marshal(monitor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "hunter2") || strings.Contains(string(data), "X-Token") {
+		t.Errorf("monitor JSON carries pre-check credentials: %s", data)
+	}
+}
+
+func TestApplyPreCheckSecrets(t *testing.T) {
+	monitor := Monitor{PreCheckURL: "https://example.com/login", PreCheckBody: "user=a&pass=b", PreCheckInject: "X-Token: {token}"}
+
+	// Edits from the dashboard never see the credentials, so empty values keep them
+	applyPreCheckSecrets(&monitor, "", "")
+	if monitor.PreCheckBody != "user=a&pass=b" || monitor.PreCheckInject != "X-Token: {token}" {
+		t.Errorf("empty update replaced the credentials: %q, %q", monitor.PreCheckBody, monitor.PreCheckInject)
+	}
+	applyPreCheckSecrets(&monitor, "user=a&pass=c", "")
+	if monitor.PreCheckBody != "user=a&pass=c" {
+		t.Errorf("body = %q, want the new one", monitor.PreCheckBody)
+	}
+
+	monitor.PreCheckURL = ""
+	applyPreCheckSecrets(&monitor, "", "")
+	if monitor.PreCheckBody != "" || monitor.PreCheckInject != "" {
+		t.Error("removing preCheckUrl kept the credentials")
+	}
+}
+
+func TestAdminMonitorPreCheck(t *testing.T) {
+	db := newTestDB(t)
+	monitor := Monitor{Name: "api", PreCheckURL: "https://example.com/login", PreCheckBody: `{"password":"hunter2"}`}
+	if err := db.Create(&monitor).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("API_KEY", "secret")
+
+	rec := httptest.NewRecorder()
+	apiAdminMonitorPreCheck(rec, httptest.NewRequest(http.MethodGet, "/api/admin/monitor/precheck?id=1", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("request without the API key = %d, want 401", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/monitor/precheck?id=1", nil)
+	req.Header.Set("X-API-Key", "secret")
+	rec = httptest.NewRecorder()
+	apiAdminMonitorPreCheck(rec, req)
+	var secrets PreCheckSecrets
+	if err := json.Unmarshal(rec.Body.Bytes(), &secrets); err != nil {
+		t.Fatalf("status %d, body %q: %v", rec.Code, rec.Body.String(), err)
+	}
+	if secrets.MonitorID != monitor.ID || secrets.PreCheckBody != monitor.PreCheckBody {
+		t.Errorf("secrets = %+v, want the monitor's pre-check body", secrets)
+	}
+}
+
+func TestBackupKeepsPreCheckSecrets(t *testing.T) {
+	db := newTestDB(t)
+	monitor := Monitor{Name: "api", URL: "https://example.com", PreCheckURL: "https://example.com/login",
+		PreCheckBody: `{"password":"hunter2"}`, PreCheckInject: "X-Token: {token}"}
+	if err := db.Create(&monitor).Error; err != nil {
+		t.Fatal(err)
+	}
+	var backup bytes.Buffer
+	if _, err := writeBackup(&backup); err != nil {
+		t.Fatal(err)
+	}
+
+	restoreDB := newTestDB(t)
+	importer := &backupImporter{
+		mode:     ImportModeReplace,
+		monitors: make(map[uint]uint),
+		response: ImportResponse{Imported: make(map[string]int), Skipped: make(map[string]int)},
+	}
+	importer.tx = restoreDB
+	if err := importer.run(&backup); err != nil {
+		t.Fatal(err)
+	}
+	var restored Monitor
+	if err := restoreDB.First(&restored, monitor.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if restored.PreCheckBody != monitor.PreCheckBody || restored.PreCheckInject != monitor.PreCheckInject {
+		t.Errorf("restored credentials = %q, %q; want the backed up ones", restored.PreCheckBody, restored.PreCheckInject)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// trustedProxies are the reverse proxies (TRUSTED_PROXIES) allowed to report the client IP
+// via X-Forwarded-For / X-Real-IP; when empty those headers are ignored
+var trustedProxies []netip.Prefix
+
+// initTrustedProxies parses TRUSTED_PROXIES, a comma-separated list of IPs and CIDR ranges
+func initTrustedProxies() {
+	trustedProxies = nil
+	for _, entry := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			trustedProxies = append(trustedProxies, prefix.Masked())
+			continue
+		}
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			addr = addr.Unmap()
+			trustedProxies = append(trustedProxies, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		log.Warn().Str("entry", entry).Msg("[Config] Ignoring invalid TRUSTED_PROXIES entry")
+	}
+
+	if len(trustedProxies) > 0 {
+		log.Info().Int("count", len(trustedProxies)).Msg("[Config] Trusting forwarded client IPs from configured proxies")
+	}
+}
+
+// isTrustedProxy reports whether ip belongs to a configured trusted proxy
+func isTrustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the IP of the client that made the request
+// Forwarding headers are only honored when the direct peer is a trusted proxy; X-Forwarded-For
+// is walked from the right, skipping trusted proxies, so clients can't spoof their address by
+// prepending entries of their own
+func clientIP(r *http.Request) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if len(trustedProxies) == 0 || !isTrustedProxy(remote) {
+		return remote
+	}
+
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+		client := ""
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if _, err := netip.ParseAddr(hop); err != nil {
+				break // Malformed entry - don't trust anything to its left
+			}
+			client = hop
+			if !isTrustedProxy(hop) {
+				break
+			}
+		}
+		if client != "" {
+			return client
+		}
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+		if _, err := netip.ParseAddr(realIP); err == nil {
+			return realIP
+		}
+	}
+
+	return remote
+}
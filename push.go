@@ -0,0 +1,241 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Monitor types
+const (
+	MonitorTypePoll = ""     // Default: the checker requests the monitor's URL
+	MonitorTypePush = "push" // The monitored workload sends heartbeats instead of being polled
+)
+
+// ErrorCategoryHeartbeat marks push monitor checks whose heartbeat didn't arrive in time
+const ErrorCategoryHeartbeat = "heartbeat"
+
+const (
+	DefaultHeartbeatGrace = 30    // Seconds a heartbeat may be late when a push monitor sets no HeartbeatGrace
+	maxHeartbeatGrace     = 86400 // Caps HeartbeatGrace
+)
+
+// pushHeartbeat is passed to checkService when a push monitor's heartbeat arrives
+type pushHeartbeat struct {
+	monitorID uint
+}
+
+// validateMonitorType checks a monitor's type (empty polls the URL, push waits for heartbeats)
+func validateMonitorType(monitorType string) error {
+	if monitorType != MonitorTypePoll && monitorType != MonitorTypePush {
+		return fmt.Errorf("type must be empty or push")
+	}
+	return nil
+}
+
+// validateHeartbeatGrace checks how many seconds a push monitor's heartbeat may be late (0 uses the default)
+func validateHeartbeatGrace(grace int) error {
+	if grace < 0 || grace > maxHeartbeatGrace {
+		return fmt.Errorf("heartbeatGrace must be between 0 and %d", maxHeartbeatGrace)
+	}
+	return nil
+}
+
+// isPush reports whether the monitor waits for heartbeats instead of polling its URL
+func (m *Monitor) isPush() bool {
+	return m.Type == MonitorTypePush
+}
+
+// ensureHeartbeatToken gives a push monitor without one a random heartbeat token
+func (m *Monitor) ensureHeartbeatToken() {
+	if m.isPush() && m.HeartbeatToken == "" {
+		m.HeartbeatToken = rand.Text()
+	}
+}
+
+// ensureHeartbeatTokens issues tokens to stored push monitors that have none, e.g. ones created before
+// tokens existed or restored from a backup
+func ensureHeartbeatTokens() {
+	var monitors []Monitor
+	if err := db.Where("monitor_type = ? AND (heartbeat_token IS NULL OR heartbeat_token = '')", MonitorTypePush).Find(&monitors).Error; err != nil {
+		log.Error().Err(err).Msg("[Database] Failed to load push monitors without a heartbeat token")
+		return
+	}
+	for i := range monitors {
+		monitors[i].ensureHeartbeatToken()
+		if err := db.Model(&monitors[i]).UpdateColumn("heartbeat_token", monitors[i].HeartbeatToken).Error; err != nil {
+			log.Error().Err(err).Uint("id", monitors[i].ID).Msg("[Database] Failed to store heartbeat token")
+			continue
+		}
+		log.Info().Uint("id", monitors[i].ID).Str("name", monitors[i].Name).Msg("[Database] Issued heartbeat token to push monitor")
+	}
+}
+
+// heartbeatGrace returns how long a heartbeat may be late before the monitor counts as down
+func (m *Monitor) heartbeatGrace() time.Duration {
+	if m.HeartbeatGrace <= 0 {
+		return DefaultHeartbeatGrace * time.Second
+	}
+	return time.Duration(m.HeartbeatGrace) * time.Second
+}
+
+// heartbeatDeadline returns when the next heartbeat is due at the latest: one interval plus the grace
+// after the last heartbeat, or after the monitor was created (or started warming) if none arrived yet
+func (m *Monitor) heartbeatDeadline() time.Time {
+	since := m.CreatedAt
+	if m.GraceStartedAt != nil && m.GraceStartedAt.After(since) {
+		since = *m.GraceStartedAt
+	}
+	if m.LastHeartbeatAt != nil && m.LastHeartbeatAt.After(since) {
+		since = *m.LastHeartbeatAt
+	}
+	interval := m.CheckInterval
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+	return since.Add(time.Duration(interval)*time.Second + m.heartbeatGrace())
+}
+
+// checkPush replaces polling for push monitors. A heartbeat is recorded as an up check; scheduled runs
+// only record a down check once the heartbeat is overdue, so due is false while it is still on time
+func checkPush(monitor *Monitor, heartbeat bool, now time.Time) (result endpointCheck, due bool) {
+	result = endpointCheck{EndpointResult: EndpointResult{URL: monitor.URL, Status: "up"}}
+	if heartbeat {
+		return result, true
+	}
+
+	deadline := monitor.heartbeatDeadline()
+	if now.Before(deadline) {
+		return result, false
+	}
+	result.Status = "down"
+	result.ErrorCategory = ErrorCategoryHeartbeat
+	if monitor.LastHeartbeatAt == nil {
+		result.Error = ErrorCategoryHeartbeat + ": no heartbeat received yet"
+	} else {
+		late := int64(now.Sub(*monitor.LastHeartbeatAt).Seconds())
+		result.Error = ErrorCategoryHeartbeat + ": no heartbeat for " + formatDowntime(late)
+	}
+	return result, true
+}
+
+// HeartbeatResponse acknowledges a push monitor's heartbeat
+type HeartbeatResponse struct {
+	MonitorID  uint   `json:"monitorId"`
+	ReceivedAt string `json:"receivedAt"` // ISO 8601
+	ExpectedBy string `json:"expectedBy"` // ISO 8601, latest time for the next heartbeat including the grace
+}
+
+// apiHeartbeat handles POST /api/heartbeat/<token> from push monitors reporting that they are alive
+// The token authenticates the heartbeat, so the path is exempt from API_KEY and is never logged
+func apiHeartbeat(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", "/api/heartbeat/").Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodPost {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// A monitor that stops being a push monitor keeps its token, so match the type as well
+	token := strings.TrimPrefix(r.URL.Path, "/api/heartbeat/")
+	var monitor Monitor
+	if token == "" || db.Where("heartbeat_token = ? AND monitor_type = ?", token, MonitorTypePush).First(&monitor).Error != nil {
+		log.Warn().Str("client_ip", clientIP(r)).Msg("[API] ERROR POST /api/heartbeat: Unknown heartbeat token")
+		http.Error(w, "Monitor not found", http.StatusNotFound)
+		return
+	}
+	id := strconv.FormatUint(uint64(monitor.ID), 10)
+
+	now := time.Now()
+	if err := db.Model(&monitor).UpdateColumn("last_heartbeat_at", now).Error; err != nil {
+		log.Error().Err(err).Str("id", id).Msg("[API] ERROR POST /api/heartbeat: Failed to record heartbeat")
+		http.Error(w, "Failed to record heartbeat", http.StatusInternalServerError)
+		return
+	}
+	monitor.LastHeartbeatAt = &now
+
+	// Paused monitors keep their last heartbeat, so resuming doesn't mark them down right away
+	if !monitor.Paused {
+		checkService(pushHeartbeat{monitorID: monitor.ID})
+	}
+
+	response := HeartbeatResponse{
+		MonitorID:  monitor.ID,
+		ReceivedAt: now.UTC().Format(time.RFC3339),
+		ExpectedBy: monitor.heartbeatDeadline().UTC().Format(time.RFC3339),
+	}
+	log.Info().Str("id", id).Str("name", monitor.Name).Msg("[API] POST /api/heartbeat")
+	if err := encodeJSONWithCompression(w, r, response); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding heartbeat response")
+	}
+}
+
+// HeartbeatTokenResponse carries a push monitor's heartbeat token and the path to send heartbeats to
+type HeartbeatTokenResponse struct {
+	MonitorID uint   `json:"monitorId"`
+	Token     string `json:"token"`
+	Path      string `json:"path"` // POST here to report a heartbeat
+}
+
+// apiMonitorHeartbeatToken handles POST requests for a push monitor's heartbeat token; rotate=true
+// replaces it, so heartbeats sent with the old one get 404. It's a POST so that API_KEY protects it
+func apiMonitorHeartbeatToken(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", id).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodPost {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	monitorID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		log.Warn().Str("id", id).Msg("[API] ERROR POST /api/monitor/heartbeat-token: Invalid monitor ID")
+		http.Error(w, "Monitor ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var monitor Monitor
+	if err := db.First(&monitor, monitorID).Error; err != nil {
+		log.Warn().Str("id", id).Err(err).Msg("[API] ERROR POST /api/monitor/heartbeat-token: Monitor not found")
+		http.Error(w, "Monitor not found", http.StatusNotFound)
+		return
+	}
+	if !monitor.isPush() {
+		log.Warn().Str("id", id).Msg("[API] ERROR POST /api/monitor/heartbeat-token: Not a push monitor")
+		http.Error(w, "Monitor is not a push monitor", http.StatusBadRequest)
+		return
+	}
+
+	rotate := r.URL.Query().Get("rotate") == "true"
+	if rotate || monitor.HeartbeatToken == "" {
+		monitor.HeartbeatToken = ""
+		monitor.ensureHeartbeatToken()
+		if err := db.Model(&monitor).UpdateColumn("heartbeat_token", monitor.HeartbeatToken).Error; err != nil {
+			log.Error().Err(err).Str("id", id).Msg("[API] ERROR POST /api/monitor/heartbeat-token: Failed to store token")
+			http.Error(w, "Failed to store heartbeat token", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	response := HeartbeatTokenResponse{
+		MonitorID: monitor.ID,
+		Token:     monitor.HeartbeatToken,
+		Path:      "/api/heartbeat/" + monitor.HeartbeatToken,
+	}
+	log.Info().Str("id", id).Bool("rotated", rotate).Msg("[API] POST /api/monitor/heartbeat-token")
+	if err := encodeJSONWithCompression(w, r, response); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding heartbeat token response")
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEnsureHeartbeatToken(t *testing.T) {
+	poll := Monitor{}
+	poll.ensureHeartbeatToken()
+	if poll.HeartbeatToken != "" {
+		t.Error("poll monitor got a heartbeat token")
+	}
+
+	push := Monitor{Type: MonitorTypePush}
+	push.ensureHeartbeatToken()
+	token := push.HeartbeatToken
+	if len(token) < 26 {
+		t.Fatalf("heartbeat token %q is too short", token)
+	}
+	push.ensureHeartbeatToken()
+	if push.HeartbeatToken != token {
+		t.Error("existing heartbeat token was replaced")
+	}
+	other := Monitor{Type: MonitorTypePush}
+	other.ensureHeartbeatToken()
+	if other.HeartbeatToken == token {
+		t.Error("two monitors got the same heartbeat token")
+	}
+}
+
+func TestEnsureHeartbeatTokensBackfillsPushMonitors(t *testing.T) {
+	db := newTestDB(t)
+	monitors := []Monitor{{Name: "push", Type: MonitorTypePush}, {Name: "poll", URL: "https://example.com"}}
+	if err := db.Create(&monitors).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	ensureHeartbeatTokens()
+
+	var push, poll Monitor
+	db.First(&push, monitors[0].ID)
+	db.First(&poll, monitors[1].ID)
+	if push.HeartbeatToken == "" {
+		t.Error("push monitor has no heartbeat token after backfill")
+	}
+	if poll.HeartbeatToken != "" {
+		t.Error("poll monitor got a heartbeat token")
+	}
+}
+
+func TestHeartbeatByToken(t *testing.T) {
+	db := newTestDB(t)
+	// Paused, so the heartbeat is recorded without running a check
+	monitor := Monitor{Name: "cron", Type: MonitorTypePush, Paused: true}
+	monitor.ensureHeartbeatToken()
+	if err := db.Create(&monitor).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("API_KEY", "secret")
+	handler := requireAPIKeyForWrites(http.HandlerFunc(apiHeartbeat))
+
+	for _, path := range []string{"/api/heartbeat/", "/api/heartbeat/wrong", "/api/heartbeat/" + monitor.HeartbeatToken + "x"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("POST %s = %d, want 404", path, rec.Code)
+		}
+	}
+
+	// No API key needed: the token authenticates the heartbeat
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/heartbeat/"+monitor.HeartbeatToken, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("heartbeat with a valid token = %d, want 200", rec.Code)
+	}
+	var stored Monitor
+	db.First(&stored, monitor.ID)
+	if stored.LastHeartbeatAt == nil {
+		t.Error("heartbeat was not recorded")
+	}
+
+	// The token no longer works once the monitor stops being a push monitor
+	db.Model(&stored).UpdateColumn("monitor_type", MonitorTypePoll)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/heartbeat/"+monitor.HeartbeatToken, nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("heartbeat to a former push monitor = %d, want 404", rec.Code)
+	}
+}
+
+func TestHeartbeatTokenEndpoint(t *testing.T) {
+	db := newTestDB(t)
+	monitor := Monitor{Name: "cron", Type: MonitorTypePush}
+	if err := db.Create(&monitor).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("API_KEY", "secret")
+	handler := requireAPIKeyForWrites(http.HandlerFunc(apiMonitorHeartbeatToken))
+	tokenRequest := func(query string, withKey bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/monitor/heartbeat-token?"+query, nil)
+		if withKey {
+			req.Header.Set("Authorization", "Bearer secret")
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := tokenRequest("id=1", false); rec.Code != http.StatusUnauthorized {
+		t.Errorf("token request without the API key = %d, want 401", rec.Code)
+	}
+	if rec := tokenRequest("id=1", true); rec.Code != http.StatusOK {
+		t.Fatalf("token request = %d, want 200", rec.Code)
+	}
+	var stored Monitor
+	db.First(&stored, monitor.ID)
+	first := stored.HeartbeatToken
+	if first == "" {
+		t.Fatal("token request didn't store a token")
+	}
+
+	tokenRequest("id=1", true)
+	db.First(&stored, monitor.ID)
+	if stored.HeartbeatToken != first {
+		t.Error("token changed without rotate=true")
+	}
+	tokenRequest("id=1&rotate=true", true)
+	db.First(&stored, monitor.ID)
+	if stored.HeartbeatToken == first || stored.HeartbeatToken == "" {
+		t.Error("rotate=true didn't issue a new token")
+	}
+
+	poll := Monitor{Name: "site", URL: "https://example.com"}
+	db.Create(&poll)
+	if rec := tokenRequest("id=2", true); rec.Code != http.StatusBadRequest {
+		t.Errorf("token request for a poll monitor = %d, want 400", rec.Code)
+	}
+}
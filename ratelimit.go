@@ -0,0 +1,120 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// rateLimitIdleTTL is how long a client's bucket is kept after its last request; a full bucket
+// behaves exactly like a missing one, so idle clients lose nothing when they're swept
+const rateLimitIdleTTL = 10 * time.Minute
+
+// RateLimiter is a per-client-IP token bucket for requests that change state
+// Each client gets Burst tokens, refilled at RPS tokens per second; a request spends one
+type RateLimiter struct {
+	RPS     float64
+	Burst   float64
+	buckets map[string]*rateBucket
+	mu      sync.Mutex
+}
+
+type rateBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// rateLimiter is nil while RATE_LIMIT_RPS is unset, which disables rate limiting
+var rateLimiter *RateLimiter
+
+// initRateLimiter reads RATE_LIMIT_RPS and RATE_LIMIT_BURST and starts sweeping idle clients
+func initRateLimiter() {
+	rateLimiter = nil
+	rps := getEnvFloat("RATE_LIMIT_RPS", 0)
+	if rps <= 0 {
+		return
+	}
+	burst := getEnvInt("RATE_LIMIT_BURST", max(1, int(math.Ceil(rps))))
+	if burst < 1 {
+		log.Warn().Int("burst", burst).Msg("[Config] RATE_LIMIT_BURST must be at least 1, using 1")
+		burst = 1
+	}
+
+	rateLimiter = &RateLimiter{RPS: rps, Burst: float64(burst), buckets: make(map[string]*rateBucket)}
+	log.Info().Float64("rps", rps).Int("burst", burst).Msg("[Config] Rate limiting non-GET /api requests per client IP")
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			rateLimiter.sweep(now)
+		}
+	}()
+}
+
+// allow spends a token of ip's bucket; when the bucket is empty it returns false and how long
+// until the next token is available
+func (l *RateLimiter) allow(ip string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, exists := l.buckets[ip]
+	if !exists {
+		bucket = &rateBucket{tokens: l.Burst}
+		l.buckets[ip] = bucket
+	} else {
+		bucket.tokens = min(l.Burst, bucket.tokens+now.Sub(bucket.lastSeen).Seconds()*l.RPS)
+	}
+	bucket.lastSeen = now
+
+	if bucket.tokens < 1 {
+		return false, time.Duration((1 - bucket.tokens) / l.RPS * float64(time.Second))
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// sweep drops the buckets of clients that haven't made a request for rateLimitIdleTTL
+func (l *RateLimiter) sweep(now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for ip, bucket := range l.buckets {
+		if now.Sub(bucket.lastSeen) > rateLimitIdleTTL {
+			delete(l.buckets, ip)
+		}
+	}
+}
+
+// rateLimitWrites wraps a handler so that, while RATE_LIMIT_RPS is set, /api requests that can
+// change state are rate limited per client IP and get 429 Too Many Requests with Retry-After when
+// the client runs out of tokens. GET, HEAD and CORS preflights (including the SSE stream) are exempt
+func rateLimitWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rateLimiter == nil || !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ip := clientIP(r)
+		if ok, wait := rateLimiter.allow(ip, time.Now()); !ok {
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			log.Warn().Str("method", r.Method).Str("path", r.URL.Path).Str("client_ip", ip).Int("retry_after", retryAfter).Msg("[API] ERROR Rate limit exceeded")
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			http.Error(w, "Too many requests", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxRemoteConfigBytes caps the size of a fetched monitor list
+const maxRemoteConfigBytes = 10 << 20
+
+// startRemoteConfigSync periodically syncs monitors from the JSON list at MONITORS_SOURCE_URL
+// The list uses the same fields as monitors.yaml, either as {"monitors": [...]} or a bare array.
+// Fetch failures keep the last successfully synced monitors in place.
+func startRemoteConfigSync() {
+	sourceURL := os.Getenv("MONITORS_SOURCE_URL")
+	if sourceURL == "" {
+		return
+	}
+
+	interval := getEnvInt("MONITORS_SOURCE_INTERVAL", 300)
+	if interval < MinCheckInterval {
+		interval = MinCheckInterval
+	}
+
+	log.Info().Str("url", sourceURL).Int("interval", interval).Msg("[Remote] Syncing monitors from remote source")
+
+	go func() {
+		syncRemoteConfig(sourceURL)
+
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			syncRemoteConfig(sourceURL)
+		}
+	}()
+}
+
+// syncRemoteConfig fetches the remote monitor list and reconciles it with the database
+func syncRemoteConfig(sourceURL string) {
+	configs, err := fetchRemoteMonitorConfigs(sourceURL)
+	if err != nil {
+		log.Warn().Err(err).Str("url", sourceURL).Msg("[Remote] Failed to fetch monitor list, keeping last-known-good config")
+		return
+	}
+
+	monitors, hashes := monitorsFromConfigs(configs, ConfigSourceRemote)
+	if len(configs) > 0 && len(monitors) == 0 {
+		// Every entry was invalid - more likely a broken source than an intentionally empty list
+		log.Warn().Int("entries", len(configs)).Str("url", sourceURL).Msg("[Remote] No valid monitors in list, keeping last-known-good config")
+		return
+	}
+
+	log.Debug().Int("count", len(monitors)).Msg("[Remote] Syncing monitors from remote source")
+	syncConfigMonitors(ConfigSourceRemote, monitors, hashes, true)
+}
+
+// fetchRemoteMonitorConfigs downloads and decodes the remote monitor list
+// MONITORS_SOURCE_TOKEN, if set, is sent as a bearer token
+func fetchRemoteMonitorConfigs(sourceURL string) ([]MonitorConfig, error) {
+	req, err := http.NewRequest("GET", sourceURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "NanoStatus/1.0")
+	req.Header.Set("Accept", "application/json")
+	if token := os.Getenv("MONITORS_SOURCE_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteConfigBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxRemoteConfigBytes {
+		return nil, fmt.Errorf("monitor list exceeds %d bytes", maxRemoteConfigBytes)
+	}
+	return decodeMonitorConfigsJSON(data)
+}
+
+// decodeMonitorConfigsJSON decodes a JSON monitor list with the fields of monitors.yaml
+func decodeMonitorConfigsJSON(data []byte) ([]MonitorConfig, error) {
+	// Accept either {"monitors": [...]} or a bare array
+	var wrapped struct {
+		Monitors *[]MonitorConfig `json:"monitors"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err == nil && wrapped.Monitors != nil {
+		return *wrapped.Monitors, nil
+	}
+	var configs []MonitorConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse monitor list: %w", err)
+	}
+	return configs, nil
+}
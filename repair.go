@@ -0,0 +1,118 @@
+package main
+
+import (
+	"math"
+
+	"github.com/rs/zerolog/log"
+)
+
+// knownStatuses are the statuses a monitor can report
+var knownStatuses = map[string]bool{
+	"up": true, "down": true, "redirect": true, "pending": true, "unknown": true, StatusDegraded: true, StatusSkipped: true,
+}
+
+// repairMonitorData normalizes out-of-range values stored on monitors (e.g. from direct database
+// edits or a bad migration) so the scheduler and stats don't misbehave
+func repairMonitorData() {
+	// NULLs (SQLite also stores NaN as NULL) can't be loaded into the model, so clear them in SQL first
+	nullDefaults := []struct {
+		column string
+		value  interface{}
+	}{
+		{"check_interval", DefaultCheckInterval},
+		{"status", "unknown"},
+		{"uptime", 0.0},
+		{"response_time", 0},
+	}
+	for _, nullDefault := range nullDefaults {
+		result := db.Model(&Monitor{}).Where(nullDefault.column+" IS NULL").Update(nullDefault.column, nullDefault.value)
+		if result.Error != nil {
+			log.Error().Err(result.Error).Str("column", nullDefault.column).Msg("[Repair] Failed to clear NULL values")
+		} else if result.RowsAffected > 0 {
+			log.Warn().Str("column", nullDefault.column).Int64("monitors", result.RowsAffected).Msg("[Repair] Replaced NULL values")
+		}
+	}
+
+	var monitors []Monitor
+	if err := db.Find(&monitors).Error; err != nil {
+		log.Error().Err(err).Msg("[Repair] Failed to load monitors for validation")
+		return
+	}
+
+	repaired := 0
+	for _, monitor := range monitors {
+		updates := monitorRepairs(&monitor)
+		if len(updates) == 0 {
+			continue
+		}
+		if err := db.Model(&Monitor{}).Where("id = ?", monitor.ID).Updates(updates).Error; err != nil {
+			log.Error().Err(err).Uint("monitor_id", monitor.ID).Msg("[Repair] Failed to repair monitor")
+			continue
+		}
+		log.Warn().Uint("monitor_id", monitor.ID).Str("name", monitor.Name).Interface("repaired", updates).Msg("[Repair] Normalized invalid stored values")
+		repaired++
+	}
+
+	if repaired > 0 {
+		log.Warn().Int("monitors", repaired).Msg("[Repair] Repaired monitors with invalid stored data")
+	}
+}
+
+// monitorRepairs returns the column updates needed to bring a monitor's stored values back in range
+func monitorRepairs(monitor *Monitor) map[string]interface{} {
+	updates := make(map[string]interface{})
+
+	if monitor.CheckInterval <= 0 {
+		updates["check_interval"] = DefaultCheckInterval
+	}
+	if !knownStatuses[monitor.Status] {
+		updates["status"] = "unknown"
+	}
+	if math.IsNaN(monitor.Uptime) || monitor.Uptime < 0 {
+		updates["uptime"] = 0.0
+	} else if monitor.Uptime > 100 {
+		updates["uptime"] = 100.0
+	}
+	if monitor.ResponseTime < 0 {
+		updates["response_time"] = 0
+	}
+	if validateDegradedThreshold(monitor.DegradedThresholdMs) != nil {
+		updates["degraded_threshold_ms"] = 0
+	}
+	if monitor.EffectiveInterval < 0 {
+		updates["effective_interval"] = 0
+	}
+	if monitor.MinAdaptiveInterval < 0 || monitor.MaxAdaptiveInterval < 0 ||
+		(monitor.MaxAdaptiveInterval > 0 && monitor.MinAdaptiveInterval > monitor.MaxAdaptiveInterval) {
+		updates["min_adaptive_interval"] = 0
+		updates["max_adaptive_interval"] = 0
+	}
+	if monitor.MinCacheAge < 0 || monitor.MaxCacheAge < 0 {
+		updates["min_cache_age"] = 0
+		updates["max_cache_age"] = 0
+	}
+	if monitor.GracePeriod < 0 {
+		updates["grace_period"] = 0
+	}
+	if monitor.EscalateAfter < 0 {
+		updates["escalate_after"] = 0
+	}
+	if validateFailureThreshold(monitor.FailureThreshold) != nil {
+		updates["failure_threshold"] = 0
+	}
+	if validateRetries(monitor.RetryCount, monitor.RetryDelayMs) != nil {
+		updates["retry_count"] = 0
+		updates["retry_delay_ms"] = 0
+	}
+	if validateHeartbeatGrace(monitor.HeartbeatGrace) != nil {
+		updates["heartbeat_grace"] = 0
+	}
+	if monitor.PreCheckTTL < 0 {
+		updates["pre_check_ttl"] = 0
+	}
+	if !isValidRedirectPolicy(monitor.RedirectPolicy) {
+		updates["redirect_policy"] = ""
+	}
+
+	return updates
+}
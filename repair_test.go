@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMonitorRepairs(t *testing.T) {
+	valid := Monitor{CheckInterval: 60, Status: "up", Uptime: 99.5}
+	if updates := monitorRepairs(&valid); len(updates) != 0 {
+		t.Errorf("valid monitor repaired: %v", updates)
+	}
+
+	corrupted := Monitor{
+		CheckInterval:       -5,
+		Status:              "exploded",
+		Uptime:              math.NaN(),
+		ResponseTime:        -1,
+		MinAdaptiveInterval: 600,
+		MaxAdaptiveInterval: 60,
+		RedirectPolicy:      "sideways",
+	}
+	updates := monitorRepairs(&corrupted)
+	want := map[string]interface{}{
+		"check_interval":        DefaultCheckInterval,
+		"status":                "unknown",
+		"uptime":                0.0,
+		"response_time":         0,
+		"min_adaptive_interval": 0,
+		"max_adaptive_interval": 0,
+		"redirect_policy":       "",
+	}
+	for column, value := range want {
+		if updates[column] != value {
+			t.Errorf("%s repaired to %v, want %v", column, updates[column], value)
+		}
+	}
+
+	if updates := monitorRepairs(&Monitor{CheckInterval: 60, Status: "up", Uptime: 140}); updates["uptime"] != 100.0 {
+		t.Errorf("uptime above 100 repaired to %v, want 100", updates["uptime"])
+	}
+}
+
+func TestRepairMonitorDataFixesCorruptedRows(t *testing.T) {
+	db := newTestDB(t)
+
+	monitor := Monitor{Name: "corrupted", URL: "https://example.com", Status: "up", CheckInterval: 60}
+	if err := db.Create(&monitor).Error; err != nil {
+		t.Fatal(err)
+	}
+	// Simulate direct database edits the model can't express
+	if err := db.Exec("UPDATE monitors SET check_interval = -30, status = NULL, uptime = NULL, response_time = -7 WHERE id = ?", monitor.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	repairMonitorData()
+
+	var repaired Monitor
+	if err := db.First(&repaired, monitor.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if repaired.CheckInterval != DefaultCheckInterval || repaired.Status != "unknown" || repaired.Uptime != 0 || repaired.ResponseTime != 0 {
+		t.Errorf("repaired monitor = interval %d, status %q, uptime %v, response time %d",
+			repaired.CheckInterval, repaired.Status, repaired.Uptime, repaired.ResponseTime)
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// requestBodyData is what a monitor's request body template can reference, rendered fresh for every request
+// e.g. {"ts": {{.Timestamp}}, "nonce": "{{.Nonce}}"}
+type requestBodyData struct {
+	Timestamp   int64  // Unix seconds
+	TimestampMs int64  // Unix milliseconds
+	Time        string // RFC 3339 time in UTC
+	Nonce       string // 32 random hex characters
+	UUID        string // Random version 4 UUID
+	MonitorID   uint
+}
+
+// bodyTemplateCache holds parsed request body templates keyed by their source
+var bodyTemplateCache sync.Map
+
+// parseRequestBody parses a request body template, caching the result
+func parseRequestBody(source string) (*template.Template, error) {
+	if cached, ok := bodyTemplateCache.Load(source); ok {
+		return cached.(*template.Template), nil
+	}
+	tmpl, err := template.New("requestBody").Option("missingkey=error").Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	bodyTemplateCache.Store(source, tmpl)
+	return tmpl, nil
+}
+
+// validateRequestBody checks that a request body template compiles and only references known fields
+func validateRequestBody(body string) error {
+	if !strings.Contains(body, "{{") {
+		return nil
+	}
+	tmpl, err := parseRequestBody(body)
+	if err != nil {
+		return fmt.Errorf("invalid requestBody template: %w", err)
+	}
+	if err := tmpl.Execute(&bytes.Buffer{}, newRequestBodyData(0)); err != nil {
+		return fmt.Errorf("invalid requestBody template: %w", err)
+	}
+	return nil
+}
+
+// AllowedMethods lists the HTTP methods a monitor's checks may use
+var AllowedMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut}
+
+// validateMethod checks a monitor's HTTP method against AllowedMethods (empty picks the default)
+// GET and HEAD checks can't carry a request body
+func validateMethod(method, body string) error {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		return nil
+	}
+	if !slices.Contains(AllowedMethods, method) {
+		return fmt.Errorf("method must be one of %s", strings.Join(AllowedMethods, ", "))
+	}
+	if body != "" && (method == http.MethodGet || method == http.MethodHead) {
+		return fmt.Errorf("requestBody requires method POST or PUT")
+	}
+	return nil
+}
+
+// validateContentType checks a request body's Content-Type parses as a media type
+func validateContentType(contentType string) error {
+	if strings.TrimSpace(contentType) == "" {
+		return nil
+	}
+	if _, _, err := mime.ParseMediaType(contentType); err != nil {
+		return fmt.Errorf("invalid contentType: %w", err)
+	}
+	return nil
+}
+
+// checkMethod returns the HTTP method a monitor's checks use: its Method, or POST when it has a
+// request body and GET otherwise (monitors that predate Method POST their body)
+func (m *Monitor) checkMethod() string {
+	if m.Method != "" {
+		return m.Method
+	}
+	if m.RequestBody != "" {
+		return http.MethodPost
+	}
+	return http.MethodGet
+}
+
+// renderRequestBody renders a monitor's request body for one request
+// Bodies without template actions are sent as-is
+func renderRequestBody(monitor *Monitor) (string, error) {
+	if !strings.Contains(monitor.RequestBody, "{{") {
+		return monitor.RequestBody, nil
+	}
+	tmpl, err := parseRequestBody(monitor.RequestBody)
+	if err != nil {
+		return "", err
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, newRequestBodyData(monitor.ID)); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+func newRequestBodyData(monitorID uint) requestBodyData {
+	now := time.Now()
+	random := make([]byte, 32)
+	rand.Read(random)
+
+	uuid := random[16:]
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // Version 4
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // RFC 4122 variant
+	encoded := hex.EncodeToString(uuid)
+
+	return requestBodyData{
+		Timestamp:   now.Unix(),
+		TimestampMs: now.UnixMilli(),
+		Time:        now.UTC().Format(time.RFC3339),
+		Nonce:       hex.EncodeToString(random[:16]),
+		UUID:        encoded[0:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:32],
+		MonitorID:   monitorID,
+	}
+}
+
+// requestContentType returns the Content-Type a monitor's request body is sent with: its
+// ContentType, or a guess from the body
+func (m *Monitor) requestContentType() string {
+	if m.ContentType != "" {
+		return m.ContentType
+	}
+	return requestBodyContentType(m.RequestBody)
+}
+
+// requestBodyContentType guesses a request body's content type: JSON bodies are sent as
+// application/json, anything else as a form
+func requestBodyContentType(body string) string {
+	if trimmed := strings.TrimSpace(body); strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return "application/json"
+	}
+	return "application/x-www-form-urlencoded"
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultMaxBodyBytes is how much of a response body the body assertions (keyword, JSON schema) read
+// when a monitor sets no MaxBodyBytes
+const DefaultMaxBodyBytes = 1 << 20
+
+// MaxBodyBytesCeiling caps a monitor's MaxBodyBytes, since the body is held in memory while it's checked
+const MaxBodyBytesCeiling = 32 << 20
+
+// validateMaxBodyBytes checks a monitor's body read limit (0 uses DefaultMaxBodyBytes)
+func validateMaxBodyBytes(limit int64) error {
+	if limit < 0 || limit > MaxBodyBytesCeiling {
+		return fmt.Errorf("maxBodyBytes must be between 0 and %d", MaxBodyBytesCeiling)
+	}
+	return nil
+}
+
+// bodyReadLimit returns how much of a response body the monitor's body assertions read
+func (m *Monitor) bodyReadLimit() int64 {
+	if m.MaxBodyBytes <= 0 {
+		return DefaultMaxBodyBytes
+	}
+	return m.MaxBodyBytes
+}
+
+// readDecodedResponseBody reads up to limit bytes of the decompressed response body
+func readDecodedResponseBody(resp *http.Response, limit int64) ([]byte, error) {
+	body, err := decodedResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(body, limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return data, nil
+}
+
+// responseContentEncoding returns how a response body was compressed on the wire (empty if it wasn't)
+// Go's transport transparently decodes the gzip it asks for itself; that still counts as compressed
+func responseContentEncoding(resp *http.Response) string {
+	if resp.Uncompressed {
+		return "gzip"
+	}
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding == "identity" {
+		return ""
+	}
+	return encoding
+}
+
+// decodedResponseBody returns the response body decompressed according to its Content-Encoding,
+// so body assertions match the content rather than compressed bytes. Callers still close resp.Body
+// and should cap how much they read, since the cap has to apply after decompression.
+// Brotli isn't decodable without a third-party decoder; checks ask for gzip only, so servers
+// sending br anyway fail the assertion with a clear error
+func decodedResponseBody(resp *http.Response) (io.Reader, error) {
+	if resp.Uncompressed {
+		return resp.Body, nil
+	}
+	switch encoding := responseContentEncoding(resp); encoding {
+	case "":
+		return resp.Body, nil
+	case "gzip", "x-gzip":
+		reader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip response body: %w", err)
+		}
+		return reader, nil
+	case "deflate":
+		// Most servers send zlib-wrapped deflate, some send raw deflate
+		buffered := bufio.NewReader(resp.Body)
+		if header, err := buffered.Peek(2); err == nil && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+			reader, err := zlib.NewReader(buffered)
+			if err != nil {
+				return nil, fmt.Errorf("invalid deflate response body: %w", err)
+			}
+			return reader, nil
+		}
+		return flate.NewReader(buffered), nil
+	default:
+		return nil, fmt.Errorf("unsupported response Content-Encoding %q", encoding)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// responseTimeSlots are the ranges served as aggregated points, with the time each point covers
+// Raw checks are only kept for RAW_RETENTION_DAYS, so older hours have to come from the hourly buckets
+var responseTimeSlots = map[string]time.Duration{
+	"1w":  time.Hour,      // 168 points
+	"30d": time.Hour,      // 720 points
+	"1y":  24 * time.Hour, // 365 points
+}
+
+// hourlyResponseTime is one hour of response times, from a bucket or aggregated from raw checks
+type hourlyResponseTime struct {
+	BucketHour      int64 // Unix hour in the same form as CheckHistoryBucket.BucketHour
+	Checks          int64 // Checks the average is weighted by
+	AvgResponseTime float64
+	MinResponseTime int
+	MaxResponseTime int
+}
+
+// rawHourlyResponseTimeSQL aggregates a monitor's raw checks since a time into hours keyed like the buckets
+const rawHourlyResponseTimeSQL = `
+	SELECT
+		` + bucketHourSQL + ` as bucket_hour,
+		COUNT(CASE WHEN response_time > 0 THEN 1 ELSE NULL END) as checks,
+		AVG(CASE WHEN response_time > 0 THEN response_time ELSE NULL END) as avg_response_time,
+		MIN(CASE WHEN response_time > 0 THEN response_time ELSE NULL END) as min_response_time,
+		MAX(CASE WHEN response_time > 0 THEN response_time ELSE NULL END) as max_response_time
+	FROM check_histories
+	WHERE monitor_id = ? AND created_at > ?
+	GROUP BY bucket_hour
+	ORDER BY bucket_hour
+`
+
+// hourlyResponseTimes returns a monitor's response times per hour since a time, oldest first
+// Hours that still have raw checks are aggregated from them; only hours before the oldest raw check are
+// read from the buckets, so the hours where both exist (raw checks outlive bucketing) aren't counted twice
+func hourlyResponseTimes(monitorID uint, since time.Time) []hourlyResponseTime {
+	rawStartHour := int64(-1)
+	var first CheckHistory
+	if err := db.Select("id", "created_at").Where("monitor_id = ? AND created_at > ?", monitorID, since).
+		Order("created_at ASC").Limit(1).Find(&first).Error; err == nil && first.ID != 0 {
+		rawStartHour = bucketHourKey(first.CreatedAt)
+	}
+
+	var buckets []CheckHistoryBucket
+	bucketQuery := db.Select("bucket_hour", "total_checks", "avg_response_time", "min_response_time", "max_response_time").
+		Where("monitor_id = ? AND bucket_hour >= ?", monitorID, since.Unix())
+	if rawStartHour >= 0 {
+		bucketQuery = bucketQuery.Where("bucket_hour < ?", rawStartHour)
+	}
+	if err := bucketQuery.Order("bucket_hour ASC").Find(&buckets).Error; err != nil {
+		log.Error().Err(err).Uint("monitor_id", monitorID).Msg("[API] Failed to load response time buckets")
+	}
+
+	hours := make([]hourlyResponseTime, 0, len(buckets))
+	for _, bucket := range buckets {
+		hour := hourlyResponseTime{
+			BucketHour:      bucket.BucketHour,
+			AvgResponseTime: bucket.AvgResponseTime,
+			MinResponseTime: bucket.MinResponseTime,
+			MaxResponseTime: bucket.MaxResponseTime,
+		}
+		if bucket.AvgResponseTime > 0 {
+			hour.Checks = int64(bucket.TotalChecks)
+		}
+		hours = append(hours, hour)
+	}
+
+	if rawStartHour >= 0 {
+		var raw []hourlyResponseTime
+		if err := db.Raw(rawHourlyResponseTimeSQL, monitorID, since).Scan(&raw).Error; err != nil {
+			log.Error().Err(err).Uint("monitor_id", monitorID).Msg("[API] Failed to aggregate raw response times")
+		}
+		hours = append(hours, raw...)
+	}
+	return hours
+}
+
+// aggregatedResponseTimeData returns one point per slot (an hour or a day) since cutoff, averaging the
+// response times of the hours in it weighted by their check count, with the fastest and slowest response
+// of those hours as Min and Max for drawing a band
+func aggregatedResponseTimeData(monitorID uint, cutoff time.Time, slot time.Duration, timeRange string) []ResponseTimeData {
+	slotSeconds := int64(slot / time.Second)
+	data := []ResponseTimeData{}
+
+	var slotStart, checks int64
+	var total float64
+	var minTime, maxTime int
+	flush := func() {
+		if slotStart == 0 {
+			return
+		}
+		point := ResponseTimeData{Min: float64(minTime), Max: float64(maxTime)}
+		if checks > 0 {
+			point.ResponseTime = total / float64(checks)
+		}
+		// Bucket hours are the stored wall-clock hour read as UTC
+		start := time.Unix(slotStart, 0).UTC()
+		point.Time = chartTimeLabel(start, timeRange)
+		point.Timestamp = start.Format(time.RFC3339)
+		data = append(data, point)
+	}
+
+	for _, hour := range hourlyResponseTimes(monitorID, cutoff) {
+		start := hour.BucketHour - hour.BucketHour%slotSeconds
+		if start != slotStart {
+			flush()
+			slotStart, checks, total, minTime, maxTime = start, 0, 0, 0, 0
+		}
+		checks += hour.Checks
+		total += hour.AvgResponseTime * float64(hour.Checks)
+		// Zero means the hour had no response to measure
+		if hour.MinResponseTime > 0 && (minTime == 0 || hour.MinResponseTime < minTime) {
+			minTime = hour.MinResponseTime
+		}
+		maxTime = max(maxTime, hour.MaxResponseTime)
+	}
+	flush()
+	return data
+}
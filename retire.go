@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Auto-retire actions
+const (
+	AutoRetirePause  = "pause"  // Pause the monitor (default)
+	AutoRetireDelete = "delete" // Delete the monitor
+)
+
+// AlertRetired is sent when a monitor is automatically retired
+const AlertRetired = "retired"
+
+// validateAutoRetire checks a monitor's auto-retire settings
+func validateAutoRetire(after int, action string) error {
+	if after < 0 {
+		return fmt.Errorf("autoRetireAfter must not be negative")
+	}
+	if action != "" && action != AutoRetirePause && action != AutoRetireDelete {
+		return fmt.Errorf("autoRetireAction must be pause or delete")
+	}
+	return nil
+}
+
+// retireReason classifies a failed primary check as a sign the target is permanently gone:
+// a DNS failure, or HTTP 404/410. Returns "" for any other result
+func retireReason(primary endpointCheck) string {
+	if primary.Status == "up" {
+		return ""
+	}
+	if primary.ErrorCategory == ErrorCategoryDNS {
+		return "dns"
+	}
+	if primary.StatusCode == http.StatusNotFound || primary.StatusCode == http.StatusGone {
+		return fmt.Sprintf("http %d", primary.StatusCode)
+	}
+	return ""
+}
+
+// retireMonitorIfDue retires a monitor that has been failing with a retire reason for AutoRetireAfter
+// Returns true when the monitor was retired (paused or deleted)
+func retireMonitorIfDue(monitor *Monitor, reason string, now time.Time) bool {
+	if monitor.AutoRetireAfter <= 0 || reason == "" || monitor.RetireCandidateSince == nil {
+		return false
+	}
+	goneFor := now.Sub(*monitor.RetireCandidateSince)
+	if goneFor < time.Duration(monitor.AutoRetireAfter)*time.Second {
+		return false
+	}
+
+	// Monitors from monitors.yaml or a remote source would be recreated by the next sync, so only pause them
+	action := monitor.AutoRetireAction
+	if action == "" || monitor.ConfigHash != "" {
+		action = AutoRetirePause
+	}
+
+	log.Warn().Uint("monitor_id", monitor.ID).Str("name", monitor.Name).Str("url", monitor.URL).
+		Str("reason", reason).Dur("failing_for", goneFor).Str("action", action).
+		Msg("[Retire] Automatically retiring monitor")
+
+	monitorScheduler.removeMonitorJob(monitor.ID)
+
+	if action == AutoRetireDelete {
+		if err := db.Delete(&Monitor{}, monitor.ID).Error; err != nil {
+			log.Error().Err(err).Uint("monitor_id", monitor.ID).Msg("[Retire] Failed to delete monitor")
+			return false
+		}
+		sendAlert(AlertRetired, *monitor)
+		broadcastUpdate("monitor_deleted", map[string]interface{}{"id": monitor.ID})
+	} else {
+		if err := db.Model(monitor).Update("paused", true).Error; err != nil {
+			log.Error().Err(err).Uint("monitor_id", monitor.ID).Msg("[Retire] Failed to pause monitor")
+			return false
+		}
+		monitor.Paused = true
+		sendAlert(AlertRetired, *monitor)
+		broadcastUpdate("monitor_update", *monitor)
+	}
+
+	alertManager.forget(monitor.ID)
+	broadcastStatsIfChanged()
+	return true
+}
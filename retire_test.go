@@ -0,0 +1,30 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRetireReasonForGoneTarget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	for path, want := range map[string]string{"/gone": "http 410", "/missing": "http 404"} {
+		rawURL := server.URL + path
+		result := checkEndpoint(&Monitor{URL: rawURL}, rawURL, nil)
+		if reason := retireReason(result); reason != want {
+			t.Errorf("%s: retireReason = %q (HTTP %d), want %q", path, reason, result.StatusCode, want)
+		}
+	}
+
+	if reason := retireReason(endpointCheck{EndpointResult: EndpointResult{Status: "down"}, ErrorCategory: ErrorCategoryTimeout}); reason != "" {
+		t.Errorf("timeout gave retire reason %q", reason)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	maxRetryCount       = 5
+	DefaultRetryDelayMs = 500   // Delay before the first retry when a monitor sets no RetryDelayMs
+	maxRetryDelayMs     = 10000 // Caps RetryDelayMs
+)
+
+// validateRetries checks a monitor's retry count and delay (0 delay uses DefaultRetryDelayMs)
+func validateRetries(count int, delayMs int) error {
+	if count < 0 || count > maxRetryCount {
+		return fmt.Errorf("retryCount must be between 0 and %d", maxRetryCount)
+	}
+	if delayMs < 0 || delayMs > maxRetryDelayMs {
+		return fmt.Errorf("retryDelayMs must be between 0 and %d", maxRetryDelayMs)
+	}
+	return nil
+}
+
+// retryDelay returns the delay before the first retry; each further retry waits twice as long
+func (m *Monitor) retryDelay() time.Duration {
+	if m.RetryDelayMs <= 0 {
+		return DefaultRetryDelayMs * time.Millisecond
+	}
+	return time.Duration(m.RetryDelayMs) * time.Millisecond
+}
+
+// checkEndpointWithRetries checks rawURL, retrying a failed check up to RetryCount times within the same cycle
+// All attempts together stay within the monitor's check timeout: a retry only runs with whatever is left of it
+// (at least a second), and gets that as its own timeout. Only the last attempt's result is returned, so a
+// success on a retry reports that attempt's response time
+func checkEndpointWithRetries(monitor *Monitor, rawURL string, inject http.Header) endpointCheck {
+	// The deadline counts from the first attempt, so a first attempt that times out leaves no time to retry
+	deadline := time.Now().Add(monitor.checkTimeout())
+	result := checkEndpoint(monitor, rawURL, inject)
+	if monitor.RetryCount <= 0 {
+		return result
+	}
+
+	delay := monitor.retryDelay()
+	for attempt := 1; attempt <= monitor.RetryCount && result.Status == "down"; attempt++ {
+		// A broken configuration fails the same way every time
+		if result.ErrorCategory == ErrorCategoryConfig {
+			break
+		}
+		remaining := time.Until(deadline) - delay
+		if remaining < time.Second {
+			log.Debug().Uint("monitor_id", monitor.ID).Str("url", rawURL).Int("attempt", attempt).
+				Msg("[Check] Check timeout used up, not retrying")
+			break
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+		log.Debug().Uint("monitor_id", monitor.ID).Str("url", rawURL).Int("attempt", attempt).
+			Str("category", result.ErrorCategory).Msg("[Check] Retrying failed check")
+
+		attemptMonitor := *monitor
+		attemptMonitor.TimeoutSeconds = int(remaining / time.Second)
+		result = checkEndpoint(&attemptMonitor, rawURL, inject)
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetriesStayWithinCheckTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	monitor := &Monitor{URL: server.URL, TimeoutSeconds: 2, RetryCount: 3, RetryDelayMs: 100}
+	start := time.Now()
+	result := checkEndpointWithRetries(monitor, server.URL, nil)
+	elapsed := time.Since(start)
+
+	if result.Status != "down" {
+		t.Errorf("status = %q, want down", result.Status)
+	}
+	if elapsed > 2500*time.Millisecond {
+		t.Errorf("check with retries took %v, want it within the 2s timeout", elapsed.Round(time.Millisecond))
+	}
+}
+
+func TestRetrySucceedsAfterFailure(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	monitor := &Monitor{URL: server.URL, TimeoutSeconds: 5, RetryCount: 2, RetryDelayMs: 10}
+	if result := checkEndpointWithRetries(monitor, server.URL, nil); result.Status != "up" || requests != 2 {
+		t.Errorf("status = %q after %d requests, want up after 2", result.Status, requests)
+	}
+}
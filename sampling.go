@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// maxSampleRate caps how many checks one stored check may stand for
+const maxSampleRate = 1000
+
+// validateSampleRate checks a monitor's history sampling rate (0 and 1 store every check)
+func validateSampleRate(rate int) error {
+	if rate < 0 || rate > maxSampleRate {
+		return fmt.Errorf("sampleRate must be between 0 and %d", maxSampleRate)
+	}
+	return nil
+}
+
+// CheckSampler decides which checks of monitors with a SampleRate are stored in check history
+// Every Nth check is kept regardless of its result, so the stored checks stay a representative
+// sample and uptime ratios computed from them remain unbiased
+type CheckSampler struct {
+	counts map[uint]int // Checks since the last stored one, by monitor ID
+	mu     sync.Mutex
+}
+
+var checkSampler = &CheckSampler{counts: make(map[uint]int)}
+
+// storesHistory reports whether a monitor's checks are stored in check history at all (default true)
+func (m *Monitor) storesHistory() bool {
+	return m.StoreHistory == nil || *m.StoreHistory
+}
+
+// keep reports whether the current check of a monitor should be stored
+func (s *CheckSampler) keep(monitor *Monitor) bool {
+	if !monitor.storesHistory() {
+		return false
+	}
+	if monitor.SampleRate <= 1 {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := s.counts[monitor.ID]
+	s.counts[monitor.ID] = (count + 1) % monitor.SampleRate
+	return count == 0
+}
+
+// forget drops a deleted monitor's counter
+func (s *CheckSampler) forget(monitorID uint) {
+	s.mu.Lock()
+	delete(s.counts, monitorID)
+	s.mu.Unlock()
+}
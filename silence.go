@@ -0,0 +1,87 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm/clause"
+)
+
+// settingNotificationSilence is the settings key holding the global silence-until time (RFC 3339)
+const settingNotificationSilence = "notification_silence_until"
+
+// MaxSilenceMinutes caps how long notifications can be silenced at once (one week)
+const MaxSilenceMinutes = 7 * 24 * 60
+
+// NotificationSilence mutes all alerts until a point in time, surviving restarts via the settings table
+type NotificationSilence struct {
+	until time.Time
+	mu    sync.RWMutex
+}
+
+var notificationSilence = &NotificationSilence{}
+
+// loadNotificationSilence restores a persisted silence (must run after initDB)
+func loadNotificationSilence() {
+	var setting Setting
+	if err := db.Where("key = ?", settingNotificationSilence).Limit(1).Find(&setting).Error; err != nil || setting.Key == "" {
+		return
+	}
+	until, err := time.Parse(time.RFC3339, setting.Value)
+	if err != nil {
+		log.Warn().Err(err).Str("value", setting.Value).Msg("[Alert] Ignoring invalid persisted notification silence")
+		return
+	}
+	if time.Now().Before(until) {
+		notificationSilence.mu.Lock()
+		notificationSilence.until = until
+		notificationSilence.mu.Unlock()
+		log.Info().Time("until", until).Msg("[Alert] Notifications silenced")
+	}
+}
+
+// active reports whether notifications are currently silenced, and until when
+func (s *NotificationSilence) active(now time.Time) (bool, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return now.Before(s.until), s.until
+}
+
+// set silences notifications until the given time and persists it
+func (s *NotificationSilence) set(until time.Time) error {
+	setting := Setting{Key: settingNotificationSilence, Value: until.UTC().Format(time.RFC3339), UpdatedAt: time.Now()}
+	if err := db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&setting).Error; err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.until = until
+	s.mu.Unlock()
+	return nil
+}
+
+// clear lifts the silence
+func (s *NotificationSilence) clear() error {
+	if err := db.Where("key = ?", settingNotificationSilence).Delete(&Setting{}).Error; err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.until = time.Time{}
+	s.mu.Unlock()
+	return nil
+}
+
+// status returns the silence as returned by the API
+func (s *NotificationSilence) status() NotificationSilenceResponse {
+	now := time.Now()
+	silenced, until := s.active(now)
+	if !silenced {
+		return NotificationSilenceResponse{}
+	}
+	untilStr := until.UTC().Format(time.RFC3339)
+	return NotificationSilenceResponse{
+		Silenced:         true,
+		Until:            &untilStr,
+		RemainingSeconds: int(until.Sub(now).Seconds()),
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// validateSLATarget checks a monitor's availability target percentage
+func validateSLATarget(target float64) error {
+	if target < 0 || target > 100 || math.IsNaN(target) {
+		return fmt.Errorf("slaTarget must be between 0 and 100")
+	}
+	return nil
+}
+
+// monitorCheckCounts returns a monitor's total and up checks since a point in time
+// Raw history is used where it exists; older hours come from the hourly buckets
+func monitorCheckCounts(monitorID uint, since time.Time) (int64, int64) {
+	var raw struct {
+		TotalCount int64
+		UpCount    int64
+	}
+	db.Model(&CheckHistory{}).
+		Select("COUNT(*) as total_count, SUM(CASE WHEN "+uptimeStatusSQL("status")+" THEN 1 ELSE 0 END) as up_count").
+		Where("monitor_id = ? AND created_at >= ?", monitorID, since).
+		Scan(&raw)
+
+	// Hours before the oldest raw check only survive as buckets
+	rawStart := time.Now()
+	var first CheckHistory
+	if err := db.Select("id", "created_at").Where("monitor_id = ? AND created_at >= ?", monitorID, since).
+		Order("created_at ASC").Limit(1).Find(&first).Error; err == nil && first.ID != 0 {
+		rawStart = first.CreatedAt
+	}
+
+	var buckets struct {
+		TotalCount int64
+		UpCount    int64
+	}
+	db.Model(&CheckHistoryBucket{}).
+		Select("SUM(total_checks) as total_count, SUM(up_checks) as up_count").
+		Where("monitor_id = ? AND bucket_hour >= ? AND bucket_hour < ?", monitorID, since.Unix(), rawStart.Truncate(time.Hour).Unix()).
+		Scan(&buckets)
+
+	return raw.TotalCount + buckets.TotalCount, raw.UpCount + buckets.UpCount
+}
+
+// getFleetSLA computes the availability of all unpaused monitors over the last days
+// Availability is averaged per monitor (each monitor weighs the same) and also weighted by check count
+func getFleetSLA(days int) SLAResponse {
+	since := time.Now().AddDate(0, 0, -days)
+
+	var monitors []Monitor
+	db.Select("id", "name", "sla_target").Where("paused = ?", false).Order("id ASC").Find(&monitors)
+
+	response := SLAResponse{
+		Range:    fmt.Sprintf("%dd", days),
+		Monitors: make([]MonitorSLA, 0, len(monitors)),
+	}
+
+	var availabilitySum float64
+	var totalChecks, upChecks int64
+	for _, monitor := range monitors {
+		total, up := monitorCheckCounts(monitor.ID, since)
+		entry := MonitorSLA{ID: monitor.ID, Name: monitor.Name, Target: monitor.SLATarget, Checks: total}
+		if total > 0 {
+			availability := float64(up) / float64(total) * 100
+			entry.Availability = &availability
+			availabilitySum += availability
+			totalChecks += total
+			upChecks += up
+			response.MonitorCount++
+		}
+
+		switch {
+		case monitor.SLATarget <= 0:
+			response.NoTarget++
+		case entry.Availability == nil:
+			response.NoData++
+		case *entry.Availability >= monitor.SLATarget:
+			met := true
+			entry.Met = &met
+			response.MeetingTarget++
+		default:
+			met := false
+			entry.Met = &met
+			response.MissingTarget++
+		}
+		response.Monitors = append(response.Monitors, entry)
+	}
+
+	if response.MonitorCount > 0 {
+		response.Availability = availabilitySum / float64(response.MonitorCount)
+	}
+	if totalChecks > 0 {
+		response.CheckWeightedAvailability = float64(upChecks) / float64(totalChecks) * 100
+	}
+	return response
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// validateSourceIP checks that a monitor's source IP is a valid address assigned to this host
+func validateSourceIP(sourceIP string) error {
+	if sourceIP == "" {
+		return nil
+	}
+	ip := net.ParseIP(sourceIP)
+	if ip == nil {
+		return fmt.Errorf("sourceIp must be an IP address")
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return fmt.Errorf("failed to list local addresses: %w", err)
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
+			return nil
+		}
+	}
+	return fmt.Errorf("sourceIp %s is not assigned to this host", sourceIP)
+}
+
+// sourceClients caches HTTP clients bound to a local source IP or DNS server, keyed by both and redirect handling
+var (
+	sourceClients   = make(map[string]*http.Client)
+	sourceClientsMu sync.Mutex
+)
+
+// clientForMonitor returns the HTTP client for a monitor's checks
+// Monitors with a SourceIP get a client whose connections originate from that address, monitors
+// with a DNSServer one that resolves through it, monitors with HTTP3 one that connects over QUIC, and
+// monitors with MaxRedirects one that gives up after that many redirects
+func clientForMonitor(monitor *Monitor, stopAtRedirects bool) *http.Client {
+	client := sourceClientForMonitor(monitor, stopAtRedirects)
+	if stopAtRedirects || monitor.MaxRedirects <= 0 {
+		return client
+	}
+	// A shallow copy shares the transport, so the monitor still uses the pooled connections
+	limited := *client
+	limited.CheckRedirect = redirectLimit(monitor.MaxRedirects)
+	return &limited
+}
+
+// sourceClientForMonitor returns the shared client, or the cached client bound to the monitor's SourceIP
+// and DNSServer
+func sourceClientForMonitor(monitor *Monitor, stopAtRedirects bool) *http.Client {
+	if monitor.HTTP3 {
+		return http3ClientForMonitor(monitor, stopAtRedirects)
+	}
+	if monitor.SourceIP == "" && monitor.DNSServer == "" {
+		if stopAtRedirects {
+			return noRedirectClient
+		}
+		return httpClient
+	}
+
+	key := fmt.Sprintf("%s|%s|%v", monitor.SourceIP, monitor.DNSServer, stopAtRedirects)
+	sourceClientsMu.Lock()
+	defer sourceClientsMu.Unlock()
+	if client, exists := sourceClients[key]; exists {
+		return client
+	}
+
+	transport := baseTransport.Clone()
+	dialer := &net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Resolver:  resolverForMonitor(monitor),
+	}
+	if monitor.SourceIP != "" {
+		dialer.LocalAddr = &net.TCPAddr{IP: net.ParseIP(monitor.SourceIP)}
+	}
+	transport.DialContext = dialer.DialContext
+
+	base := httpClient
+	if stopAtRedirects {
+		base = noRedirectClient
+	}
+	client := &http.Client{
+		Timeout:       base.Timeout,
+		Transport:     transport,
+		CheckRedirect: base.CheckRedirect,
+	}
+	sourceClients[key] = client
+	return client
+}
+
+// tcpDialer returns the dialer for a monitor's raw TCP connections (e.g. WebSocket checks), bound to its SourceIP
+// and resolving through its DNSServer if set
+func tcpDialer(monitor *Monitor, timeout time.Duration) *net.Dialer {
+	dialer := &net.Dialer{Timeout: timeout, Resolver: resolverForMonitor(monitor)}
+	if monitor.SourceIP != "" {
+		dialer.LocalAddr = &net.TCPAddr{IP: net.ParseIP(monitor.SourceIP)}
+	}
+	return dialer
+}
+
+// udpDialer returns the dialer for a monitor's UDP checks, bound to its SourceIP and resolving through its
+// DNSServer if set
+func udpDialer(monitor *Monitor, timeout time.Duration) *net.Dialer {
+	dialer := &net.Dialer{Timeout: timeout, Resolver: resolverForMonitor(monitor)}
+	if monitor.SourceIP != "" {
+		dialer.LocalAddr = &net.UDPAddr{IP: net.ParseIP(monitor.SourceIP)}
+	}
+	return dialer
+}
@@ -250,6 +250,14 @@ export function App() {
         const update = JSON.parse(event.data);
         
         switch (update.type) {
+          case "connected":
+            // Reconnected after missing more updates than the server buffers: reload everything
+            if (update.data?.resync) {
+              fetchMonitors();
+              fetchStats();
+            }
+            break;
+
           case "monitor_update":
             // Update the specific monitor in the list
             setMonitors((prev) => {
@@ -268,6 +276,17 @@ export function App() {
             setLastUpdate(new Date());
             break;
             
+          case "monitors_batch": {
+            // Several monitor updates at once (startup sweep or config sync)
+            const byId = new Map<string, Monitor>(
+              update.data.map((m: Monitor) => [String(m.id), m])
+            );
+            setMonitors((prev) => prev.map((m) => byId.get(String(m.id)) ?? m));
+            setSelectedMonitor((sel) => (sel && byId.get(String(sel.id))) || sel);
+            setLastUpdate(new Date());
+            break;
+          }
+
           case "monitor_added":
             // Add new monitor to the list only if it doesn't already exist
             setMonitors((prev) => {
@@ -314,7 +333,7 @@ export function App() {
     return () => {
       eventSource.close();
     };
-  }, []);
+  }, [fetchMonitors, fetchStats]);
 
   useEffect(() => {
     if (selectedMonitor) {
@@ -41,6 +41,8 @@ export function MonitorDetails({ monitor, responseTimeData, onDelete, onEdit, on
             return date.toLocaleTimeString('en-US', { hour: 'numeric', minute: '2-digit', hour12: true });
           case "1w":
             return date.toLocaleString('en-US', { weekday: 'short', hour: 'numeric', minute: '2-digit', hour12: true });
+          case "30d":
+            return date.toLocaleString('en-US', { month: 'short', day: 'numeric', hour: 'numeric', hour12: true });
           case "1y":
             return date.toLocaleDateString('en-US', { month: 'short', day: 'numeric' });
           default:
@@ -56,9 +58,11 @@ export function MonitorDetails({ monitor, responseTimeData, onDelete, onEdit, on
   };
   
   // Format response time data with local timezone (recalculates when timeRange or responseTimeData changes)
+  // Aggregated points (1w, 30d, 1y) also carry their fastest and slowest response, drawn as a band
   const formattedResponseTimeData = responseTimeData.map(data => ({
     ...data,
-    time: formatTime(data, timeRange)
+    time: formatTime(data, timeRange),
+    band: data.min !== undefined && data.max !== undefined ? [data.min, data.max] : undefined
   }));
   
   // Calculate seconds since last update
@@ -218,7 +222,7 @@ export function MonitorDetails({ monitor, responseTimeData, onDelete, onEdit, on
                 <span className="text-xs font-semibold text-slate-400 uppercase">Current</span>
               </div>
               <p className="text-2xl font-bold text-white">
-                {monitor.status === "up" ? `${monitor.responseTime}ms` : "N/A"}
+                {monitor.status === "up" || monitor.status === "degraded" ? `${monitor.responseTime}ms` : "N/A"}
               </p>
             </div>
             <div className="rounded-xl bg-slate-800/30 border border-slate-700/50 p-4">
@@ -253,12 +257,19 @@ export function MonitorDetails({ monitor, responseTimeData, onDelete, onEdit, on
                   className={`${
                     monitor.status === "up"
                       ? "bg-emerald-500/20 text-emerald-400 border-emerald-500/30"
-                      : "bg-rose-500/20 text-rose-400 border-rose-500/30"
+                      : monitor.status === "degraded"
+                        ? "bg-amber-500/20 text-amber-400 border-amber-500/30"
+                        : "bg-rose-500/20 text-rose-400 border-rose-500/30"
                   } border px-3 py-1`}
                 >
-                  {monitor.status === "up" ? "Online" : "Offline"}
+                  {monitor.status === "up" ? "Online" : monitor.status === "degraded" ? "Degraded" : "Offline"}
                 </Badge>
               )}
+              {!isPaused && monitor.status !== "up" && monitor.lastError && (
+                <p className="mt-2 text-xs text-rose-300 break-words" title={monitor.lastErrorCategory}>
+                  {monitor.lastError}
+                </p>
+              )}
             </div>
           </div>
 
@@ -269,7 +280,7 @@ export function MonitorDetails({ monitor, responseTimeData, onDelete, onEdit, on
                 <h3 className="text-lg font-bold text-white">Response Time History</h3>
               </div>
               <div className="flex items-center gap-2">
-                {(["1h", "12h", "1w", "1y"] as const).map((range) => (
+                {(["1h", "12h", "1w", "30d", "1y"] as const).map((range) => (
                   <Button
                     key={range}
                     variant={timeRange === range ? "default" : "outline"}
@@ -286,7 +297,7 @@ export function MonitorDetails({ monitor, responseTimeData, onDelete, onEdit, on
                       }
                     }}
                   >
-                    {range === "1h" ? "1 Hour" : range === "12h" ? "12 Hours" : range === "1w" ? "1 Week" : "1 Year"}
+                    {range === "1h" ? "1 Hour" : range === "12h" ? "12 Hours" : range === "1w" ? "1 Week" : range === "30d" ? "30 Days" : "1 Year"}
                   </Button>
                 ))}
               </div>
@@ -317,10 +328,19 @@ export function MonitorDetails({ monitor, responseTimeData, onDelete, onEdit, on
                     borderRadius: "8px",
                     color: "#f1f5f9"
                   }}
-                  formatter={(value: number | undefined) => [
-                    value !== undefined ? `${value.toFixed(2)} ms` : "N/A",
-                    "Response Time"
-                  ]}
+                  formatter={(value: number | number[] | undefined) =>
+                    Array.isArray(value)
+                      ? [`${value[0]} - ${value[1]} ms`, "Min - Max"]
+                      : [value !== undefined ? `${value.toFixed(2)} ms` : "N/A", "Response Time"]
+                  }
+                />
+                <Area
+                  type="monotone"
+                  dataKey="band"
+                  stroke="none"
+                  fill="#22c55e"
+                  fillOpacity={0.15}
+                  isAnimationActive={false}
                 />
                 <Area 
                   type="monotone" 
@@ -1,5 +1,5 @@
 import { motion } from "framer-motion";
-import { CheckCircle2, XCircle, Server, Pause } from "lucide-react";
+import { CheckCircle2, XCircle, Server, Pause, AlertTriangle } from "lucide-react";
 import type { Monitor } from "../types";
 
 interface ServiceCardProps {
@@ -57,12 +57,16 @@ export function ServiceCard({ monitor, isSelected, onClick, index }: ServiceCard
               ? "bg-amber-500/20 border border-amber-500/30"
               : monitor.status === "up" 
                 ? "bg-emerald-500/20 border border-emerald-500/30" 
-                : "bg-rose-500/20 border border-rose-500/30"
+                : monitor.status === "degraded"
+                  ? "bg-amber-500/20 border border-amber-500/30"
+                  : "bg-rose-500/20 border border-rose-500/30"
           }`}>
             {isPaused ? (
               <Pause className="h-4 w-4 text-amber-400" />
             ) : monitor.status === "up" ? (
               <CheckCircle2 className="h-4 w-4 text-emerald-400" />
+            ) : monitor.status === "degraded" ? (
+              <AlertTriangle className="h-4 w-4 text-amber-400" />
             ) : (
               <XCircle className="h-4 w-4 text-rose-400" />
             )}
@@ -74,7 +78,7 @@ export function ServiceCard({ monitor, isSelected, onClick, index }: ServiceCard
             <span className="text-slate-400">Uptime</span>
             <span className="font-bold text-white">{Math.round(monitor.uptime)}%</span>
           </div>
-          {!isPaused && monitor.status === "up" && (
+          {!isPaused && (monitor.status === "up" || monitor.status === "degraded") && (
             <div className="flex items-center justify-between text-xs">
               <span className="text-slate-400">Response</span>
               <span className="font-bold text-white">{monitor.responseTime}ms</span>
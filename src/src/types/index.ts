@@ -3,27 +3,207 @@ export interface Monitor {
   name: string;
   url: string;
   uptime: number;
-  status: "up" | "down";
+  status: "up" | "down" | "redirect" | "degraded" | "pending" | "unknown" | "skipped";
   responseTime: number;
   lastCheck: string;
   isThirdParty?: boolean;
   icon?: string;
   checkInterval?: number;
+  timeoutSeconds?: number;
+  statusSince?: string;
   paused?: boolean;
+  redirectPolicy?: "follow" | "redirect" | "down";
+  maxRedirects?: number;
+  followRedirects?: boolean;
+  statusOutcomes?: string;
+  expectedStatusCodes?: string;
+  lastRedirect?: string;
+  minCacheAge?: number;
+  maxCacheAge?: number;
+  lastCacheAge?: number;
+  lastServerDate?: string;
+  tags?: string[];
+  group?: string;
+  endpoints?: string[];
+  parallelEndpoints?: boolean;
+  endpointResults?: EndpointResult[];
+  gracePeriod?: number;
+  escalateAfter?: number;
+  failureThreshold?: number;
+  retryCount?: number;
+  retryDelayMs?: number;
+  type?: "" | "push";
+  heartbeatGrace?: number;
+  lastHeartbeatAt?: string;
+  consecutiveFailures?: number;
+  graceStartedAt?: string;
+  storeHeaders?: boolean;
+  lightCheck?: boolean;
+  http3?: boolean;
+  udpProbe?: string;
+  udpExpect?: string;
+  websocketPing?: boolean;
+  adaptiveInterval?: boolean;
+  minAdaptiveInterval?: number;
+  maxAdaptiveInterval?: number;
+  effectiveInterval?: number;
+  effectiveIntervalSince?: string;
+  configSource?: string;
+  versionHeader?: string;
+  currentVersion?: string;
+  versionChangedAt?: string;
+  extractLabel?: string;
+  lastLabel?: string;
+  watchValidators?: boolean;
+  lastEtag?: string;
+  lastModified?: string;
+  validatorChangedAt?: string;
+  watchCertificate?: boolean;
+  certFingerprint?: string;
+  previousCertFingerprint?: string;
+  certFingerprintChangedAt?: string;
+  dependsOn?: string;
+  method?: 'GET' | 'HEAD' | 'POST' | 'PUT';
+  requestBody?: string;
+  contentType?: string;
+  expectKeyword?: string;
+  expectKeywordAbsent?: boolean;
+  maxBodyBytes?: number;
+  jsonSchema?: string;
+  schemaError?: string;
+  slaTarget?: number;
+  sourceIp?: string;
+  dnsServer?: string;
+  expectedIp?: string;
+  autoRetireAfter?: number;
+  autoRetireAction?: "pause" | "delete";
+  retireCandidateSince?: string;
+  responseTimeMode?: "ttfb" | "total";
+  latencyWindow?: number;
+  latencyThreshold?: number;
+  rollingResponseTime?: number;
+  degradedThresholdMs?: number;
+  p50ResponseTime?: number;
+  p90ResponseTime?: number;
+  p99ResponseTime?: number;
+  sampleRate?: number;
+  storeHistory?: boolean;
+  lastTtfb?: number;
+  lastTotalTime?: number;
+  lastContentEncoding?: string;
+  lastProtocol?: string;
+  preCheckUrl?: string;
+  preCheckMethod?: string;
+  preCheckExtract?: string;
+  preCheckTtl?: number;
+  certificate?: CertificateInfo;
+  lastError?: string;
+  lastErrorCategory?: string;
   updatedAt?: string;
 }
 
+export interface EndpointResult {
+  url: string;
+  status: string;
+  responseTime: number;
+}
+
 export interface Stats {
   overallUptime: number;
   servicesUp: number;
   servicesDown: number;
+  servicesDegraded: number;
   avgResponseTime: number;
+  p50ResponseTime: number;
+  p90ResponseTime: number;
+  p99ResponseTime: number;
+}
+
+export interface StatsSnapshot extends Pick<Stats, "overallUptime" | "servicesUp" | "servicesDown" | "avgResponseTime"> {
+  timestamp: string;
+}
+
+export interface StatsHistory {
+  range: string;
+  snapshots: StatsSnapshot[];
+  change?: {
+    overallUptime: number;
+    avgResponseTime: number;
+  };
 }
 
 export interface ResponseTimeData {
   time: string;
   timestamp?: string; // ISO 8601 timestamp for client-side formatting
   responseTime: number;
+  min?: number; // Only on aggregated points (1w, 30d, 1y)
+  max?: number;
+  unit?: "ms" | "s";
+}
+
+export interface DowntimeDay {
+  date: string; // YYYY-MM-DD (UTC)
+  downtimeMinutes: number;
+  partial?: boolean;
+  estimated?: boolean;
+}
+
+export interface DowntimeResponse {
+  monitorId: number;
+  range: string;
+  totalMinutes: number;
+  ongoing: boolean;
+  days: DowntimeDay[];
+}
+
+export interface CheckFailure {
+  timestamp: string;
+  status: string;
+  responseTime: number;
+  errorCategory?: string;
+  headers?: Record<string, string[]>;
+}
+
+export interface VersionChange {
+  id: number;
+  monitorId: number;
+  oldVersion: string;
+  newVersion: string;
+  createdAt: string;
+}
+
+export interface MonitorStreak {
+  monitorId: number;
+  status: Monitor["status"];
+  paused: boolean;
+  since?: string;
+  durationSeconds: number;
+}
+
+export interface MonitorUptime {
+  monitorId: number;
+  uptime24h: number | null;
+  uptime7d: number | null;
+  uptime30d: number | null;
+  uptime90d: number | null;
+}
+
+export interface Incident {
+  id: number;
+  monitorId: number;
+  monitorName?: string;
+  startedAt: string;
+  endedAt: string | null;
+  durationSeconds: number;
+}
+
+export interface ValidatorChange {
+  id: number;
+  monitorId: number;
+  header: "ETag" | "Last-Modified";
+  oldValue: string;
+  newValue: string;
+  createdAt: string;
 }
 
 export interface NewService {
@@ -34,3 +214,134 @@ export interface NewService {
   checkInterval: number;
 }
 
+export interface NotificationSilence {
+  silenced: boolean;
+  until?: string;
+  remainingSeconds?: number;
+}
+
+export interface MonitorSLA {
+  id: number;
+  name: string;
+  availability?: number;
+  target?: number;
+  met?: boolean;
+  checks: number;
+}
+
+export interface FleetSLA {
+  range: string;
+  availability: number;
+  checkWeightedAvailability: number;
+  monitorCount: number;
+  meetingTarget: number;
+  missingTarget: number;
+  noTarget: number;
+  noData: number;
+  monitors: MonitorSLA[];
+}
+
+export interface FleetPercentiles {
+  range: string;
+  p50: number;
+  p95: number;
+  p99: number;
+  monitorCount: number;
+  rawChecks: number;
+  bucketedChecks: number;
+  approximate: boolean;
+}
+
+export interface CertificateInfo {
+  subject: string;
+  issuer: string;
+  sans?: string[];
+  serialNumber: string;
+  notBefore: string;
+  notAfter: string;
+  keyAlgorithm: string;
+  keyBits?: number;
+  signatureAlgorithm: string;
+  chainLength: number;
+  trusted: boolean;
+  hostnameMatch: boolean;
+  verifyError?: string;
+  warnings?: string[];
+  checkedAt: string;
+}
+
+export interface BulkTagResult {
+  updated: number;
+  missing?: number[];
+}
+
+export interface MonitorAlert {
+  monitorId: number;
+  name: string;
+  url: string;
+  kind: string;
+  status: string;
+  message: string;
+  time: string;
+  downSeconds?: number;
+}
+
+export interface MaintenanceWindow {
+  id: number;
+  monitorId: number;
+  startsAt: string;
+  endsAt: string;
+  recurrence?: "daily" | "weekly";
+  description?: string;
+  createdAt: string;
+}
+
+export interface MaintenanceResponse {
+  monitorId: number;
+  inMaintenance: boolean;
+  until?: string;
+  windows: MaintenanceWindow[];
+}
+
+export interface NotificationPreview {
+  event: string;
+  alert: MonitorAlert;
+  channels: string[];
+  escalateAfter?: number;
+  suppressed: boolean;
+  suppressedUntil?: string;
+}
+
+export interface AdminMetrics {
+  scheduledJobs: number;
+  checkRateBudget: number;
+  demandedCheckRate: number;
+  effectiveCheckRate: number;
+  intervalScale: number;
+  maxConcurrentChecks: number;
+  runningChecks: number;
+  throttled: boolean;
+  deferredChecks: number;
+  goroutines: number;
+}
+
+export interface QueryPlanStep {
+  id: number;
+  parent: number;
+  detail: string;
+}
+
+export interface QueryPlan {
+  name: string;
+  sql: string;
+  plan?: QueryPlanStep[];
+  fullScan: boolean;
+  error?: string;
+}
+
+export interface ImportResult {
+  mode: "merge" | "replace";
+  version: number;
+  imported: Record<string, number>;
+  skipped: Record<string, number>;
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -19,10 +20,26 @@ var (
 	debounceMu     sync.Mutex
 )
 
+// sseReplayBufferSize caps how many recent events are kept for clients reconnecting with Last-Event-ID
+const sseReplayBufferSize = 256
+
+// SSEEvent is a broadcast message and the ID it was sent with
+type SSEEvent struct {
+	ID         uint64
+	Data       []byte
+	MonitorIDs []uint // Monitors the event is about; empty for global events such as stats_update
+}
+
 // SSEClient represents a connected SSE client
 type SSEClient struct {
-	ID   string
-	Send chan []byte
+	ID        string
+	Send      chan SSEEvent
+	MonitorID uint // Only events about this monitor (and global events) are sent; 0 sends everything
+}
+
+// wants reports whether the client's monitor filter lets an event through
+func (c *SSEClient) wants(event SSEEvent) bool {
+	return c.MonitorID == 0 || len(event.MonitorIDs) == 0 || slices.Contains(event.MonitorIDs, c.MonitorID)
 }
 
 // SSEBroadcaster manages all SSE connections
@@ -30,6 +47,8 @@ type SSEBroadcaster struct {
 	clients   map[string]*SSEClient
 	mu        sync.RWMutex
 	broadcast chan []byte
+	lastID    uint64     // ID of the most recent event, increasing from 1 since startup
+	history   []SSEEvent // Most recent events, oldest first, for Last-Event-ID replay
 }
 
 var sseBroadcaster = &SSEBroadcaster{
@@ -37,17 +56,34 @@ var sseBroadcaster = &SSEBroadcaster{
 	broadcast: make(chan []byte, 256),
 }
 
-// addClient adds a new SSE client
-func (b *SSEBroadcaster) addClient(id string) *SSEClient {
+// addClient adds a new SSE client, optionally limited to the events of one monitor
+// With a lastEventID from a reconnecting client it also returns the buffered events the client
+// missed; complete is false when some of them are no longer buffered (or the ID is from before a
+// restart), in which case the client has to reload its state. baseline is the latest event ID
+func (b *SSEBroadcaster) addClient(id string, monitorID uint, lastEventID *uint64) (client *SSEClient, replay []SSEEvent, baseline uint64, complete bool) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	client := &SSEClient{
-		ID:   id,
-		Send: make(chan []byte, 256),
+	client = &SSEClient{
+		ID:        id,
+		Send:      make(chan SSEEvent, 256),
+		MonitorID: monitorID,
 	}
 	b.clients[id] = client
-	log.Info().Str("client_id", id).Int("total", len(b.clients)).Msg("[SSE] Client connected")
-	return client
+	log.Info().Str("client_id", id).Uint("monitor_id", monitorID).Int("total", len(b.clients)).Msg("[SSE] Client connected")
+
+	complete = true
+	if lastEventID != nil && *lastEventID != b.lastID {
+		oldest := b.lastID + 1 - uint64(len(b.history))
+		complete = *lastEventID < b.lastID && *lastEventID+1 >= oldest
+		if complete {
+			for _, event := range b.history[*lastEventID+1-oldest:] {
+				if client.wants(event) {
+					replay = append(replay, event)
+				}
+			}
+		}
+	}
+	return client, replay, b.lastID, complete
 }
 
 // removeClient removes an SSE client
@@ -61,11 +97,20 @@ func (b *SSEBroadcaster) removeClient(id string) {
 	}
 }
 
-// broadcastMessage sends a message to all connected clients
-func (b *SSEBroadcaster) broadcastMessage(message []byte) {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
-	
+// broadcastMessage assigns a message the next event ID, buffers it for replay and sends it to all
+// connected clients whose filter matches one of monitorIDs (none for global events). IDs are assigned
+// under the same lock as the sends, so every client receives events in ID order
+func (b *SSEBroadcaster) broadcastMessage(message []byte, monitorIDs ...uint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event := SSEEvent{ID: b.lastID, Data: message, MonitorIDs: monitorIDs}
+	if len(b.history) >= sseReplayBufferSize {
+		b.history = append(b.history[:0], b.history[1:]...)
+	}
+	b.history = append(b.history, event)
+
 	clientCount := len(b.clients)
 	if clientCount == 0 {
 		log.Debug().Int("bytes", len(message)).Msg("[SSE] No clients connected, dropping message")
@@ -75,8 +120,11 @@ func (b *SSEBroadcaster) broadcastMessage(message []byte) {
 	sentCount := 0
 	droppedCount := 0
 	for id, client := range b.clients {
+		if !client.wants(event) {
+			continue
+		}
 		select {
-		case client.Send <- message:
+		case client.Send <- event:
 			sentCount++
 		default:
 			droppedCount++
@@ -117,7 +165,121 @@ func broadcastUpdate(updateType string, data interface{}) {
 	}
 	
 	log.Debug().Str("update_type", updateType).Int("bytes", len(jsonData)).Msg("[SSE] Broadcasting update")
-	go sseBroadcaster.broadcastMessage(jsonData)
+	go sseBroadcaster.broadcastMessage(jsonData, updateMonitorIDs(data)...)
+}
+
+// updateMonitorIDs returns the monitors a broadcast update is about, or nil for global updates
+func updateMonitorIDs(data interface{}) []uint {
+	switch value := data.(type) {
+	case Monitor:
+		return []uint{value.ID}
+	case *Monitor:
+		return []uint{value.ID}
+	case []Monitor:
+		ids := make([]uint, len(value))
+		for i := range value {
+			ids[i] = value[i].ID
+		}
+		return ids
+	case MonitorAlert:
+		return []uint{value.MonitorID}
+	case ValidatorChange:
+		return []uint{value.MonitorID}
+	case VersionChange:
+		return []uint{value.MonitorID}
+	case map[string]interface{}:
+		switch id := value["id"].(type) {
+		case uint:
+			return []uint{id}
+		case uint64:
+			return []uint{uint(id)}
+		}
+	}
+	return nil
+}
+
+// monitorBatchWindow is how long monitor_update events are coalesced while batching
+const monitorBatchWindow = time.Second
+
+// MonitorUpdateBatcher coalesces the monitor_update events of bulk operations (the startup sweep and
+// config syncs) into monitors_batch messages, so clients aren't flooded with one message per monitor
+type MonitorUpdateBatcher struct {
+	active  int              // Bulk operations currently batching
+	pending map[uint]Monitor // Latest update per monitor since the last flush
+	order   []uint           // Monitor IDs in the order they were first updated
+	timer   *time.Timer
+	mu      sync.Mutex
+}
+
+var monitorBatcher = &MonitorUpdateBatcher{pending: make(map[uint]Monitor)}
+
+// begin starts batching monitor updates; the returned function ends it, flushing the last batch
+// once no other bulk operation is batching
+func (b *MonitorUpdateBatcher) begin() func() {
+	b.mu.Lock()
+	b.active++
+	b.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			b.active--
+			done := b.active == 0
+			b.mu.Unlock()
+			if done {
+				b.flush()
+			}
+		})
+	}
+}
+
+// add queues a monitor update while batching, flushing within monitorBatchWindow
+// Returns false when nothing is batching, so the caller sends the update on its own
+func (b *MonitorUpdateBatcher) add(monitor Monitor) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.active == 0 {
+		return false
+	}
+	if _, exists := b.pending[monitor.ID]; !exists {
+		b.order = append(b.order, monitor.ID)
+	}
+	b.pending[monitor.ID] = monitor
+	if b.timer == nil {
+		b.timer = time.AfterFunc(monitorBatchWindow, b.flush)
+	}
+	return true
+}
+
+// flush broadcasts the pending updates as one monitors_batch message
+func (b *MonitorUpdateBatcher) flush() {
+	b.mu.Lock()
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	monitors := make([]Monitor, 0, len(b.order))
+	for _, id := range b.order {
+		monitors = append(monitors, b.pending[id])
+	}
+	b.pending = make(map[uint]Monitor)
+	b.order = nil
+	b.mu.Unlock()
+
+	if len(monitors) == 0 {
+		return
+	}
+	log.Debug().Int("monitors", len(monitors)).Msg("[SSE] Broadcasting batched monitor updates")
+	broadcastUpdate("monitors_batch", monitors)
+}
+
+// broadcastMonitorUpdate sends a monitor_update, or adds it to the current batch while a bulk
+// operation is running
+func broadcastMonitorUpdate(monitor Monitor) {
+	if !monitorBatcher.add(monitor) {
+		broadcastUpdate("monitor_update", monitor)
+	}
 }
 
 // broadcastStatsIfChanged broadcasts stats only if they've changed (with debouncing)
@@ -148,6 +310,7 @@ func broadcastStatsIfChanged() {
 			lastStats.OverallUptime != newStats.OverallUptime ||
 			lastStats.ServicesUp != newStats.ServicesUp ||
 			lastStats.ServicesDown != newStats.ServicesDown ||
+			lastStats.ServicesDegraded != newStats.ServicesDegraded ||
 			lastStats.AvgResponseTime != newStats.AvgResponseTime
 		
 		if changed {
@@ -2,11 +2,19 @@ package main
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/rs/zerolog/log"
 )
 
+// avgResponseTimeSQL averages the response times of passing checks since a time
+const avgResponseTimeSQL = `
+	SELECT AVG(response_time) as avg_response_time 
+	FROM check_histories 
+	WHERE created_at > ? AND response_time > 0 AND status = ?
+`
+
 // getStats calculates overall statistics from all monitors using database aggregation
 func getStats() StatsResponse {
 	// Use database aggregation to calculate stats without loading all monitors
@@ -14,14 +22,16 @@ func getStats() StatsResponse {
 		UnpausedCount int64
 		UpCount       int64
 		DownCount     int64
+		DegradedCount int64
 		TotalUptime   float64
 	}
 	
 	db.Model(&Monitor{}).
 		Select(`
 			COUNT(*) as unpaused_count,
-			SUM(CASE WHEN status = 'up' THEN 1 ELSE 0 END) as up_count,
+			SUM(CASE WHEN ` + uptimeStatusSQL("status") + ` THEN 1 ELSE 0 END) as up_count,
 			SUM(CASE WHEN status = 'down' THEN 1 ELSE 0 END) as down_count,
+			SUM(CASE WHEN status = '` + StatusDegraded + `' THEN 1 ELSE 0 END) as degraded_count,
 			SUM(uptime) as total_uptime
 		`).
 		Where("paused = ?", false).
@@ -48,11 +58,7 @@ func getStats() StatsResponse {
 	
 	if countResult > 0 {
 		// Use raw SQL to ensure correct column name
-		err := db.Raw(`
-			SELECT AVG(response_time) as avg_response_time 
-			FROM check_histories 
-			WHERE created_at > ? AND response_time > 0 AND status = ?
-		`, twentyFourHoursAgo, "up").Row().Scan(&avgResult)
+		err := db.Raw(avgResponseTimeSQL, twentyFourHoursAgo, "up").Row().Scan(&avgResult)
 		
 		if err == nil && avgResult.Valid {
 			avgResponseTime = int(avgResult.Float64)
@@ -91,11 +97,57 @@ func getStats() StatsResponse {
 		overallUptime = totalUptime / float64(unpausedCount)
 	}
 
+	p50, p90, p99 := upCheckPercentiles(twentyFourHoursAgo, "monitor_id IN (SELECT id FROM monitors WHERE paused = 0)")
+
 	return StatsResponse{
-		OverallUptime:   overallUptime,
-		ServicesUp:      upCount,
-		ServicesDown:    downCount,
-		AvgResponseTime: avgResponseTime,
+		OverallUptime:    overallUptime,
+		ServicesUp:       upCount,
+		ServicesDown:     downCount,
+		ServicesDegraded: int(stats.DegradedCount),
+		AvgResponseTime:  avgResponseTime,
+		P50ResponseTime:  p50,
+		P90ResponseTime:  p90,
+		P99ResponseTime:  p99,
+	}
+}
+
+
+// recordStatsSnapshot persists the current overall stats for the history endpoint
+func recordStatsSnapshot() {
+	stats := getStats()
+	snapshot := StatsSnapshot{
+		OverallUptime:   stats.OverallUptime,
+		ServicesUp:      stats.ServicesUp,
+		ServicesDown:    stats.ServicesDown,
+		AvgResponseTime: stats.AvgResponseTime,
+		CreatedAt:       time.Now(),
+	}
+	if err := db.Create(&snapshot).Error; err != nil {
+		log.Error().Err(err).Msg("[Stats] Failed to record stats snapshot")
+		return
 	}
+	log.Debug().Float64("uptime", snapshot.OverallUptime).Int("avg_ms", snapshot.AvgResponseTime).Msg("[Stats] Recorded stats snapshot")
 }
 
+// getStatsHistory returns the stats snapshots of the last days, oldest first
+func getStatsHistory(days int) StatsHistoryResponse {
+	var snapshots []StatsSnapshot
+	db.Where("created_at >= ?", time.Now().AddDate(0, 0, -days)).
+		Order("created_at ASC").Find(&snapshots)
+
+	response := StatsHistoryResponse{
+		Range:     fmt.Sprintf("%dd", days),
+		Snapshots: snapshots,
+	}
+	if response.Snapshots == nil {
+		response.Snapshots = []StatsSnapshot{}
+	}
+	if len(snapshots) >= 2 {
+		first, last := snapshots[0], snapshots[len(snapshots)-1]
+		response.Change = &StatsChange{
+			OverallUptime:   last.OverallUptime - first.OverallUptime,
+			AvgResponseTime: last.AvgResponseTime - first.AvgResponseTime,
+		}
+	}
+	return response
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrorCategoryStatusCode marks checks whose response status code isn't in the monitor's expectedStatusCodes
+const ErrorCategoryStatusCode = "status_code"
+
+// Response classes whose outcome a monitor can override with statusOutcomes
+const (
+	StatusClassInformational = "1xx" // Default: down (the 200-399 range excludes it)
+	StatusClassPartial       = "206" // Default: up
+	StatusClassNotModified   = "304" // Default: treated like other 3xx by redirectPolicy (up when following)
+)
+
+// parseStatusOutcomes parses a spec such as "206=degraded,304=down" into outcomes by response class
+func parseStatusOutcomes(spec string) (map[string]string, error) {
+	outcomes := make(map[string]string)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		class, outcome, found := strings.Cut(entry, "=")
+		class, outcome = strings.ToLower(strings.TrimSpace(class)), strings.ToLower(strings.TrimSpace(outcome))
+		if !found {
+			return nil, fmt.Errorf("statusOutcomes entry %q must look like class=outcome", entry)
+		}
+		switch class {
+		case StatusClassInformational, StatusClassPartial, StatusClassNotModified:
+		default:
+			return nil, fmt.Errorf("statusOutcomes class %q must be 1xx, 206, or 304", class)
+		}
+		switch outcome {
+		case "up", "down", StatusDegraded:
+		default:
+			return nil, fmt.Errorf("statusOutcomes outcome %q must be up, down, or degraded", outcome)
+		}
+		outcomes[class] = outcome
+	}
+	return outcomes, nil
+}
+
+// validateStatusOutcomes checks a monitor's statusOutcomes spec
+func validateStatusOutcomes(spec string) error {
+	_, err := parseStatusOutcomes(spec)
+	return err
+}
+
+// statusOutcome returns the configured outcome for a response status code, if its class has one
+func statusOutcome(spec string, statusCode int) (string, bool) {
+	if spec == "" {
+		return "", false
+	}
+	outcomes, err := parseStatusOutcomes(spec)
+	if err != nil {
+		return "", false
+	}
+
+	class := ""
+	switch {
+	case statusCode >= 100 && statusCode < 200:
+		class = StatusClassInformational
+	case statusCode == 206:
+		class = StatusClassPartial
+	case statusCode == 304:
+		class = StatusClassNotModified
+	}
+	outcome, ok := outcomes[class]
+	return outcome, ok
+}
+
+// parseStatusCodes parses a spec such as "200-299,301" into inclusive ranges of response status codes
+func parseStatusCodes(spec string) ([][2]int, error) {
+	var ranges [][2]int
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		low, high, isRange := strings.Cut(entry, "-")
+		from, err := strconv.Atoi(strings.TrimSpace(low))
+		to := from
+		if err == nil && isRange {
+			to, err = strconv.Atoi(strings.TrimSpace(high))
+		}
+		if err != nil || from < 100 || to > 599 || from > to {
+			return nil, fmt.Errorf("expectedStatusCodes entry %q must be a status code (100-599) or a range such as 200-299", entry)
+		}
+		ranges = append(ranges, [2]int{from, to})
+	}
+	return ranges, nil
+}
+
+// validateExpectedStatusCodes checks a monitor's expectedStatusCodes spec
+func validateExpectedStatusCodes(spec string) error {
+	_, err := parseStatusCodes(spec)
+	return err
+}
+
+// statusCodeExpected reports whether a response status code matches the expectedStatusCodes spec
+func statusCodeExpected(spec string, statusCode int) bool {
+	ranges, err := parseStatusCodes(spec)
+	if err != nil {
+		return false
+	}
+	for _, r := range ranges {
+		if statusCode >= r[0] && statusCode <= r[1] {
+			return true
+		}
+	}
+	return false
+}
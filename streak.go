@@ -0,0 +1,70 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// statusSinceFromHistory finds when a monitor's current run of checks with the given status started,
+// for monitors that predate StatusSince. Uses the (monitor_id, created_at) index, so it only reads
+// back to the last check with a different status
+func statusSinceFromHistory(monitorID uint, status string, fallback time.Time) time.Time {
+	var lastDifferent CheckHistory
+	query := db.Where("monitor_id = ?", monitorID)
+	if err := db.Select("created_at").Where("monitor_id = ? AND status != ?", monitorID, status).
+		Order("created_at DESC").Limit(1).Find(&lastDifferent).Error; err == nil && !lastDifferent.CreatedAt.IsZero() {
+		query = query.Where("created_at > ?", lastDifferent.CreatedAt)
+	}
+
+	var first CheckHistory
+	if err := query.Select("created_at").Order("created_at ASC").Limit(1).Find(&first).Error; err != nil || first.CreatedAt.IsZero() {
+		return fallback
+	}
+	return first.CreatedAt
+}
+
+// monitorStreak describes how long a monitor has had its current status
+func monitorStreak(monitor *Monitor, now time.Time) MonitorStreak {
+	streak := MonitorStreak{MonitorID: monitor.ID, Status: monitor.Status, Paused: monitor.Paused}
+	if monitor.StatusSince != nil {
+		since := monitor.StatusSince.UTC().Format(time.RFC3339)
+		streak.Since = &since
+		streak.DurationSeconds = int64(now.Sub(*monitor.StatusSince).Seconds())
+	}
+	return streak
+}
+
+// apiMonitorStreak handles GET requests for how long a monitor has been in its current status
+func apiMonitorStreak(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", id).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id == "" {
+		log.Warn().Msg("[API] ERROR GET /api/monitor/streak: Missing monitor ID")
+		http.Error(w, "Monitor ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var monitor Monitor
+	if err := db.First(&monitor, id).Error; err != nil {
+		log.Warn().Str("id", id).Err(err).Msg("[API] ERROR GET /api/monitor/streak: Monitor not found")
+		http.Error(w, "Monitor not found", http.StatusNotFound)
+		return
+	}
+
+	streak := monitorStreak(&monitor, time.Now())
+	log.Info().Str("id", id).Str("status", streak.Status).Int64("duration_seconds", streak.DurationSeconds).Msg("[API] GET /api/monitor/streak")
+	if err := encodeJSONWithCompression(w, r, streak); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding monitor streak")
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// checkSummary counts checks between summary log lines
+type checkSummary struct {
+	checks          atomic.Int64
+	down            atomic.Int64
+	responded       atomic.Int64 // Checks with a response time
+	responseTimeSum atomic.Int64 // Milliseconds
+	since           atomic.Int64 // Unix nanoseconds of the last summary
+}
+
+var summaryCounters = newCheckSummary()
+
+func newCheckSummary() *checkSummary {
+	summary := &checkSummary{}
+	summary.since.Store(time.Now().UnixNano())
+	return summary
+}
+
+// record counts one completed check
+func (s *checkSummary) record(status string, responseTime int) {
+	s.checks.Add(1)
+	if status == "down" {
+		s.down.Add(1)
+	}
+	if responseTime > 0 {
+		s.responded.Add(1)
+		s.responseTimeSum.Add(int64(responseTime))
+	}
+}
+
+// logCheckSummary logs one info line with the monitors' current status and the checks since the last summary,
+// a heartbeat for watching NanoStatus itself through its logs
+func logCheckSummary() {
+	now := time.Now()
+	since := time.Unix(0, summaryCounters.since.Swap(now.UnixNano()))
+	checks := summaryCounters.checks.Swap(0)
+	down := summaryCounters.down.Swap(0)
+	responded := summaryCounters.responded.Swap(0)
+	responseTimeSum := summaryCounters.responseTimeSum.Swap(0)
+
+	var avgResponseTime int64
+	if responded > 0 {
+		avgResponseTime = responseTimeSum / responded
+	}
+
+	stats := getStats()
+	log.Info().Int("up", stats.ServicesUp).Int("down", stats.ServicesDown).Float64("overall_uptime", stats.OverallUptime).
+		Int64("checks", checks).Int64("failed_checks", down).Int64("avg_response_time_ms", avgResponseTime).
+		Dur("period", now.Sub(since).Round(time.Second)).Msg("[Summary] Check summary")
+}
+
+// scheduleCheckSummary adds the summary log job when SUMMARY_LOG_INTERVAL (seconds) is set; off by default
+func scheduleCheckSummary(scheduler gocron.Scheduler) {
+	interval := getEnvInt("SUMMARY_LOG_INTERVAL", 0)
+	if interval <= 0 {
+		return
+	}
+	summaryCounters.since.Store(time.Now().UnixNano())
+	if _, err := scheduler.NewJob(
+		gocron.DurationJob(time.Duration(interval)*time.Second),
+		gocron.NewTask(logCheckSummary),
+		gocron.WithName("check-summary"),
+	); err != nil {
+		log.Error().Err(err).Msg("[Summary] Failed to schedule check summary job")
+		return
+	}
+	log.Info().Int("interval_seconds", interval).Msg("[Summary] Logging a check summary periodically")
+}
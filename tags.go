@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// maxTagsPerMonitor caps how many tags a single monitor may carry
+const maxTagsPerMonitor = 20
+
+// maxBulkTagMonitors caps how many monitors a single bulk-tag request may touch
+const maxBulkTagMonitors = 1000
+
+// tagPattern is the allowed tag format, e.g. "payments", "env:prod" or "team-core"
+var tagPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:-]{0,31}$`)
+
+// validateTags checks the format and number of a monitor's tags
+func validateTags(tags []string) error {
+	if len(tags) > maxTagsPerMonitor {
+		return fmt.Errorf("at most %d tags are allowed", maxTagsPerMonitor)
+	}
+	for _, tag := range tags {
+		if !tagPattern.MatchString(tag) {
+			return fmt.Errorf("invalid tag %q: tags are 1-32 letters, digits, '_', '.', ':' or '-' and start with a letter or digit", tag)
+		}
+	}
+	return nil
+}
+
+// uniqueTags returns tags without duplicates, keeping the first occurrence's position
+func uniqueTags(tags []string) StringList {
+	unique := make(StringList, 0, len(tags))
+	for _, tag := range tags {
+		if !slices.Contains(unique, tag) {
+			unique = append(unique, tag)
+		}
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+	return unique
+}
+
+// applyTagChanges adds and removes tags, reporting whether anything changed
+func applyTagChanges(current StringList, add, remove []string) (StringList, bool) {
+	updated := make([]string, 0, len(current)+len(add))
+	for _, tag := range current {
+		if !slices.Contains(remove, tag) {
+			updated = append(updated, tag)
+		}
+	}
+	updated = append(updated, add...)
+	result := uniqueTags(updated)
+	return result, !slices.Equal(result, current)
+}
+
+// BulkTagRequest adds and removes tags on many monitors at once
+type BulkTagRequest struct {
+	IDs    []uint   `json:"ids"`
+	Add    []string `json:"add,omitempty"`
+	Remove []string `json:"remove,omitempty"`
+}
+
+// BulkTagResponse reports the outcome of a bulk tag change
+type BulkTagResponse struct {
+	Updated int    `json:"updated"`           // Monitors whose tags changed
+	Missing []uint `json:"missing,omitempty"` // Requested IDs that don't exist
+}
+
+// apiBulkTagMonitors handles POST requests adding/removing tags on many monitors in one transaction
+func apiBulkTagMonitors(w http.ResponseWriter, r *http.Request) {
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodPost {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Error().Err(err).Msg("[API] ERROR POST /api/monitors/bulk-tag: Failed to read request body")
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+	var req BulkTagRequest
+	if err := json.Unmarshal(bodyBytes, &req); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR POST /api/monitors/bulk-tag: Invalid request body")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.IDs) == 0 || len(req.IDs) > maxBulkTagMonitors {
+		log.Warn().Int("ids", len(req.IDs)).Msg("[API] ERROR POST /api/monitors/bulk-tag: Invalid number of ids")
+		http.Error(w, fmt.Sprintf("ids must list between 1 and %d monitors", maxBulkTagMonitors), http.StatusBadRequest)
+		return
+	}
+	if len(req.Add) == 0 && len(req.Remove) == 0 {
+		log.Warn().Msg("[API] ERROR POST /api/monitors/bulk-tag: No tags to add or remove")
+		http.Error(w, "add or remove must list at least one tag", http.StatusBadRequest)
+		return
+	}
+	for _, tags := range [][]string{req.Add, req.Remove} {
+		if err := validateTags(tags); err != nil {
+			log.Warn().Err(err).Msg("[API] ERROR POST /api/monitors/bulk-tag: Invalid tags")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var changed []Monitor
+	response := BulkTagResponse{}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var monitors []Monitor
+		if err := tx.Where("id IN ?", req.IDs).Find(&monitors).Error; err != nil {
+			return err
+		}
+
+		found := make(map[uint]bool, len(monitors))
+		for _, monitor := range monitors {
+			found[monitor.ID] = true
+			tags, modified := applyTagChanges(monitor.Tags, req.Add, req.Remove)
+			if !modified {
+				continue
+			}
+			if len(tags) > maxTagsPerMonitor {
+				return fmt.Errorf("monitor %d would have more than %d tags", monitor.ID, maxTagsPerMonitor)
+			}
+			if err := tx.Model(&Monitor{}).Where("id = ?", monitor.ID).Update("tags", tags).Error; err != nil {
+				return err
+			}
+			monitor.Tags = tags
+			changed = append(changed, monitor)
+		}
+		for _, id := range req.IDs {
+			if !found[id] && !slices.Contains(response.Missing, id) {
+				response.Missing = append(response.Missing, id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("[API] ERROR POST /api/monitors/bulk-tag: Failed to update tags")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response.Updated = len(changed)
+	for _, monitor := range changed {
+		broadcastUpdate("monitor_update", monitor)
+	}
+
+	log.Info().Int("updated", response.Updated).Int("missing", len(response.Missing)).
+		Strs("add", req.Add).Strs("remove", req.Remove).Msg("[API] POST /api/monitors/bulk-tag: Updated tags")
+	if err := encodeJSONWithCompression(w, r, response); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding bulk tag response")
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"time"
+	_ "time/tzdata" // Zone database for images without one (e.g. distroless)
+
+	"github.com/rs/zerolog/log"
+)
+
+// displayLocation is the zone server-side formatted times are shown in (default: the server's local zone)
+var displayLocation = time.Local
+
+// initDisplayTimezone configures the display zone from DISPLAY_TIMEZONE, an IANA zone name such as
+// Europe/Berlin (the same values TZ accepts). Invalid zones are reported and the server's zone is kept
+func initDisplayTimezone() {
+	displayLocation = time.Local
+	name := strings.TrimSpace(os.Getenv("DISPLAY_TIMEZONE"))
+	if name == "" {
+		return
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		log.Warn().Err(err).Str("timezone", name).Msg("[Config] Invalid DISPLAY_TIMEZONE, using the server's timezone")
+		return
+	}
+	displayLocation = location
+	log.Info().Str("timezone", location.String()).Msg("[Config] Formatting times in configured timezone")
+}
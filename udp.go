@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// parseProbeBytes decodes a UDP probe/expect value
+// Values prefixed with "hex:" are hex-decoded, anything else is used as literal text
+func parseProbeBytes(value string) ([]byte, error) {
+	if encoded, ok := strings.CutPrefix(value, "hex:"); ok {
+		data, err := hex.DecodeString(strings.ReplaceAll(encoded, " ", ""))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex value: %w", err)
+		}
+		return data, nil
+	}
+	return []byte(value), nil
+}
+
+// validateUDPProbe checks a monitor's UDP probe and expected response settings
+func validateUDPProbe(probe, expect string) error {
+	if _, err := parseProbeBytes(probe); err != nil {
+		return fmt.Errorf("udpProbe: %w", err)
+	}
+	if _, err := parseProbeBytes(expect); err != nil {
+		return fmt.Errorf("udpExpect: %w", err)
+	}
+	return nil
+}
+
+// checkUDP sends the monitor's probe datagram to a udp://host:port URL and waits for a reply
+// UDP is connectionless, so this is best-effort: any reply within the timeout is up,
+// silence is down, and an ICMP port-unreachable surfaces as an immediate read error (down)
+func checkUDP(monitor *Monitor, rawURL string) endpointCheck {
+	result := endpointCheck{EndpointResult: EndpointResult{URL: rawURL, Status: "down"}}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Hostname() == "" || parsedURL.Port() == "" {
+		log.Debug().Uint("monitor_id", monitor.ID).Str("url", rawURL).Msg("[Check] UDP URL must be udp://host:port")
+		return result
+	}
+
+	probe, _ := parseProbeBytes(monitor.UDPProbe)
+	expect, _ := parseProbeBytes(monitor.UDPExpect)
+
+	timeout := monitor.checkTimeout()
+	start := time.Now()
+	conn, err := udpDialer(monitor, timeout).Dial("udp", parsedURL.Host)
+	if err != nil {
+		log.Debug().Err(err).Uint("monitor_id", monitor.ID).Str("url", rawURL).Msg("[Check] UDP dial failed")
+		result.setRequestError(err)
+		return result
+	}
+	defer conn.Close()
+	conn.SetDeadline(start.Add(timeout))
+
+	if _, err := conn.Write(probe); err != nil {
+		log.Debug().Err(err).Uint("monitor_id", monitor.ID).Str("url", rawURL).Msg("[Check] UDP write failed")
+		result.setRequestError(err)
+		return result
+	}
+
+	buf := make([]byte, 64*1024)
+	n, err := conn.Read(buf)
+	if err != nil {
+		log.Debug().Err(err).Uint("monitor_id", monitor.ID).Str("url", rawURL).Msg("[Check] No UDP response")
+		result.setRequestError(err)
+		return result
+	}
+	result.ResponseTime = int(time.Since(start).Milliseconds())
+	result.TTFB, result.TotalTime = result.ResponseTime, result.ResponseTime
+
+	if len(expect) > 0 && !bytes.Contains(buf[:n], expect) {
+		log.Debug().Uint("monitor_id", monitor.ID).Str("url", rawURL).Int("bytes", n).Msg("[Check] UDP response did not contain expected bytes")
+		return result
+	}
+
+	result.Status = "up"
+	return result
+}
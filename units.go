@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+)
+
+// Response time units accepted by ?unit=
+const (
+	UnitMilliseconds = "ms" // Default
+	UnitSeconds      = "s"
+)
+
+// StatsUnitResponse is StatsResponse with the average response time in the requested unit
+type StatsUnitResponse struct {
+	OverallUptime    float64 `json:"overallUptime"`
+	ServicesUp       int     `json:"servicesUp"`
+	ServicesDown     int     `json:"servicesDown"`
+	ServicesDegraded int     `json:"servicesDegraded"`
+	AvgResponseTime  float64 `json:"avgResponseTime"`
+	P50ResponseTime  float64 `json:"p50ResponseTime"`
+	P90ResponseTime  float64 `json:"p90ResponseTime"`
+	P99ResponseTime  float64 `json:"p99ResponseTime"`
+	Unit             string  `json:"unit"`
+}
+
+// parseResponseTimeUnit reads the ?unit= parameter; requested is false when it was omitted,
+// so responses keep their original shape for existing clients
+func parseResponseTimeUnit(r *http.Request) (unit string, requested bool, err error) {
+	switch value := r.URL.Query().Get("unit"); value {
+	case "":
+		return UnitMilliseconds, false, nil
+	case UnitMilliseconds, UnitSeconds:
+		return value, true, nil
+	default:
+		return "", false, fmt.Errorf("unit must be ms or s")
+	}
+}
+
+// convertResponseTime converts milliseconds to unit, keeping millisecond precision for seconds
+func convertResponseTime(ms float64, unit string) float64 {
+	if unit == UnitSeconds {
+		return math.Round(ms) / 1000
+	}
+	return ms
+}
+
+// statsInUnit converts the overall stats to the requested unit
+func statsInUnit(stats StatsResponse, unit string) StatsUnitResponse {
+	return StatsUnitResponse{
+		OverallUptime:    stats.OverallUptime,
+		ServicesUp:       stats.ServicesUp,
+		ServicesDown:     stats.ServicesDown,
+		ServicesDegraded: stats.ServicesDegraded,
+		AvgResponseTime:  convertResponseTime(float64(stats.AvgResponseTime), unit),
+		P50ResponseTime:  convertResponseTime(float64(stats.P50ResponseTime), unit),
+		P90ResponseTime:  convertResponseTime(float64(stats.P90ResponseTime), unit),
+		P99ResponseTime:  convertResponseTime(float64(stats.P99ResponseTime), unit),
+		Unit:             unit,
+	}
+}
+
+// responseTimeDataInUnit converts response time points to the requested unit, labelling each with it
+func responseTimeDataInUnit(data []ResponseTimeData, unit string) []ResponseTimeData {
+	for i := range data {
+		data[i].ResponseTime = convertResponseTime(data[i].ResponseTime, unit)
+		data[i].Min = convertResponseTime(data[i].Min, unit)
+		data[i].Max = convertResponseTime(data[i].Max, unit)
+		data[i].Unit = unit
+	}
+	return data
+}
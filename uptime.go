@@ -0,0 +1,34 @@
+package main
+
+import (
+	"github.com/rs/zerolog/log"
+)
+
+// StatusDegraded marks a check where the service responded but missed a soft expectation
+const StatusDegraded = "degraded"
+
+// degradedCountsAsUp controls whether degraded checks count toward uptime
+var degradedCountsAsUp = true
+
+// initUptimePolicy configures the uptime policy from DEGRADED_COUNTS_AS_UP (default: true)
+// Must run before initDB, since the aggregation views embed the policy
+func initUptimePolicy() {
+	degradedCountsAsUp = getEnvBool("DEGRADED_COUNTS_AS_UP", true)
+	if !degradedCountsAsUp {
+		log.Info().Msg("[Config] Degraded checks do not count toward uptime")
+	}
+}
+
+// countsAsUp reports whether a check status counts toward uptime under the current policy
+func countsAsUp(status string) bool {
+	return status == "up" || (degradedCountsAsUp && status == StatusDegraded)
+}
+
+// uptimeStatusSQL returns a SQL condition matching the statuses that count toward uptime
+// column is the (trusted, hardcoded) status column to test, e.g. "status" or "m.status"
+func uptimeStatusSQL(column string) string {
+	if degradedCountsAsUp {
+		return column + " IN ('up', '" + StatusDegraded + "')"
+	}
+	return column + " = 'up'"
+}
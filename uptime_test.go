@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// withDegradedPolicy sets degradedCountsAsUp for the rest of the test
+func withDegradedPolicy(t *testing.T, countsAsUp bool) {
+	t.Helper()
+	previous := degradedCountsAsUp
+	degradedCountsAsUp = countsAsUp
+	t.Cleanup(func() { degradedCountsAsUp = previous })
+}
+
+func TestInitUptimePolicy(t *testing.T) {
+	withDegradedPolicy(t, degradedCountsAsUp)
+
+	t.Setenv("DEGRADED_COUNTS_AS_UP", "")
+	initUptimePolicy()
+	if !degradedCountsAsUp {
+		t.Error("degraded doesn't count as up by default")
+	}
+	t.Setenv("DEGRADED_COUNTS_AS_UP", "false")
+	initUptimePolicy()
+	if degradedCountsAsUp {
+		t.Error("DEGRADED_COUNTS_AS_UP=false ignored")
+	}
+}
+
+func TestCountsAsUp(t *testing.T) {
+	for _, policy := range []bool{true, false} {
+		withDegradedPolicy(t, policy)
+		if !countsAsUp("up") {
+			t.Errorf("policy %v: up doesn't count as up", policy)
+		}
+		if countsAsUp("down") {
+			t.Errorf("policy %v: down counts as up", policy)
+		}
+		if countsAsUp(StatusDegraded) != policy {
+			t.Errorf("policy %v: degraded counts as up = %v", policy, !policy)
+		}
+	}
+}
+
+func TestMonitorStatsViewFollowsDegradedPolicy(t *testing.T) {
+	db := newTestDB(t)
+
+	monitor := Monitor{Name: "slow", URL: "https://example.com", Status: StatusDegraded}
+	if err := db.Create(&monitor).Error; err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC()
+	history := []CheckHistory{
+		{MonitorID: monitor.ID, Status: "up", CreatedAt: now.Add(-3 * time.Hour)},
+		{MonitorID: monitor.ID, Status: StatusDegraded, CreatedAt: now.Add(-2 * time.Hour)},
+		{MonitorID: monitor.ID, Status: StatusDegraded, CreatedAt: now.Add(-time.Hour)},
+		{MonitorID: monitor.ID, Status: "down", CreatedAt: now.Add(-time.Minute)},
+	}
+	if err := db.Create(&history).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		policy   bool
+		upChecks int64
+	}{{true, 3}, {false, 1}} {
+		withDegradedPolicy(t, tc.policy)
+		if err := createAggregationViews(db); err != nil {
+			t.Fatal(err)
+		}
+		var got int64
+		if err := db.Raw("SELECT up_checks FROM monitor_stats_24h WHERE monitor_id = ?", monitor.ID).Scan(&got).Error; err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.upChecks {
+			t.Errorf("policy %v: up_checks = %d, want %d", tc.policy, got, tc.upChecks)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// windowUptime returns a monitor's uptime percentage since a point in time, or nil without checks
+// monitorCheckCounts only reads buckets for hours before the oldest raw check, so checks still kept
+// raw after bucketing aren't counted twice
+func windowUptime(monitorID uint, since time.Time) *float64 {
+	total, up := monitorCheckCounts(monitorID, since)
+	if total == 0 {
+		return nil
+	}
+	uptime := float64(up) / float64(total) * 100
+	return &uptime
+}
+
+// monitorUptime computes a monitor's uptime over the last 24 hours, 7, 30 and 90 days
+func monitorUptime(monitorID uint, now time.Time) MonitorUptime {
+	return MonitorUptime{
+		MonitorID: monitorID,
+		Uptime24h: windowUptime(monitorID, now.Add(-24*time.Hour)),
+		Uptime7d:  windowUptime(monitorID, now.AddDate(0, 0, -7)),
+		Uptime30d: windowUptime(monitorID, now.AddDate(0, 0, -30)),
+		Uptime90d: windowUptime(monitorID, now.AddDate(0, 0, -90)),
+	}
+}
+
+// apiMonitorUptime handles GET requests for a monitor's uptime over multiple windows
+func apiMonitorUptime(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", id).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id == "" {
+		log.Warn().Msg("[API] ERROR GET /api/monitor/uptime: Missing monitor ID")
+		http.Error(w, "Monitor ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var monitor Monitor
+	if err := db.Select("id").First(&monitor, id).Error; err != nil {
+		log.Warn().Str("id", id).Err(err).Msg("[API] ERROR GET /api/monitor/uptime: Monitor not found")
+		http.Error(w, "Monitor not found", http.StatusNotFound)
+		return
+	}
+
+	uptime := monitorUptime(monitor.ID, time.Now())
+	log.Info().Str("id", id).Msg("[API] GET /api/monitor/uptime")
+	if err := encodeJSONWithCompression(w, r, uptime); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding monitor uptime")
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AlertChanged is the alert kind for an unexpected change of a watched monitor's ETag or Last-Modified
+const AlertChanged = "changed"
+
+// Cache validator headers watched by monitors with WatchValidators
+const (
+	ValidatorETag         = "ETag"
+	ValidatorLastModified = "Last-Modified"
+)
+
+// trackValidatorChanges compares the ETag and Last-Modified of a check response with the stored values,
+// recording each change and alerting once when either changed (an unplanned deploy of a static asset)
+// A missing header keeps the stored value, since failed checks and some servers omit them
+func trackValidatorChanges(monitor *Monitor, header http.Header, updates map[string]interface{}, now time.Time) {
+	if !monitor.WatchValidators || header == nil {
+		return
+	}
+
+	validators := []struct {
+		name     string
+		column   string
+		previous string
+	}{
+		{ValidatorETag, "last_etag", monitor.LastETag},
+		{ValidatorLastModified, "last_modified", monitor.LastModified},
+	}
+
+	changed := false
+	alerted := *monitor
+	for _, validator := range validators {
+		value := strings.TrimSpace(header.Get(validator.name))
+		if value == "" || value == validator.previous {
+			continue
+		}
+		updates[validator.column] = value
+		switch validator.name {
+		case ValidatorETag:
+			alerted.LastETag = value
+		case ValidatorLastModified:
+			alerted.LastModified = value
+		}
+		// The first value seen is the baseline, not a change
+		if validator.previous != "" {
+			recordValidatorChange(monitor, validator.name, validator.previous, value, now)
+			changed = true
+		}
+	}
+
+	if changed {
+		updates["validator_changed_at"] = now
+		alerted.ValidatorChangedAt = &now
+		go sendAlert(AlertChanged, alerted)
+	}
+}
+
+// recordValidatorChange stores a validator change event and notifies SSE clients
+func recordValidatorChange(monitor *Monitor, header, oldValue, newValue string, at time.Time) {
+	change := ValidatorChange{
+		MonitorID: monitor.ID,
+		Header:    header,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		CreatedAt: at,
+	}
+	if err := db.Create(&change).Error; err != nil {
+		log.Error().Err(err).Uint("monitor_id", monitor.ID).Msg("[Check] Failed to record validator change")
+		return
+	}
+
+	log.Warn().Uint("monitor_id", monitor.ID).Str("name", monitor.Name).Str("header", header).
+		Str("old_value", oldValue).Str("new_value", newValue).
+		Msg("[Check] Cache validator changed")
+
+	broadcastUpdate("validator_change", change)
+}
+
+// apiMonitorValidatorChanges handles GET requests for a monitor's ETag/Last-Modified change history
+func apiMonitorValidatorChanges(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	log.Info().Str("method", r.Method).Str("path", r.URL.Path).Str("id", id).Msg("[API] Request")
+
+	setJSONHeaders(w)
+
+	if r.Method != http.MethodGet {
+		log.Warn().Str("method", r.Method).Msg("[API] ERROR Method not allowed")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id == "" {
+		log.Warn().Msg("[API] ERROR GET /api/monitor/validator-changes: Missing monitor ID")
+		http.Error(w, "Monitor ID is required", http.StatusBadRequest)
+		return
+	}
+
+	changes := []ValidatorChange{}
+	if err := db.Where("monitor_id = ?", id).Order("created_at DESC").Limit(100).Find(&changes).Error; err != nil {
+		log.Error().Err(err).Str("id", id).Msg("[API] ERROR GET /api/monitor/validator-changes: Failed to query validator changes")
+		http.Error(w, "Failed to load validator changes", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info().Str("id", id).Int("changes", len(changes)).Msg("[API] GET /api/monitor/validator-changes")
+	if err := encodeJSONWithCompression(w, r, changes); err != nil {
+		log.Error().Err(err).Msg("[API] ERROR encoding validator changes")
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// readVersionHeader extracts the monitor's version header from a check response
+func readVersionHeader(monitor *Monitor, header http.Header) string {
+	if monitor.VersionHeader == "" || header == nil {
+		return ""
+	}
+	return strings.TrimSpace(header.Get(monitor.VersionHeader))
+}
+
+// recordVersionChange stores a version change event and notifies SSE clients
+func recordVersionChange(monitor *Monitor, oldVersion, newVersion string, at time.Time) {
+	change := VersionChange{
+		MonitorID:  monitor.ID,
+		OldVersion: oldVersion,
+		NewVersion: newVersion,
+		CreatedAt:  at,
+	}
+	if err := db.Create(&change).Error; err != nil {
+		log.Error().Err(err).Uint("monitor_id", monitor.ID).Msg("[Check] Failed to record version change")
+		return
+	}
+
+	log.Info().Uint("monitor_id", monitor.ID).Str("name", monitor.Name).
+		Str("old_version", oldVersion).Str("new_version", newVersion).
+		Msg("[Check] Version changed")
+
+	broadcastUpdate("version_change", change)
+}
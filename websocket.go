@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrorCategoryWebSocket marks WebSocket checks whose handshake or ping/pong was rejected or malformed
+const ErrorCategoryWebSocket = "websocket"
+
+const (
+	webSocketPingPayload  = "nanostatus"
+	maxWebSocketFrames    = 64      // Messages read while waiting for the pong before giving up
+	maxWebSocketFrameSize = 1 << 20 // Largest message skipped while waiting for the pong
+)
+
+// errWebSocketPong ends the read loop once the pong arrives
+var errWebSocketPong = errors.New("pong received")
+
+// isWebSocketURL reports whether rawURL uses the ws:// or wss:// scheme
+func isWebSocketURL(rawURL string) bool {
+	scheme := urlScheme(rawURL)
+	return scheme == "ws" || scheme == "wss"
+}
+
+// checkWebSocket performs the WebSocket opening handshake with a ws:// or wss:// URL and, with
+// WebSocketPing, sends a ping and waits for the pong. The handshake time is the response time
+func checkWebSocket(monitor *Monitor, rawURL string, inject http.Header) endpointCheck {
+	result := endpointCheck{EndpointResult: EndpointResult{URL: rawURL, Status: "down"}}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Hostname() == "" {
+		log.Debug().Uint("monitor_id", monitor.ID).Str("url", rawURL).Msg("[Check] WebSocket URL must be ws://host/path or wss://host/path")
+		result.ErrorCategory = ErrorCategoryConfig
+		result.Error = ErrorCategoryConfig + ": invalid WebSocket URL"
+		return result
+	}
+
+	timeout := monitor.checkTimeout()
+	start := time.Now()
+	deadline := start.Add(timeout)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	dialer := websocket.Dialer{
+		NetDialContext:   tcpDialer(monitor, timeout).DialContext,
+		TLSClientConfig:  &tls.Config{ServerName: parsedURL.Hostname()},
+		HandshakeTimeout: timeout,
+	}
+	header := http.Header{}
+	for name, values := range inject {
+		header[name] = values
+	}
+	header.Set("User-Agent", "NanoStatus/1.0")
+
+	conn, resp, err := dialer.DialContext(ctx, rawURL, header)
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+		result.Header = resp.Header
+		result.Protocol = resp.Proto
+	}
+	if err != nil {
+		// A rejected upgrade (not 101, or a bad Sec-WebSocket-Accept) comes with the response
+		if errors.Is(err, websocket.ErrBadHandshake) && resp != nil {
+			log.Debug().Err(err).Uint("monitor_id", monitor.ID).Str("url", rawURL).Int("status_code", resp.StatusCode).Msg("[Check] WebSocket handshake rejected")
+			result.ResponseTime = int(time.Since(start).Milliseconds())
+			result.TTFB, result.TotalTime = result.ResponseTime, result.ResponseTime
+			result.ErrorCategory = ErrorCategoryWebSocket
+			if resp.StatusCode != http.StatusSwitchingProtocols {
+				result.Error = fmt.Sprintf("%s: handshake returned HTTP %d instead of 101", ErrorCategoryWebSocket, resp.StatusCode)
+			} else {
+				result.Error = ErrorCategoryWebSocket + ": invalid handshake response"
+			}
+			return result
+		}
+		log.Debug().Err(err).Uint("monitor_id", monitor.ID).Str("url", rawURL).Msg("[Check] WebSocket handshake failed")
+		result.setRequestError(err)
+		if rawURL == monitor.URL && urlScheme(rawURL) == "wss" {
+			result.Certificate = certificateFromError(err, parsedURL.Hostname())
+		}
+		return result
+	}
+	defer conn.Close()
+	result.ResponseTime = int(time.Since(start).Milliseconds())
+	result.TTFB, result.TotalTime = result.ResponseTime, result.ResponseTime
+	if tlsConn, ok := conn.UnderlyingConn().(*tls.Conn); ok && rawURL == monitor.URL {
+		state := tlsConn.ConnectionState()
+		result.Certificate = certificateFromState(&state, parsedURL.Hostname())
+	}
+
+	if monitor.WebSocketPing {
+		if err := webSocketPingPong(conn, deadline); err != nil {
+			log.Debug().Err(err).Uint("monitor_id", monitor.ID).Str("url", rawURL).Msg("[Check] WebSocket ping failed")
+			var netErr net.Error
+			if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				result.setRequestError(err)
+			} else {
+				result.ErrorCategory = ErrorCategoryWebSocket
+				result.Error = ErrorCategoryWebSocket + ": " + err.Error()
+			}
+			return result
+		}
+		result.TotalTime = int(time.Since(start).Milliseconds())
+	}
+
+	// Close politely so the server doesn't log an abnormal disconnect; failures here don't matter
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), deadline)
+
+	result.Status = "up"
+	return result
+}
+
+// webSocketPingPong sends a ping and reads until the matching pong, skipping messages the server
+// sends meanwhile
+func webSocketPingPong(conn *websocket.Conn, deadline time.Time) error {
+	conn.SetReadLimit(maxWebSocketFrameSize)
+	conn.SetReadDeadline(deadline)
+	conn.SetPongHandler(func(data string) error {
+		if data == webSocketPingPayload {
+			return errWebSocketPong
+		}
+		return nil
+	})
+	if err := conn.WriteControl(websocket.PingMessage, []byte(webSocketPingPayload), deadline); err != nil {
+		return err
+	}
+
+	for i := 0; i < maxWebSocketFrames; i++ {
+		_, _, err := conn.NextReader()
+		switch {
+		case errors.Is(err, errWebSocketPong):
+			return nil
+		case errors.As(err, new(*websocket.CloseError)):
+			return fmt.Errorf("server closed the connection instead of answering the ping")
+		case errors.Is(err, websocket.ErrReadLimit):
+			return fmt.Errorf("message exceeds %d bytes", maxWebSocketFrameSize)
+		case err != nil:
+			return err
+		}
+	}
+	return fmt.Errorf("no pong within %d messages", maxWebSocketFrames)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestCheckWebSocket(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// Send a message before the pong, which the check must skip
+		conn.WriteMessage(websocket.TextMessage, []byte("hello"))
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	mux.HandleFunc("/close", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetPingHandler(func(string) error {
+			return conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""))
+		})
+		conn.ReadMessage()
+	})
+	mux.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	base := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	cases := []struct {
+		path     string
+		ping     bool
+		status   string
+		category string
+	}{
+		{"/echo", false, "up", ""},
+		{"/echo", true, "up", ""},
+		{"/close", true, "down", ErrorCategoryWebSocket},
+		{"/plain", false, "down", ErrorCategoryWebSocket},
+	}
+	for _, tc := range cases {
+		rawURL := base + tc.path
+		result := checkWebSocket(&Monitor{URL: rawURL, TimeoutSeconds: 5, WebSocketPing: tc.ping}, rawURL, nil)
+		if result.Status != tc.status || result.ErrorCategory != tc.category {
+			t.Errorf("%s (ping %v): status %q category %q (%s), want %q %q",
+				tc.path, tc.ping, result.Status, result.ErrorCategory, result.Error, tc.status, tc.category)
+		}
+	}
+}